@@ -0,0 +1,70 @@
+package aglx
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/biwakonbu/aglx/internal/claude"
+	"github.com/biwakonbu/aglx/internal/skill"
+)
+
+func TestSeverityMatchesInternal(t *testing.T) {
+	for _, sev := range []Severity{SeverityOff, SeverityWarning, SeverityError} {
+		if got := skill.Severity(sev).String(); got != sev.String() {
+			t.Errorf("Severity(%d) = %s, internal %s", sev, sev, got)
+		}
+		if parsed, err := ParseSeverity(sev.String()); err != nil || parsed != sev {
+			t.Errorf("ParseSeverity(%q) = %v, %v", sev, parsed, err)
+		}
+	}
+}
+
+func TestValidate_EditedFields(t *testing.T) {
+	fsys := fstest.MapFS{"SKILL.md": {Data: []byte("---\nname: Demo\ndescription: Demo.\n---\n")}}
+	s, err := ParseFS(fsys, "demo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Validate(s, nil).Valid() {
+		t.Fatal("expected the uppercase name to be invalid")
+	}
+
+	// Edits to the exported fields are validated; positions still come from the file.
+	s.Name = "demo"
+	if result := Validate(s, nil); !result.Valid() {
+		t.Errorf("expected the edited skill to be valid, got %v", result.Errors)
+	}
+	s.Name = "demo-"
+	result := Validate(s, nil)
+	if result.Valid() || result.Errors[0].Position.Line != 2 {
+		t.Errorf("expected a positioned error for the edited name, got %v", result.Errors)
+	}
+
+	// A Skill built by hand validates without a file.
+	if result := Validate(&Skill{Name: "hand-made", Description: "By hand."}, nil); !result.Valid() {
+		t.Errorf("unexpected errors: %v", result.Errors)
+	}
+}
+
+func TestOptions_ClaudeMdSeverities(t *testing.T) {
+	opts := (&Options{Severities: map[string]Severity{
+		claude.RuleBodyEmpty: SeverityOff,
+		skill.RuleBodySize:   SeverityError,
+	}}).checkOptions()
+	if !opts.ClaudeMd.Disabled[claude.RuleBodyEmpty] {
+		t.Error("expected the CLAUDE.md rule to be disabled")
+	}
+	if opts.Severities[skill.RuleBodySize] != skill.SeverityError || len(opts.Severities) != 1 {
+		t.Errorf("unexpected skill severities: %v", opts.Severities)
+	}
+}
+
+func TestRules(t *testing.T) {
+	rules := Rules()
+	if len(rules) != len(skill.Rules())+len(claude.RuleDescriptions) {
+		t.Fatalf("got %d rules", len(rules))
+	}
+	if rules[0].ID != skill.Rules()[0].ID || rules[len(rules)-1].DefaultSeverity != SeverityWarning {
+		t.Errorf("unexpected rules: %+v", rules)
+	}
+}
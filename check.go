@@ -0,0 +1,119 @@
+package aglx
+
+import (
+	"context"
+	"io/fs"
+
+	"github.com/biwakonbu/aglx/internal/checker"
+	"github.com/biwakonbu/aglx/internal/prompt"
+	"github.com/biwakonbu/aglx/internal/skill"
+)
+
+// Result is the outcome of checking a skill directory: SKILL.md against the
+// selected specifications, plus CLAUDE.md if present.
+type Result struct {
+	// Path is the skill directory. For archives it is "<archive>/<folder>".
+	Path string
+
+	// Skill is the parsed SKILL.md (nil if ParseError is set).
+	Skill *Skill
+
+	// ParseError is set if SKILL.md (or the archive) could not be read or parsed.
+	ParseError error
+
+	// AgentSkills and ClaudeCode hold the SKILL.md findings per
+	// specification; a result is nil if that specification was not checked.
+	AgentSkills *ValidationResult
+	ClaudeCode  *ValidationResult
+
+	// ClaudeMd holds the CLAUDE.md findings (nil if there is no CLAUDE.md).
+	ClaudeMd *ClaudeMdResult
+
+	// ClaudeMdError is set if CLAUDE.md exists but could not be read.
+	ClaudeMdError error
+}
+
+// ClaudeMdResult holds the findings for a CLAUDE.md file. CLAUDE.md checks
+// only produce warnings.
+type ClaudeMdResult struct {
+	// Path is the CLAUDE.md file.
+	Path string
+
+	Warnings []Finding
+}
+
+// Valid reports whether everything could be parsed and no checked
+// specification reported errors. Warnings do not make a result invalid.
+func (r *Result) Valid() bool {
+	if r.ParseError != nil || r.ClaudeMdError != nil {
+		return false
+	}
+	for _, vr := range []*ValidationResult{r.AgentSkills, r.ClaudeCode} {
+		if vr != nil && !vr.Valid() {
+			return false
+		}
+	}
+	return true
+}
+
+// Check validates the skill directory at path, which may also be a .zip,
+// .tar.gz or .tgz bundle containing a single skill folder. Archives are
+// read in memory with path and size limits; nothing is extracted.
+func Check(path string, opts *Options) *Result {
+	return newResult(checker.CheckWithOptions(path, opts.checkOptions()))
+}
+
+// CheckFS validates the skill directory held by fsys (rooted at the
+// directory). dirPath is the path the results are reported under.
+func CheckFS(fsys fs.FS, dirPath string, opts *Options) *Result {
+	return newResult(checker.CheckFS(fsys, dirPath, opts.checkOptions()))
+}
+
+// CheckMultiple checks several skill directories or bundles in parallel
+// (see Options.Concurrency). Results are in the order of paths. If ctx is
+// cancelled, no new checks are started and ctx.Err() is returned without
+// results.
+func CheckMultiple(ctx context.Context, paths []string, opts *Options) ([]*Result, error) {
+	results, err := checker.CheckMultipleContext(ctx, paths, opts.checkOptions())
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*Result, len(results))
+	for i, r := range results {
+		out[i] = newResult(r)
+	}
+	return out, nil
+}
+
+func newResult(r *checker.Result) *Result {
+	result := &Result{
+		Path:          r.Path,
+		Skill:         newSkill(r.Skill),
+		ParseError:    r.ParseError,
+		ClaudeMdError: r.ClaudeMdError,
+	}
+	if r.AgentSkillsResult != nil {
+		result.AgentSkills = newValidationResult(skill.SpecAgentSkills, r.AgentSkillsResult.ValidationResult)
+	}
+	if r.ClaudeCodeResult != nil {
+		result.ClaudeCode = newValidationResult(skill.SpecClaudeCode, r.ClaudeCodeResult.ValidationResult)
+	}
+	if cr := r.ClaudeMdResult; cr != nil {
+		result.ClaudeMd = &ClaudeMdResult{Path: cr.Skill.Path, Warnings: make([]Finding, len(cr.Warnings))}
+		for i, w := range cr.Warnings {
+			result.ClaudeMd.Warnings[i] = Finding{Rule: w.Rule, Field: w.Field, Message: w.Message, Position: Position(w.Pos)}
+		}
+	}
+	return result
+}
+
+// GenerateXMLPrompt renders the <available_skills> block that tells an
+// agent which skills exist, with each skill's name, description and
+// SKILL.md location.
+func GenerateXMLPrompt(skills []*Skill) (string, error) {
+	in := make([]*skill.Skill, len(skills))
+	for i, s := range skills {
+		in[i] = s.internal()
+	}
+	return prompt.GenerateXMLPrompt(in)
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/biwakonbu/aglx/internal/bundle"
+	"github.com/biwakonbu/aglx/internal/checker"
+	aglxerrors "github.com/biwakonbu/aglx/internal/errors"
+	"github.com/biwakonbu/aglx/internal/skill"
+)
+
+func runBundle(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("bundle", "[flags] <path>", stderr)
+	output := fs.String("output", "", "archive to write (default: <name>.zip or <name>.tar.gz in the working directory)")
+	fs.StringVar(output, "o", "", "shorthand for --output")
+	formatName := fs.String("format", "", "archive format: zip or tar.gz (default: from --output, else zip)")
+	force := fs.Bool("force", false, "bundle even if validation fails")
+	specName := fs.String("spec", "auto", "specification to validate against: auto, agent-skills, claude-code")
+	configs := addConfigFlags(fs)
+	policies := addPolicyFlag(fs)
+
+	paths, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(paths) != 1 {
+		fs.Usage()
+		return aglxerrors.NewUsageError("bundle requires exactly one path")
+	}
+	dir := paths[0]
+
+	format, err := archiveFormat(*formatName, *output)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := configs.load()
+	if err != nil {
+		return err
+	}
+	if err := policies.apply(cfg); err != nil {
+		return err
+	}
+	opts := cfg.CheckOptions()
+
+	// An explicit --spec overrides the config file.
+	if flagWasSet(fs, "spec") {
+		spec, err := skill.ParseSpec(*specName)
+		if err != nil {
+			return aglxerrors.NewUsageError(err.Error())
+		}
+		opts.Spec = spec
+	}
+
+	// Only bundle skills that pass validation, unless forced.
+	results := []*checker.Result{checker.CheckWithOptions(dir, opts)}
+	if err := validationOutcome(results); err != nil {
+		writeQuiet(stderr, results)
+		if !*force {
+			var cliErr *aglxerrors.CLIError
+			if errors.As(err, &cliErr) {
+				cliErr.Message = fmt.Sprintf("%s does not pass validation; fix it or use --force to bundle anyway", dir)
+			}
+			return err
+		}
+		fmt.Fprintf(stderr, "aglx: bundling %s despite validation failures (--force)\n", dir)
+	}
+
+	target := *output
+	if target == "" {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return err
+		}
+		target = filepath.Base(abs) + "." + string(format)
+	}
+
+	result, err := writeArchive(target, dir, &bundle.Options{Format: format, Exclude: []string{target}})
+	if err != nil {
+		return err
+	}
+
+	for _, f := range result.Manifest.Files {
+		fmt.Fprintf(stdout, "  + %s\n", f.Path)
+	}
+	for _, e := range result.Excluded {
+		fmt.Fprintf(stdout, "  - %s (%s)\n", e.Path, e.Reason)
+	}
+	fmt.Fprintf(stdout, "Wrote %s (%d file(s))\n", target, len(result.Manifest.Files))
+	return nil
+}
+
+// archiveFormat picks the format from --format, then from the output name.
+func archiveFormat(name, output string) (bundle.Format, error) {
+	if name != "" {
+		format, err := bundle.ParseFormat(name)
+		if err != nil {
+			return "", aglxerrors.NewUsageError(err.Error())
+		}
+		if inferred, ok := bundle.FormatFromPath(output); ok && inferred != format {
+			return "", aglxerrors.NewUsageError(fmt.Sprintf("--format %s conflicts with output file %s", name, output))
+		}
+		return format, nil
+	}
+	if format, ok := bundle.FormatFromPath(output); ok {
+		return format, nil
+	}
+	if output != "" {
+		return "", aglxerrors.NewUsageError(fmt.Sprintf("cannot infer the archive format of %s; use --format", output))
+	}
+	return bundle.FormatZip, nil
+}
+
+// writeArchive writes the bundle to a temporary file next to target and
+// renames it into place, so a failed run never leaves a partial archive.
+func writeArchive(target, dir string, opts *bundle.Options) (*bundle.Result, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".aglx-bundle-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	opts.Exclude = append(opts.Exclude, tmp.Name())
+	result, err := bundle.Write(tmp, dir, opts)
+	if cerr := tmp.Close(); err == nil && cerr != nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	return result, nil
+}
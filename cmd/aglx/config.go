@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/biwakonbu/aglx/internal/config"
+	aglxerrors "github.com/biwakonbu/aglx/internal/errors"
+)
+
+// configFlags holds the flags selecting the project config file.
+type configFlags struct {
+	path     string
+	disabled bool
+}
+
+func addConfigFlags(fs *flag.FlagSet) *configFlags {
+	c := &configFlags{}
+	fs.StringVar(&c.path, "config", "", "config file (default: nearest .aglx.yaml searched upward from the working directory)")
+	fs.BoolVar(&c.disabled, "no-config", false, "ignore config files")
+	return c
+}
+
+// load returns the selected config, or an empty config when none applies.
+func (c *configFlags) load() (*config.Config, error) {
+	if c.disabled {
+		if c.path != "" {
+			return nil, aglxerrors.NewUsageError("--config conflicts with --no-config")
+		}
+		return &config.Config{}, nil
+	}
+
+	var cfg *config.Config
+	var err error
+	if c.path != "" {
+		cfg, err = config.Load(c.path)
+	} else {
+		cfg, err = config.LoadNearest(".")
+	}
+	if err != nil {
+		return nil, configError(err)
+	}
+	return cfg, nil
+}
+
+// policyFlag holds the --policy flag of the commands that validate skills.
+type policyFlag struct {
+	path string
+}
+
+func addPolicyFlag(fs *flag.FlagSet) *policyFlag {
+	p := &policyFlag{}
+	fs.StringVar(&p.path, "policy", "", "allowed-tools security policy file (overrides policy in the config file)")
+	return p
+}
+
+// apply replaces the policy of cfg with the file given by --policy, if any.
+func (p *policyFlag) apply(cfg *config.Config) error {
+	if p.path == "" {
+		return nil
+	}
+	pol, err := config.LoadPolicy(p.path)
+	if err != nil {
+		return configError(err)
+	}
+	cfg.Policy = pol
+	return nil
+}
+
+// configError reports an unusable config file with the usage exit code.
+func configError(err error) error {
+	return &aglxerrors.CLIError{Message: err.Error(), ExitCode: aglxerrors.ExitUsageError}
+}
+
+// flagWasSet reports whether a flag was given explicitly on the command line.
+func flagWasSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/biwakonbu/aglx/internal/discovery"
+	aglxerrors "github.com/biwakonbu/aglx/internal/errors"
+)
+
+// stringList is a repeatable string flag.
+type stringList []string
+
+func (s *stringList) String() string { return strings.Join(*s, ",") }
+
+func (s *stringList) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+// discoveryFlags holds the flags shared by commands that accept skill paths.
+type discoveryFlags struct {
+	recursive      bool
+	exclude        stringList
+	noGitignore    bool
+	followSymlinks bool
+}
+
+func addDiscoveryFlags(fs *flag.FlagSet) *discoveryFlags {
+	df := &discoveryFlags{}
+	fs.BoolVar(&df.recursive, "recursive", false, "search each path recursively for directories containing SKILL.md")
+	fs.BoolVar(&df.recursive, "r", false, "shorthand for --recursive")
+	fs.Var(&df.exclude, "exclude", "`pattern` (.gitignore syntax) to skip during recursive search (repeatable)")
+	fs.BoolVar(&df.noGitignore, "no-gitignore", false, "do not apply .gitignore files during recursive search")
+	fs.BoolVar(&df.followSymlinks, "follow-symlinks", false, "follow symbolic links to directories during recursive search")
+	return df
+}
+
+// resolve expands paths into skill directories when --recursive is set.
+// Discovery errors are reported on stderr; finding no skills at all is an error.
+func (df *discoveryFlags) resolve(paths []string, stderr io.Writer) ([]string, error) {
+	if !df.recursive {
+		return paths, nil
+	}
+	for _, p := range df.exclude {
+		if err := discovery.ValidatePattern(p); err != nil {
+			return nil, aglxerrors.NewUsageError(fmt.Sprintf("invalid --exclude: %v", err))
+		}
+	}
+
+	opts := &discovery.Options{
+		Exclude:      df.exclude,
+		UseGitignore: !df.noGitignore,
+	}
+	if df.followSymlinks {
+		opts.Symlinks = discovery.SymlinkFollow
+	}
+
+	dirs, errs := discovery.DiscoverMultiple(paths, opts)
+	for _, err := range errs {
+		fmt.Fprintf(stderr, "aglx: %v\n", err)
+	}
+	if len(dirs) == 0 {
+		return nil, aglxerrors.NewParseError(fmt.Sprintf("no SKILL.md found under %s", strings.Join(paths, ", ")))
+	}
+	return dirs, nil
+}
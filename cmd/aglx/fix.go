@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	aglxerrors "github.com/biwakonbu/aglx/internal/errors"
+	"github.com/biwakonbu/aglx/internal/fix"
+	"github.com/biwakonbu/aglx/internal/skill"
+)
+
+func runFix(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("fix", "[flags] <path>...", stderr)
+	dryRun := fs.Bool("dry-run", false, "show the changes as a unified diff without applying them")
+	renameDir := fs.Bool("rename-dir", false, "rename the directory to match the name instead of rewriting the name")
+	specName := fs.String("spec", "auto", "specification for the allowed-tools separator: auto (unchanged), agent-skills, claude-code")
+	discover := addDiscoveryFlags(fs)
+	configs := addConfigFlags(fs)
+
+	paths, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		fs.Usage()
+		return aglxerrors.NewUsageError("fix requires at least one path")
+	}
+
+	cfg, err := configs.load()
+	if err != nil {
+		return err
+	}
+	opts := &fix.Options{Spec: cfg.Spec, RenameDir: *renameDir, DryRun: *dryRun}
+
+	// An explicit --spec overrides the config file.
+	if flagWasSet(fs, "spec") {
+		spec, err := skill.ParseSpec(*specName)
+		if err != nil {
+			return aglxerrors.NewUsageError(err.Error())
+		}
+		opts.Spec = spec
+	}
+
+	paths, err = discover.resolve(paths, stderr)
+	if err != nil {
+		return err
+	}
+
+	var failed bool
+	var changes, fixed int
+	for _, path := range paths {
+		fmt.Fprintf(stdout, "=== %s ===\n", path)
+		result, err := fix.Fix(path, opts)
+		if err != nil {
+			fmt.Fprintf(stdout, "  ✗ %v\n\n", err)
+			failed = true
+			continue
+		}
+		writeFixResult(stdout, result, *dryRun)
+		if result.Changed() {
+			changes += len(result.Changes)
+			fixed++
+		}
+	}
+
+	verb := "Fixed"
+	if *dryRun {
+		verb = "Would fix"
+	}
+	fmt.Fprintf(stdout, "=== Summary ===\n%s %d issue(s) in %d of %d skill(s)\n", verb, changes, fixed, len(paths))
+
+	if failed {
+		return &aglxerrors.CLIError{ExitCode: aglxerrors.ExitParseError}
+	}
+	return nil
+}
+
+// writeFixResult lists the changes of one skill, followed by the SKILL.md
+// diff in dry-run mode.
+func writeFixResult(w io.Writer, result *fix.Result, dryRun bool) {
+	for _, c := range result.Changes {
+		fmt.Fprintf(w, "  ✓ %s: %s%s\n", c.Field, c.Message, ruleSuffix(c.Rule))
+	}
+	for _, c := range result.Skipped {
+		fmt.Fprintf(w, "  ! %s: %s%s\n", c.Field, c.Message, ruleSuffix(c.Rule))
+	}
+	if len(result.Changes) == 0 && len(result.Skipped) == 0 {
+		fmt.Fprintf(w, "  - nothing to fix\n")
+	}
+	if dryRun {
+		if diff := result.Diff(); diff != "" {
+			fmt.Fprintf(w, "\n%s", diff)
+		}
+	}
+	fmt.Fprintln(w)
+}
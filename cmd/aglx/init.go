@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	aglxerrors "github.com/biwakonbu/aglx/internal/errors"
+	"github.com/biwakonbu/aglx/internal/scaffold"
+	"github.com/biwakonbu/aglx/internal/skill"
+)
+
+func runInit(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("init", "[flags] <name>", stderr)
+	parent := fs.String("dir", ".", "directory to create the skill in")
+	description := fs.String("description", "", "frontmatter description (default: a TODO placeholder)")
+	with := fs.String("with", "", "optional directories to create with starter files: comma-separated list of scripts, references, assets, or all")
+	templateDir := fs.String("template", "", "user template directory (default: built-in template)")
+	specName := fs.String("spec", "auto", "specification the frontmatter follows: auto, agent-skills, claude-code")
+	configs := addConfigFlags(fs)
+
+	names, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(names) != 1 {
+		fs.Usage()
+		return aglxerrors.NewUsageError("init requires exactly one skill name")
+	}
+
+	dirs, err := parseDirList(*with)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := configs.load()
+	if err != nil {
+		return err
+	}
+	opts := &scaffold.Options{
+		Name:        names[0],
+		Description: *description,
+		Spec:        cfg.Spec,
+		Dirs:        dirs,
+		TemplateDir: *templateDir,
+	}
+
+	// An explicit --spec overrides the config file.
+	if flagWasSet(fs, "spec") {
+		spec, err := skill.ParseSpec(*specName)
+		if err != nil {
+			return aglxerrors.NewUsageError(err.Error())
+		}
+		opts.Spec = spec
+	}
+
+	dir, files, err := scaffold.Create(*parent, opts)
+	var nameErr *scaffold.NameError
+	switch {
+	case errors.As(err, &nameErr), errors.Is(err, scaffold.ErrExists):
+		return aglxerrors.NewUsageError(err.Error())
+	case err != nil:
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Created %s\n", dir)
+	for _, f := range files {
+		fmt.Fprintf(stdout, "  %s\n", f)
+	}
+	fmt.Fprintf(stdout, "\nEdit %s/SKILL.md, then run 'aglx validate %s'.\n", dir, dir)
+	return nil
+}
+
+// parseDirList splits the --with value; "all" selects every optional directory.
+func parseDirList(s string) ([]string, error) {
+	var dirs []string
+	for _, d := range strings.Split(s, ",") {
+		switch d = strings.TrimSpace(d); {
+		case d == "":
+		case d == "all":
+			return slices.Clone(skill.OptionalDirs), nil
+		case slices.Contains(skill.OptionalDirs, d):
+			dirs = append(dirs, d)
+		default:
+			return nil, aglxerrors.NewUsageError(fmt.Sprintf("unknown directory %q for --with (expected %s or all)", d, strings.Join(skill.OptionalDirs, ", ")))
+		}
+	}
+	return dirs, nil
+}
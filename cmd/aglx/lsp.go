@@ -0,0 +1,66 @@
+package main
+
+import (
+	"io"
+	"os"
+
+	"github.com/biwakonbu/aglx/internal/config"
+	aglxerrors "github.com/biwakonbu/aglx/internal/errors"
+	"github.com/biwakonbu/aglx/internal/lsp"
+	"github.com/biwakonbu/aglx/internal/skill"
+)
+
+// runLSP serves the Language Server Protocol on stdin and stdout.
+func runLSP(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("lsp", "[flags]", stderr)
+	specName := fs.String("spec", "auto", "specification to validate against: auto, agent-skills, claude-code")
+	configs := addConfigFlags(fs)
+
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		fs.Usage()
+		return aglxerrors.NewUsageError("lsp takes no arguments (it communicates over stdin and stdout)")
+	}
+
+	var spec *skill.Spec
+	if flagWasSet(fs, "spec") {
+		s, err := skill.ParseSpec(*specName)
+		if err != nil {
+			return aglxerrors.NewUsageError(err.Error())
+		}
+		spec = &s
+	}
+
+	// By default each document uses the config file nearest to it. An
+	// explicit --config or --no-config applies to every document.
+	var fixed *config.Config
+	if configs.path != "" || configs.disabled {
+		if fixed, err = configs.load(); err != nil {
+			return err
+		}
+	}
+	load := func(dir string) (*config.Config, error) {
+		cfg := fixed
+		if cfg == nil {
+			var err error
+			if cfg, err = config.LoadNearest(dir); err != nil {
+				return nil, err
+			}
+		}
+		if spec != nil {
+			override := *cfg
+			override.Spec = *spec
+			cfg = &override
+		}
+		return cfg, nil
+	}
+
+	server := lsp.NewServer(&lsp.Options{Config: load, Version: version})
+	if err := server.Serve(os.Stdin, stdout); err != nil {
+		return aglxerrors.NewValidationError(err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+// Command aglx validates Agent Skills (SKILL.md) and Claude Skills (CLAUDE.md).
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	aglxerrors "github.com/biwakonbu/aglx/internal/errors"
+)
+
+// Set via -ldflags at build time (see .goreleaser.yaml).
+var (
+	version = "dev"
+	commit  = "none"
+	date    = "unknown"
+)
+
+const usageText = `aglx - Agent sKiLls eXaminer
+
+Usage:
+  aglx <command> [flags] [arguments]
+
+Commands:
+  validate <path>...    Validate SKILL.md and CLAUDE.md simultaneously
+  fix <path>...         Fix common SKILL.md violations in place
+  init <name>           Create a new skill from a template
+  bundle <path>         Package a validated skill into a zip or tar.gz archive
+  to-prompt <path>...   Generate XML prompt for AI agents
+  lsp                   Start the language server on stdin/stdout
+  rules                 List validation rules and their default severities
+  version               Show version information
+  help                  Show this help message
+
+Run 'aglx <command> -h' for command-specific flags.
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run dispatches the subcommand and returns the process exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usageText)
+		return int(aglxerrors.ExitUsageError)
+	}
+
+	var err error
+	switch args[0] {
+	case "validate":
+		err = runValidate(args[1:], stdout, stderr)
+	case "fix":
+		err = runFix(args[1:], stdout, stderr)
+	case "init":
+		err = runInit(args[1:], stdout, stderr)
+	case "bundle":
+		err = runBundle(args[1:], stdout, stderr)
+	case "to-prompt":
+		err = runToPrompt(args[1:], stdout, stderr)
+	case "rules":
+		err = runRules(args[1:], stdout, stderr)
+	case "lsp":
+		err = runLSP(args[1:], stdout, stderr)
+	case "version", "--version", "-v":
+		fmt.Fprintf(stdout, "aglx %s (commit: %s, built: %s)\n", version, commit, date)
+	case "help", "--help", "-h":
+		fmt.Fprint(stdout, usageText)
+	default:
+		err = aglxerrors.NewUsageError(fmt.Sprintf("unknown command %q", args[0]))
+		fmt.Fprint(stderr, usageText)
+	}
+
+	return exitCode(err, stderr)
+}
+
+// exitCode reports err on stderr and maps it to an exit code.
+func exitCode(err error, stderr io.Writer) int {
+	if err == nil {
+		return int(aglxerrors.ExitSuccess)
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return int(aglxerrors.ExitSuccess)
+	}
+
+	var cliErr *aglxerrors.CLIError
+	if errors.As(err, &cliErr) {
+		if cliErr.Message != "" {
+			fmt.Fprintf(stderr, "aglx: %s\n", cliErr.Message)
+		}
+		return int(cliErr.ExitCode)
+	}
+
+	fmt.Fprintf(stderr, "aglx: %v\n", err)
+	return int(aglxerrors.ExitParseError)
+}
+
+// parseArgs parses flags that may be interleaved with positional arguments,
+// so both `aglx validate --json ./a` and `aglx validate ./a --json` work.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	// Everything after a bare "--" is positional.
+	var tail []string
+	for i, arg := range args {
+		if arg == "--" {
+			args, tail = args[:i], args[i+1:]
+			break
+		}
+	}
+
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			// The FlagSet has already printed the error and usage.
+			return nil, &aglxerrors.CLIError{ExitCode: aglxerrors.ExitUsageError}
+		}
+		rest := fs.Args()
+		if len(rest) == 0 {
+			return append(positional, tail...), nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// newFlagSet creates a FlagSet that reports errors instead of exiting.
+func newFlagSet(name, usage string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage:\n  aglx %s %s\n\nFlags:\n", name, strings.TrimSpace(usage))
+		fs.PrintDefaults()
+	}
+	return fs
+}
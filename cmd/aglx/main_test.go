@@ -0,0 +1,531 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/biwakonbu/aglx/internal/checker"
+	"github.com/biwakonbu/aglx/internal/config"
+	aglxerrors "github.com/biwakonbu/aglx/internal/errors"
+	"github.com/biwakonbu/aglx/internal/skill"
+	"github.com/biwakonbu/aglx/internal/watch"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// checkGolden compares got with testdata/<name>.golden, rewriting it with -update.
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	golden := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with -update to create): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output does not match %s\n--- got ---\n%s\n--- want ---\n%s", golden, got, want)
+	}
+}
+
+func runCLI(t *testing.T, args ...string) (string, string, int) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return stdout.String(), stderr.String(), code
+}
+
+func TestRun_Golden(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode aglxerrors.ExitCode
+	}{
+		{"validate_valid", []string{"validate", "../../testdata/valid/pdf-processing", "../../testdata/valid/simple-skill"}, aglxerrors.ExitSuccess},
+		{"validate_invalid", []string{"validate", "../../testdata/invalid/uppercase-name"}, aglxerrors.ExitValidationError},
+		{"validate_warning", []string{"validate", "--spec", "agent-skills", "../../testdata/valid/with-hidden-files"}, aglxerrors.ExitSuccess},
+		{"validate_parse_error", []string{"validate", "../../testdata/invalid/invalid-yaml"}, aglxerrors.ExitParseError},
+		{"validate_quiet", []string{"validate", "../../testdata/valid/simple-skill", "../../testdata/invalid/hyphen-end", "--quiet"}, aglxerrors.ExitValidationError},
+		{"validate_json", []string{"validate", "--json", "../../testdata/valid/with-metadata", "../../testdata/invalid/missing-description"}, aglxerrors.ExitValidationError},
+		{"validate_sarif", []string{"validate", "--format", "sarif", "../../testdata/invalid/hyphen-start", "../../testdata/valid/large-body"}, aglxerrors.ExitValidationError},
+		{"validate_junit", []string{"validate", "--format", "junit", "../../testdata/valid/simple-skill", "../../testdata/invalid/missing-name"}, aglxerrors.ExitValidationError},
+		{"validate_checkstyle", []string{"validate", "--format", "checkstyle", "../../testdata/valid/simple-skill", "../../testdata/invalid/missing-name"}, aglxerrors.ExitValidationError},
+		{"validate_config", []string{"validate", "--config", "../../testdata/config/claude-code/.aglx.yaml", "../../testdata/valid/large-body", "../../testdata/valid/with-hidden-files"}, aglxerrors.ExitValidationError},
+		{"validate_policy", []string{"validate", "--spec", "agent-skills", "--policy", "../../testdata/config/policy/security.yaml", "../../testdata/valid/broad-tools"}, aglxerrors.ExitValidationError},
+		{"validate_references", []string{"validate", "--spec", "claude-code", "../../testdata/invalid/broken-references"}, aglxerrors.ExitValidationError},
+		{"validate_suppressions", []string{"validate", "--quiet", "../../testdata/valid/with-suppressions"}, aglxerrors.ExitSuccess},
+		{"rules", []string{"rules"}, aglxerrors.ExitSuccess},
+		{"fix_dry_run", []string{"fix", "--dry-run", "../../testdata/invalid/uppercase-name", "../../testdata/invalid/missing-name", "../../testdata/valid/simple-skill"}, aglxerrors.ExitSuccess},
+		{"to_prompt", []string{"to-prompt", "../../testdata/valid/pdf-processing", "../../testdata/valid/simple-skill"}, aglxerrors.ExitSuccess},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr, code := runCLI(t, tt.args...)
+			if code != int(tt.wantCode) {
+				t.Errorf("exit code = %d, want %d (stderr: %s)", code, tt.wantCode, stderr)
+			}
+			checkGolden(t, tt.name, []byte(stdout))
+		})
+	}
+}
+
+func TestRun_JSONIsValid(t *testing.T) {
+	stdout, _, _ := runCLI(t, "validate", "--json", "../../testdata/valid/simple-skill", "../../testdata/invalid/no-frontmatter")
+
+	var report jsonReport
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+	}
+	if report.Summary.Total != 2 || report.Summary.Failed != 1 {
+		t.Errorf("unexpected summary: %+v", report.Summary)
+	}
+	if report.Results[1].ParseError == "" {
+		t.Error("expected parse_error for no-frontmatter")
+	}
+}
+
+func TestRun_UsageErrors(t *testing.T) {
+	tests := [][]string{
+		nil,
+		{"unknown"},
+		{"validate"},
+		{"validate", "--spec", "bogus", "../../testdata/valid/simple-skill"},
+		{"validate", "--no-such-flag", "../../testdata/valid/simple-skill"},
+		{"validate", "--format", "xml", "../../testdata/valid/simple-skill"},
+		{"validate", "--json", "--format", "sarif", "../../testdata/valid/simple-skill"},
+		{"validate", "--jobs", "-1", "../../testdata/valid/simple-skill"},
+		{"validate", "--watch", "--format", "json", "../../testdata/valid/simple-skill"},
+		{"validate", "--watch", "--interval", "0s", "../../testdata/valid/simple-skill"},
+		{"validate", "-r", "--exclude", "[z-a]", "../../testdata/valid"},
+		{"to-prompt"},
+		{"fix"},
+		{"init"},
+		{"bundle"},
+		{"bundle", "../../testdata/valid/simple-skill", "../../testdata/valid/pdf-processing"},
+		{"bundle", "--format", "rar", "../../testdata/valid/simple-skill"},
+		{"bundle", "--format", "zip", "-o", "simple-skill.tar.gz", "../../testdata/valid/simple-skill"},
+		{"bundle", "-o", "simple-skill.rar", "../../testdata/valid/simple-skill"},
+		{"init", "one", "two"},
+		{"init", "Bad_Name"},
+		{"init", "--with", "docs", "new-skill"},
+		{"fix", "--spec", "bogus", "../../testdata/valid/simple-skill"},
+		{"rules", "extra"},
+		{"lsp", "extra"},
+		{"lsp", "--spec", "bogus"},
+		{"lsp", "--config", "../../testdata/config/invalid/.aglx.yaml"},
+		{"validate", "--config", "../../testdata/config/invalid/.aglx.yaml", "../../testdata/valid/simple-skill"},
+		{"validate", "--config", "../../testdata/config/missing.yaml", "../../testdata/valid/simple-skill"},
+		{"validate", "--config", "../../testdata/config/claude-code/.aglx.yaml", "--no-config", "../../testdata/valid/simple-skill"},
+	}
+
+	for _, args := range tests {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			_, _, code := runCLI(t, args...)
+			if code != int(aglxerrors.ExitUsageError) {
+				t.Errorf("exit code = %d, want %d", code, aglxerrors.ExitUsageError)
+			}
+		})
+	}
+}
+
+func TestRun_ConfigSchemaError(t *testing.T) {
+	_, stderr, _ := runCLI(t, "validate", "--config", "../../testdata/config/invalid/.aglx.yaml", "../../testdata/valid/simple-skill")
+	for _, want := range []string{".aglx.yaml:1:7: unknown spec", ".aglx.yaml:3:3: unknown rule \"body-sise\""} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("expected %q in stderr, got:\n%s", want, stderr)
+		}
+	}
+}
+
+func TestRun_SpecFlagOverridesConfig(t *testing.T) {
+	stdout, _, code := runCLI(t, "validate", "-q", "--spec", "agent-skills", "--config", "../../testdata/config/claude-code/.aglx.yaml", "../../testdata/valid/large-body")
+	if code != int(aglxerrors.ExitValidationError) {
+		t.Errorf("exit code = %d, want %d", code, aglxerrors.ExitValidationError)
+	}
+	if !strings.Contains(stdout, "agent-skills: error: body:") || strings.Contains(stdout, "claude-code") {
+		t.Errorf("expected only agent-skills findings with the config's severities, got:\n%s", stdout)
+	}
+}
+
+func TestRun_FixAppliesChanges(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "fixable-skill")
+	if err := os.MkdirAll(filepath.Join(dir, "scripts"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nname: Fixable--Skill\ndescription: Needs fixing.\nallowed-tools: Read Grep\n---\n\n# Fixable\n"
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, stderr, code := runCLI(t, "validate", "--spec", "claude-code", dir); code != int(aglxerrors.ExitValidationError) {
+		t.Fatalf("expected the skill to fail validation before fixing (exit %d, stderr: %s)", code, stderr)
+	}
+	stdout, stderr, code := runCLI(t, "fix", "--spec", "claude-code", dir)
+	if code != int(aglxerrors.ExitSuccess) {
+		t.Fatalf("fix exit code = %d (stderr: %s)", code, stderr)
+	}
+	if !strings.Contains(stdout, "Fixed 3 issue(s) in 1 of 1 skill(s)") {
+		t.Errorf("unexpected fix output:\n%s", stdout)
+	}
+	if stdout, _, code := runCLI(t, "validate", "--spec", "claude-code", dir); code != int(aglxerrors.ExitSuccess) {
+		t.Errorf("expected the fixed skill to pass validation, got exit %d:\n%s", code, stdout)
+	}
+}
+
+func TestRun_Init(t *testing.T) {
+	parent := t.TempDir()
+	stdout, stderr, code := runCLI(t, "init", "--dir", parent, "--spec", "claude-code", "--with", "scripts,references", "new-skill")
+	if code != int(aglxerrors.ExitSuccess) {
+		t.Fatalf("init exit code = %d (stderr: %s)", code, stderr)
+	}
+	dir := filepath.Join(parent, "new-skill")
+	for _, want := range []string{"Created " + dir, "  SKILL.md\n", "  references/REFERENCE.md\n", "  scripts/example.sh\n"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in output:\n%s", want, stdout)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "assets")); !os.IsNotExist(err) {
+		t.Error("assets/ should only be created when requested")
+	}
+
+	if stdout, _, code := runCLI(t, "validate", "--spec", "claude-code", dir); code != int(aglxerrors.ExitSuccess) {
+		t.Errorf("expected the new skill to pass validation, got exit %d:\n%s", code, stdout)
+	}
+	if _, _, code := runCLI(t, "init", "--dir", parent, "new-skill"); code != int(aglxerrors.ExitUsageError) {
+		t.Errorf("expected a usage error for an existing skill, got exit %d", code)
+	}
+}
+
+func TestRun_Bundle(t *testing.T) {
+	out := t.TempDir()
+
+	archive := filepath.Join(out, "simple-skill.tar.gz")
+	stdout, stderr, code := runCLI(t, "bundle", "-o", archive, "../../testdata/valid/simple-skill")
+	if code != int(aglxerrors.ExitSuccess) {
+		t.Fatalf("bundle exit code = %d (stderr: %s)", code, stderr)
+	}
+	if !strings.Contains(stdout, "  + SKILL.md\n") || !strings.Contains(stdout, "Wrote "+archive+" (1 file(s))") {
+		t.Errorf("unexpected output:\n%s", stdout)
+	}
+	if _, err := os.Stat(archive); err != nil {
+		t.Errorf("archive not written: %v", err)
+	}
+
+	// Invalid skills are refused unless forced.
+	refused := filepath.Join(out, "uppercase-name.zip")
+	_, stderr, code = runCLI(t, "bundle", "-o", refused, "../../testdata/invalid/uppercase-name")
+	if code != int(aglxerrors.ExitValidationError) {
+		t.Errorf("exit code = %d, want %d", code, aglxerrors.ExitValidationError)
+	}
+	if !strings.Contains(stderr, "[name-format]") || !strings.Contains(stderr, "use --force") {
+		t.Errorf("expected the findings and a hint on stderr, got:\n%s", stderr)
+	}
+	if _, err := os.Stat(refused); !os.IsNotExist(err) {
+		t.Error("no archive should be written for an invalid skill")
+	}
+
+	if _, stderr, code := runCLI(t, "bundle", "--force", "-o", refused, "../../testdata/invalid/uppercase-name"); code != int(aglxerrors.ExitSuccess) {
+		t.Errorf("bundle --force exit code = %d (stderr: %s)", code, stderr)
+	}
+	if _, err := os.Stat(refused); err != nil {
+		t.Errorf("archive not written with --force: %v", err)
+	}
+}
+
+func TestRun_ValidateJobs(t *testing.T) {
+	// Parallel validation must print exactly what a sequential run prints.
+	args := []string{"validate", "-r", "../../testdata/valid", "../../testdata/invalid"}
+	sequential, _, seqCode := runCLI(t, append(args, "-j", "1")...)
+	for _, jobs := range []string{"0", "8"} {
+		parallel, _, code := runCLI(t, append(args, "--jobs", jobs)...)
+		if code != seqCode || parallel != sequential {
+			t.Errorf("--jobs %s output (exit %d) differs from --jobs 1 (exit %d)", jobs, code, seqCode)
+		}
+	}
+}
+
+func TestRun_ValidateArchive(t *testing.T) {
+	out := t.TempDir()
+	for _, name := range []string{"simple-skill.zip", "simple-skill.tar.gz"} {
+		archive := filepath.Join(out, name)
+		if _, stderr, code := runCLI(t, "bundle", "-o", archive, "../../testdata/valid/simple-skill"); code != int(aglxerrors.ExitSuccess) {
+			t.Fatalf("bundle exit code = %d (stderr: %s)", code, stderr)
+		}
+		stdout, stderr, code := runCLI(t, "validate", archive)
+		if code != int(aglxerrors.ExitSuccess) {
+			t.Errorf("validate %s exit code = %d (stderr: %s)", name, code, stderr)
+		}
+		if !strings.Contains(stdout, "=== "+filepath.Join(archive, "simple-skill")+" ===") {
+			t.Errorf("expected the archive folder in the output, got:\n%s", stdout)
+		}
+	}
+
+	corrupt := filepath.Join(out, "corrupt.zip")
+	os.WriteFile(corrupt, []byte("not a zip"), 0644)
+	if _, _, code := runCLI(t, "validate", corrupt); code != int(aglxerrors.ExitParseError) {
+		t.Errorf("corrupt archive exit code = %d, want %d", code, aglxerrors.ExitParseError)
+	}
+}
+
+// syncBuffer is a bytes.Buffer that a running watch loop and the test can share.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestWatchLoop(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "watched-skill")
+	other := filepath.Join(filepath.Dir(dir), "other-skill")
+	writeSkill := func(dir, frontmatter string) {
+		t.Helper()
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\n"+frontmatter+"---\n\n# Skill\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeSkill(dir, "name: watched-skill\ndescription: Watched.\n")
+	writeSkill(other, "name: other-skill\ndescription: Untouched.\n")
+
+	opts := &checker.CheckOptions{Spec: skill.SpecAgentSkills}
+	paths := []string{dir, other}
+	w := watch.New(paths)
+	results := checker.CheckMultipleWithOptions(paths, opts)
+
+	var stdout, stderr syncBuffer
+	loop := &watchLoop{
+		interval: 5 * time.Millisecond,
+		opts:     opts,
+		resolve:  func() ([]string, error) { return paths, nil },
+		now:      func() time.Time { return time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC) },
+		stdout:   &stdout,
+		stderr:   &stderr,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- loop.run(ctx, w, paths, results) }()
+
+	waitFor := func(want string) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for !strings.Contains(stdout.String(), want) {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %q, got:\n%s", want, stdout.String())
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	// Break the name: the new finding is reported, not the untouched skill.
+	// Every edit changes the size, since timestamps may be coarser than the test.
+	writeSkill(dir, "name: Watched-Skill\ndescription: Broken.\n")
+	waitFor("Agent Skills: ✗ FAIL")
+	// Fix it: the findings are reported as removed.
+	writeSkill(dir, "name: watched-skill\nlicense: MIT\ndescription: Watched again.\n")
+	waitFor("Agent Skills: ✓ PASS")
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("run() = %v, want nil after cancellation", err)
+	}
+
+	out := stdout.String()
+	if strings.Contains(out, "other-skill") {
+		t.Errorf("expected only the changed skill to be re-checked, got:\n%s", out)
+	}
+	for _, want := range []string{
+		"[15:04:05] " + dir + "\n",
+		"  + " + filepath.Join(dir, "SKILL.md") + ":2:7: agent-skills: error: name: must match parent directory name",
+		"  - " + filepath.Join(dir, "SKILL.md") + ":2:7: agent-skills: error: name: must match parent directory name",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestWatchLoop_ReloadsConfig(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "watched-skill")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: other-name\ndescription: Watched.\n---\n\n# Skill\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(root, ".aglx.yaml")
+	writeConfig := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeConfig("spec: agent-skills\n")
+	reload := func() (*checker.CheckOptions, []string, error) {
+		cfg, err := config.Load(configPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return cfg.CheckOptions(), []string{configPath}, nil
+	}
+
+	opts, files, err := reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths := []string{dir}
+	w, inputs := watch.New(paths), watch.New(files)
+	results := checker.CheckMultipleWithOptions(paths, opts)
+
+	var stdout, stderr syncBuffer
+	loop := &watchLoop{
+		interval: 5 * time.Millisecond,
+		opts:     opts,
+		resolve:  func() ([]string, error) { return paths, nil },
+		reload:   reload,
+		files:    files,
+		inputs:   inputs,
+		now:      func() time.Time { return time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC) },
+		stdout:   &stdout,
+		stderr:   &stderr,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- loop.run(ctx, w, paths, results) }()
+
+	waitFor := func(buf *syncBuffer, want string) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for !strings.Contains(buf.String(), want) {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %q, got:\n%s", want, buf.String())
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	// An invalid config is reported and the previous options are kept.
+	writeConfig("spec: gpt\n")
+	waitFor(&stderr, "aglx: ")
+	// Turning the failing rule off re-checks the unchanged skill.
+	writeConfig("spec: agent-skills\nrules:\n  name-dir-match: off\n")
+	waitFor(&stdout, "Agent Skills: ✓ PASS")
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("run() = %v, want nil after cancellation", err)
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"[15:04:05] " + configPath + "\n  reloaded, re-checking all skills\n",
+		"  - " + filepath.Join(dir, "SKILL.md") + ":2:7: agent-skills: error: name: must match parent directory name",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestDiffFindings(t *testing.T) {
+	nameErr := finding{"s/SKILL.md:2:7", "agent-skills", "error", "name: bad [name-format]"}
+	moved := nameErr
+	moved.location = "s/SKILL.md:3:7"
+	warn := finding{"s", "CLAUDE.md", "warning", "body: long [claude-md-size]"}
+
+	added, removed := diffFindings([]finding{nameErr, nameErr}, []finding{moved, warn})
+	if len(added) != 1 || added[0] != warn {
+		t.Errorf("added = %v, want [%v]", added, warn)
+	}
+	// One of the duplicates is gone; the other only moved.
+	if len(removed) != 1 || removed[0] != nameErr {
+		t.Errorf("removed = %v, want [%v]", removed, nameErr)
+	}
+}
+
+func TestRun_ToPromptParseError(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "to-prompt", "../../testdata/valid/simple-skill", "../../testdata/invalid/no-frontmatter")
+	if code != int(aglxerrors.ExitParseError) {
+		t.Errorf("exit code = %d, want %d", code, aglxerrors.ExitParseError)
+	}
+	if !strings.Contains(stdout, "<name>simple-skill</name>") {
+		t.Errorf("expected parsed skill in output, got:\n%s", stdout)
+	}
+	if !strings.Contains(stderr, "no-frontmatter") {
+		t.Errorf("expected parse error on stderr, got: %s", stderr)
+	}
+}
+
+func TestRun_VersionAndHelp(t *testing.T) {
+	stdout, _, code := runCLI(t, "version")
+	if code != 0 || !strings.HasPrefix(stdout, "aglx dev") {
+		t.Errorf("unexpected version output %q (code %d)", stdout, code)
+	}
+
+	stdout, _, code = runCLI(t, "help")
+	if code != 0 || !strings.Contains(stdout, "to-prompt") {
+		t.Errorf("unexpected help output %q (code %d)", stdout, code)
+	}
+}
+
+func TestParseArgs_Interleaved(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	jsonFlag := fs.Bool("json", false, "")
+	paths, err := parseArgs(fs, []string{"a", "--json", "b", "--", "--c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !*jsonFlag {
+		t.Error("expected --json to be parsed after a positional argument")
+	}
+	want := []string{"a", "b", "--c"}
+	if strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Errorf("paths = %v, want %v", paths, want)
+	}
+}
+
+func TestRun_Recursive(t *testing.T) {
+	stdout, _, code := runCLI(t, "to-prompt", "--recursive", "../../testdata/valid")
+	if code != int(aglxerrors.ExitSuccess) {
+		t.Errorf("exit code = %d, want %d", code, aglxerrors.ExitSuccess)
+	}
+	for _, name := range []string{"pdf-processing", "simple-skill", "with-metadata", "large-body", "with-hidden-files"} {
+		if !strings.Contains(stdout, "<name>"+name+"</name>") {
+			t.Errorf("expected %s to be discovered, got:\n%s", name, stdout)
+		}
+	}
+
+	stdout, _, _ = runCLI(t, "validate", "-r", "--quiet", "--exclude", "large-body", "--exclude", "with-*", "../../testdata/valid")
+	if strings.Contains(stdout, "large-body") || strings.Contains(stdout, "with-hidden-files") {
+		t.Errorf("expected excluded skills to be skipped, got:\n%s", stdout)
+	}
+
+	_, _, code = runCLI(t, "validate", "-r", t.TempDir())
+	if code != int(aglxerrors.ExitParseError) {
+		t.Errorf("exit code = %d, want %d for empty tree", code, aglxerrors.ExitParseError)
+	}
+}
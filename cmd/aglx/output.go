@@ -0,0 +1,378 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/biwakonbu/aglx/internal/checker"
+	"github.com/biwakonbu/aglx/internal/claude"
+	"github.com/biwakonbu/aglx/internal/report"
+	"github.com/biwakonbu/aglx/internal/skill"
+	"github.com/biwakonbu/aglx/internal/source"
+)
+
+// Output formats accepted by --format.
+const (
+	formatText       = "text"
+	formatJSON       = "json"
+	formatSARIF      = "sarif"
+	formatJUnit      = "junit"
+	formatCheckstyle = "checkstyle"
+)
+
+var knownFormats = []string{formatText, formatJSON, formatSARIF, formatJUnit, formatCheckstyle}
+
+func isKnownFormat(format string) bool {
+	for _, f := range knownFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// writeResults renders results in the selected format.
+// quiet only affects the text format.
+func writeResults(w io.Writer, format string, quiet bool, results []*checker.Result) error {
+	switch format {
+	case formatJSON:
+		return writeJSON(w, results)
+	case formatSARIF:
+		return report.WriteSARIF(w, results, report.ToolInfo{Version: version})
+	case formatJUnit:
+		return report.WriteJUnit(w, results)
+	case formatCheckstyle:
+		return report.WriteCheckstyle(w, results)
+	default:
+		if quiet {
+			writeQuiet(w, results)
+		} else {
+			writeText(w, results)
+		}
+		return nil
+	}
+}
+
+// statusIcon returns the symbol used for a status in text output.
+func statusIcon(s checker.Status) string {
+	switch s {
+	case checker.StatusPass:
+		return "✓"
+	case checker.StatusFail:
+		return "✗"
+	case checker.StatusWarning:
+		return "!"
+	default:
+		return "-"
+	}
+}
+
+// writeText renders results in the human-readable format.
+func writeText(w io.Writer, results []*checker.Result) {
+	for _, r := range results {
+		fmt.Fprintf(w, "=== %s ===\n", r.Path)
+
+		if r.ParseError != nil {
+			fmt.Fprintf(w, "\n--- SKILL.md ---\n")
+			fmt.Fprintf(w, "  ✗ %v\n", r.ParseError)
+		}
+		for _, sr := range specResults(r) {
+			fmt.Fprintf(w, "\n--- %s (SKILL.md) ---\n", sr.label)
+			writeSpecResult(w, r, sr.result)
+		}
+
+		fmt.Fprintf(w, "\n--- Claude Skills (CLAUDE.md) ---\n")
+		writeClaudeMdResult(w, r)
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "=== Summary ===\n")
+	for _, r := range results {
+		fmt.Fprintf(w, "%s: %s\n", r.Path, summaryLine(r))
+	}
+}
+
+func writeSpecResult(w io.Writer, r *checker.Result, sr *checker.SpecResult) {
+	fmt.Fprintf(w, "  %s %s\n", statusIcon(sr.Status), displayName(r.Skill))
+	for _, e := range sr.ValidationResult.Errors {
+		fmt.Fprintf(w, "    - %s%s%s\n", e.Error(), locationSuffix(r.Path, e.Pos), ruleSuffix(e.Rule))
+	}
+	for _, warn := range sr.ValidationResult.Warnings {
+		fmt.Fprintf(w, "    ! %s%s%s\n", warn.Error(), locationSuffix(r.Path, warn.Pos), ruleSuffix(warn.Rule))
+	}
+}
+
+// locationSuffix formats pos relative to the skill directory, e.g. " (SKILL.md:2:7)".
+func locationSuffix(base string, pos source.Position) string {
+	if !pos.IsValid() {
+		return ""
+	}
+	if rel, err := filepath.Rel(base, pos.File); err == nil && !strings.HasPrefix(rel, "..") {
+		pos.File = filepath.ToSlash(rel)
+	}
+	return fmt.Sprintf(" (%s)", pos)
+}
+
+// ruleSuffix formats a rule ID for text output, e.g. " [name-format]".
+func ruleSuffix(rule string) string {
+	if rule == "" {
+		return ""
+	}
+	return " [" + rule + "]"
+}
+
+// findingLocation returns the position as a prefix for quiet output,
+// falling back to the skill directory.
+func findingLocation(dir string, pos source.Position) string {
+	if !pos.IsValid() {
+		return dir
+	}
+	return pos.String()
+}
+
+func writeClaudeMdResult(w io.Writer, r *checker.Result) {
+	switch {
+	case r.ClaudeMdError != nil:
+		fmt.Fprintf(w, "  ✗ %v\n", r.ClaudeMdError)
+	case r.ClaudeMdResult == nil:
+		fmt.Fprintf(w, "  - CLAUDE.md not found\n")
+	default:
+		fmt.Fprintf(w, "  %s %s\n", statusIcon(r.ClaudeMdStatus()), r.ClaudeMdResult.Skill.Path)
+		for _, warn := range r.ClaudeMdResult.Warnings {
+			fmt.Fprintf(w, "    ! %s: %s%s%s\n", warn.Field, warn.Message, locationSuffix(r.Path, warn.Pos), ruleSuffix(warn.Rule))
+		}
+	}
+}
+
+func summaryLine(r *checker.Result) string {
+	var parts []string
+	if r.ParseError != nil {
+		parts = append(parts, fmt.Sprintf("SKILL.md: %s PARSE ERROR", statusIcon(checker.StatusFail)))
+	}
+	for _, sr := range specResults(r) {
+		parts = append(parts, fmt.Sprintf("%s: %s %s", sr.label, statusIcon(sr.result.Status), sr.result.Status))
+	}
+	status := r.ClaudeMdStatus()
+	parts = append(parts, fmt.Sprintf("Claude Skills: %s %s", statusIcon(status), status))
+	return strings.Join(parts, " | ")
+}
+
+// writeQuiet prints one line per finding and nothing for passing checks.
+func writeQuiet(w io.Writer, results []*checker.Result) {
+	for _, r := range results {
+		for _, f := range resultFindings(r) {
+			fmt.Fprintln(w, f)
+		}
+	}
+}
+
+// finding is one line of quiet output, kept in parts so that watch mode can
+// match findings across runs even when their location moved.
+type finding struct {
+	location string
+	scope    string // spec, "CLAUDE.md", or empty for SKILL.md parse errors
+	level    string // "error" or "warning"
+	text     string
+}
+
+func (f finding) String() string {
+	if f.scope == "" {
+		return fmt.Sprintf("%s: %s: %s", f.location, f.level, f.text)
+	}
+	return fmt.Sprintf("%s: %s: %s: %s", f.location, f.scope, f.level, f.text)
+}
+
+// key identifies a finding independently of its location.
+func (f finding) key() string {
+	return f.scope + "\x00" + f.level + "\x00" + f.text
+}
+
+// resultFindings lists every finding of a result in quiet output order.
+func resultFindings(r *checker.Result) []finding {
+	var out []finding
+	if r.ParseError != nil {
+		out = append(out, finding{r.Path, "", "error", r.ParseError.Error()})
+	}
+	for _, sr := range specResults(r) {
+		scope := string(sr.spec)
+		for _, e := range sr.result.ValidationResult.Errors {
+			out = append(out, finding{findingLocation(r.Path, e.Pos), scope, "error", e.Error() + ruleSuffix(e.Rule)})
+		}
+		for _, warn := range sr.result.ValidationResult.Warnings {
+			out = append(out, finding{findingLocation(r.Path, warn.Pos), scope, "warning", warn.Error() + ruleSuffix(warn.Rule)})
+		}
+	}
+	if r.ClaudeMdError != nil {
+		out = append(out, finding{r.Path, "CLAUDE.md", "error", r.ClaudeMdError.Error()})
+	}
+	if r.ClaudeMdResult != nil {
+		for _, warn := range r.ClaudeMdResult.Warnings {
+			out = append(out, finding{findingLocation(r.Path, warn.Pos), "CLAUDE.md", "warning", warn.Field + ": " + warn.Message + ruleSuffix(warn.Rule)})
+		}
+	}
+	return out
+}
+
+func displayName(s *skill.Skill) string {
+	if s == nil || s.Name == "" {
+		return "(unnamed)"
+	}
+	return s.Name
+}
+
+// JSON output types. Field names are part of the CLI's public contract.
+type jsonReport struct {
+	Results []jsonResult `json:"results"`
+	Summary jsonSummary  `json:"summary"`
+}
+
+type jsonSummary struct {
+	Total    int `json:"total"`
+	Passed   int `json:"passed"`
+	Warnings int `json:"warnings"`
+	Failed   int `json:"failed"`
+}
+
+type jsonResult struct {
+	Path        string          `json:"path"`
+	Name        string          `json:"name,omitempty"`
+	Description string          `json:"description,omitempty"`
+	ParseError  string          `json:"parse_error,omitempty"`
+	AgentSkills *jsonSpecResult `json:"agent_skills,omitempty"`
+	ClaudeCode  *jsonSpecResult `json:"claude_code,omitempty"`
+	ClaudeMd    jsonClaudeMd    `json:"claude_md"`
+}
+
+type jsonSpecResult struct {
+	Status   string        `json:"status"`
+	Errors   []jsonFinding `json:"errors"`
+	Warnings []jsonFinding `json:"warnings"`
+}
+
+type jsonClaudeMd struct {
+	Status   string        `json:"status"`
+	Path     string        `json:"path,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	Warnings []jsonFinding `json:"warnings"`
+}
+
+type jsonFinding struct {
+	Rule    string `json:"rule,omitempty"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+}
+
+func newJSONFinding(rule, field, message string, pos source.Position) jsonFinding {
+	return jsonFinding{
+		Rule:    rule,
+		Field:   field,
+		Message: message,
+		File:    pos.File,
+		Line:    pos.Line,
+		Column:  pos.Column,
+	}
+}
+
+func writeJSON(w io.Writer, results []*checker.Result) error {
+	report := jsonReport{Results: make([]jsonResult, 0, len(results))}
+
+	for _, r := range results {
+		jr := jsonResult{
+			Path:     r.Path,
+			ClaudeMd: toJSONClaudeMd(r),
+		}
+		if r.Skill != nil {
+			jr.Name = r.Skill.Name
+			jr.Description = r.Skill.Description
+		}
+		if r.ParseError != nil {
+			jr.ParseError = r.ParseError.Error()
+		}
+		jr.AgentSkills = toJSONSpecResult(r.AgentSkillsResult)
+		jr.ClaudeCode = toJSONSpecResult(r.ClaudeCodeResult)
+		report.Results = append(report.Results, jr)
+
+		report.Summary.Total++
+		switch overallStatus(r) {
+		case checker.StatusFail:
+			report.Summary.Failed++
+		case checker.StatusWarning:
+			report.Summary.Warnings++
+		default:
+			report.Summary.Passed++
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// overallStatus folds all statuses of a result into the most severe one.
+func overallStatus(r *checker.Result) checker.Status {
+	if r.ParseError != nil || r.ClaudeMdError != nil {
+		return checker.StatusFail
+	}
+	statuses := []checker.Status{r.ClaudeMdStatus()}
+	for _, sr := range specResults(r) {
+		statuses = append(statuses, sr.result.Status)
+	}
+	overall := checker.StatusPass
+	for _, s := range statuses {
+		switch s {
+		case checker.StatusFail:
+			return checker.StatusFail
+		case checker.StatusWarning:
+			overall = checker.StatusWarning
+		}
+	}
+	return overall
+}
+
+func toJSONSpecResult(sr *checker.SpecResult) *jsonSpecResult {
+	if sr == nil {
+		return nil
+	}
+	return &jsonSpecResult{
+		Status:   sr.Status.String(),
+		Errors:   toJSONFindings(sr.ValidationResult.Errors),
+		Warnings: toJSONFindings(sr.ValidationResult.Warnings),
+	}
+}
+
+func toJSONFindings(errs []skill.ValidationError) []jsonFinding {
+	findings := make([]jsonFinding, 0, len(errs))
+	for _, e := range errs {
+		findings = append(findings, newJSONFinding(e.Rule, e.Field, e.Message, e.Pos))
+	}
+	return findings
+}
+
+func toJSONClaudeMd(r *checker.Result) jsonClaudeMd {
+	out := jsonClaudeMd{
+		Status:   r.ClaudeMdStatus().String(),
+		Warnings: []jsonFinding{},
+	}
+	if r.ClaudeMdError != nil {
+		out.Error = r.ClaudeMdError.Error()
+	}
+	if r.ClaudeMdResult != nil {
+		out.Path = r.ClaudeMdResult.Skill.Path
+		out.Warnings = toJSONClaudeFindings(r.ClaudeMdResult.Warnings)
+	}
+	return out
+}
+
+func toJSONClaudeFindings(warnings []claude.ValidationWarning) []jsonFinding {
+	findings := make([]jsonFinding, 0, len(warnings))
+	for _, w := range warnings {
+		findings = append(findings, newJSONFinding(w.Rule, w.Field, w.Message, w.Pos))
+	}
+	return findings
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	aglxerrors "github.com/biwakonbu/aglx/internal/errors"
+	"github.com/biwakonbu/aglx/internal/prompt"
+	"github.com/biwakonbu/aglx/internal/skill"
+)
+
+func runToPrompt(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("to-prompt", "[flags] <path>...", stderr)
+	discover := addDiscoveryFlags(fs)
+
+	paths, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		fs.Usage()
+		return aglxerrors.NewUsageError("to-prompt requires at least one path")
+	}
+
+	paths, err = discover.resolve(paths, stderr)
+	if err != nil {
+		return err
+	}
+
+	skills, parseErrs := skill.ParseMultiple(paths)
+	for _, e := range parseErrs {
+		fmt.Fprintf(stderr, "aglx: %v\n", e)
+	}
+
+	output, err := prompt.GenerateXMLPrompt(skills)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, output)
+
+	if len(parseErrs) > 0 {
+		return &aglxerrors.CLIError{ExitCode: aglxerrors.ExitParseError}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/biwakonbu/aglx/internal/claude"
+	aglxerrors "github.com/biwakonbu/aglx/internal/errors"
+	"github.com/biwakonbu/aglx/internal/skill"
+)
+
+// runRules lists every rule ID with its default severity and applicable specs.
+func runRules(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("rules", "", stderr)
+
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		fs.Usage()
+		return aglxerrors.NewUsageError("rules takes no arguments")
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RULE\tSEVERITY\tSPECS\tDESCRIPTION")
+	for _, r := range skill.Rules() {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.ID, r.DefaultSeverity, ruleSpecs(r), r.Description)
+	}
+
+	// CLAUDE.md checks are not part of the skill registry; list them after it.
+	claudeIDs := make([]string, 0, len(claude.RuleDescriptions))
+	for id := range claude.RuleDescriptions {
+		claudeIDs = append(claudeIDs, id)
+	}
+	sort.Strings(claudeIDs)
+	for _, id := range claudeIDs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", id, skill.SeverityWarning, "claude-md", claude.RuleDescriptions[id])
+	}
+	return tw.Flush()
+}
+
+// ruleSpecs formats the specs a rule applies to, or "all".
+func ruleSpecs(r skill.Rule) string {
+	if len(r.Specs) == 0 {
+		return "all"
+	}
+	specs := make([]string, len(r.Specs))
+	for i, s := range r.Specs {
+		specs[i] = string(s)
+	}
+	return strings.Join(specs, ",")
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/biwakonbu/aglx/internal/checker"
+	"github.com/biwakonbu/aglx/internal/config"
+	aglxerrors "github.com/biwakonbu/aglx/internal/errors"
+	"github.com/biwakonbu/aglx/internal/skill"
+	"github.com/biwakonbu/aglx/internal/watch"
+)
+
+func runValidate(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("validate", "[flags] <path>...", stderr)
+	format := fs.String("format", formatText, "output format: text, json, sarif, junit, checkstyle")
+	jsonOutput := fs.Bool("json", false, "output results in JSON format (same as --format json)")
+	quiet := fs.Bool("quiet", false, "only display errors and warnings")
+	fs.BoolVar(quiet, "q", false, "shorthand for --quiet")
+	specName := fs.String("spec", "auto", "specification to validate against: auto, agent-skills, claude-code")
+	jobs := fs.Int("jobs", 0, "number of skills to validate in parallel (0: one per CPU)")
+	fs.IntVar(jobs, "j", 0, "shorthand for --jobs")
+	watchMode := fs.Bool("watch", false, "keep running and re-validate skills when their files, the config or the policy change")
+	interval := fs.Duration("interval", time.Second, "how often --watch polls for changes")
+	discover := addDiscoveryFlags(fs)
+	configs := addConfigFlags(fs)
+	policies := addPolicyFlag(fs)
+
+	paths, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		fs.Usage()
+		return aglxerrors.NewUsageError("validate requires at least one path")
+	}
+
+	// loadOptions reads the config and policy files and applies the flags
+	// that override them. It also returns the files read, which --watch
+	// polls to load the options again when they change.
+	loadOptions := func() (*checker.CheckOptions, []string, error) {
+		cfg, err := configs.load()
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := policies.apply(cfg); err != nil {
+			return nil, nil, err
+		}
+		opts := cfg.CheckOptions()
+
+		// An explicit --spec overrides the config file.
+		if flagWasSet(fs, "spec") {
+			spec, err := skill.ParseSpec(*specName)
+			if err != nil {
+				return nil, nil, aglxerrors.NewUsageError(err.Error())
+			}
+			opts.Spec = spec
+		}
+		opts.Concurrency = *jobs
+		return opts, configFiles(cfg), nil
+	}
+	opts, files, err := loadOptions()
+	if err != nil {
+		return err
+	}
+
+	if *jobs < 0 {
+		return aglxerrors.NewUsageError(fmt.Sprintf("--jobs must not be negative (got %d)", *jobs))
+	}
+
+	if *jsonOutput {
+		if *format != formatText && *format != formatJSON {
+			return aglxerrors.NewUsageError(fmt.Sprintf("--json conflicts with --format %s", *format))
+		}
+		*format = formatJSON
+	}
+	if !isKnownFormat(*format) {
+		return aglxerrors.NewUsageError(fmt.Sprintf("unknown output format %q", *format))
+	}
+
+	if *watchMode {
+		if *format != formatText {
+			return aglxerrors.NewUsageError(fmt.Sprintf("--watch does not support --format %s", *format))
+		}
+		if *interval <= 0 {
+			return aglxerrors.NewUsageError(fmt.Sprintf("--interval must be positive (got %s)", *interval))
+		}
+	}
+
+	roots := paths
+	paths, err = discover.resolve(roots, stderr)
+	if err != nil {
+		return err
+	}
+
+	var w, inputs *watch.Watcher
+	if *watchMode {
+		w = watch.New(paths)
+		inputs = watch.New(files)
+	}
+	results := checker.CheckMultipleWithOptions(paths, opts)
+
+	if err := writeResults(stdout, *format, *quiet, results); err != nil {
+		return err
+	}
+
+	if *watchMode {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		fmt.Fprintf(stderr, "Watching %d skill(s) for changes. Press Ctrl+C to stop.\n", len(paths))
+		loop := &watchLoop{
+			interval: *interval,
+			opts:     opts,
+			// Discovery errors were reported by the initial run.
+			resolve: func() ([]string, error) { return discover.resolve(roots, io.Discard) },
+			reload:  loadOptions,
+			files:   files,
+			inputs:  inputs,
+			now:     time.Now,
+			stdout:  stdout,
+			stderr:  stderr,
+		}
+		return loop.run(ctx, w, paths, results)
+	}
+
+	return validationOutcome(results)
+}
+
+// configFiles returns the config and policy files cfg was loaded from.
+func configFiles(cfg *config.Config) []string {
+	var files []string
+	if cfg.Path != "" {
+		files = append(files, cfg.Path)
+	}
+	if cfg.Policy != nil && cfg.Policy.Path != "" {
+		files = append(files, cfg.Policy.Path)
+	}
+	return files
+}
+
+// validationOutcome maps check results to a CLI error carrying the exit code.
+// Parse errors take precedence over validation errors.
+func validationOutcome(results []*checker.Result) error {
+	var parseFailed, validationFailed bool
+	for _, r := range results {
+		if r.ParseError != nil || r.ClaudeMdError != nil {
+			parseFailed = true
+			continue
+		}
+		for _, sr := range specResults(r) {
+			if sr.result.Status == checker.StatusFail {
+				validationFailed = true
+			}
+		}
+	}
+
+	switch {
+	case parseFailed:
+		return &aglxerrors.CLIError{ExitCode: aglxerrors.ExitParseError}
+	case validationFailed:
+		return &aglxerrors.CLIError{ExitCode: aglxerrors.ExitValidationError}
+	default:
+		return nil
+	}
+}
+
+// namedSpecResult pairs a spec result with its display label.
+type namedSpecResult struct {
+	spec   skill.Spec
+	label  string
+	result *checker.SpecResult
+}
+
+// specResults returns the spec results that were computed, in display order.
+func specResults(r *checker.Result) []namedSpecResult {
+	var out []namedSpecResult
+	if r.AgentSkillsResult != nil {
+		out = append(out, namedSpecResult{skill.SpecAgentSkills, "Agent Skills", r.AgentSkillsResult})
+	}
+	if r.ClaudeCodeResult != nil {
+		out = append(out, namedSpecResult{skill.SpecClaudeCode, "Claude Code", r.ClaudeCodeResult})
+	}
+	return out
+}
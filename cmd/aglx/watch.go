@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/biwakonbu/aglx/internal/checker"
+	"github.com/biwakonbu/aglx/internal/watch"
+)
+
+// watchLoop re-validates skills whenever their files change and prints how
+// the findings differ from the previous run.
+type watchLoop struct {
+	interval time.Duration
+	opts     *checker.CheckOptions
+	// resolve returns the skill paths to watch; it is called on every poll
+	// so that --recursive picks up skills that are added or removed.
+	resolve func() ([]string, error)
+	// reload loads the options again from the config and policy files; it
+	// is called when inputs reports that one of files changed, and every
+	// skill is then re-checked.
+	reload func() (*checker.CheckOptions, []string, error)
+	files  []string
+	inputs *watch.Watcher
+	now    func() time.Time
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// run polls until ctx is done. paths and results are those of the initial
+// run, and w must have been created before that run so that no edit made
+// while it was checking goes unnoticed.
+func (l *watchLoop) run(ctx context.Context, w *watch.Watcher, paths []string, results []*checker.Result) error {
+	last := make(map[string][]finding, len(paths))
+	for i, p := range paths {
+		last[p] = resultFindings(results[i])
+	}
+
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	var lastErr string
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		current, err := l.resolve()
+		if err != nil {
+			// Report each distinct error once rather than on every poll.
+			if err.Error() != lastErr {
+				fmt.Fprintf(l.stderr, "aglx: %v\n", err)
+				lastErr = err.Error()
+			}
+			continue
+		}
+		lastErr = ""
+
+		for _, p := range paths {
+			if !slices.Contains(current, p) {
+				fmt.Fprintf(l.stdout, "[%s] %s\n  no longer watched\n", l.timestamp(), p)
+				delete(last, p)
+			}
+		}
+		paths = current
+
+		changed := w.Poll(current)
+		if l.reloadOptions() {
+			changed = current
+		}
+		if len(changed) == 0 {
+			continue
+		}
+		results, err := checker.CheckMultipleContext(ctx, changed, l.opts)
+		if err != nil {
+			return nil // Interrupted while checking
+		}
+		for i, p := range changed {
+			next := resultFindings(results[i])
+			l.report(p, results[i], last[p], next)
+			last[p] = next
+		}
+	}
+}
+
+// reloadOptions loads the options again if a config or policy file
+// changed, and reports whether it did. An unusable file is reported and the
+// previous options are kept until it is fixed.
+func (l *watchLoop) reloadOptions() bool {
+	if l.inputs == nil {
+		return false
+	}
+	changed := l.inputs.Poll(l.files)
+	if len(changed) == 0 {
+		return false
+	}
+	opts, files, err := l.reload()
+	if err != nil {
+		fmt.Fprintf(l.stderr, "aglx: %v\n", err)
+		return false
+	}
+	fmt.Fprintf(l.stdout, "[%s] %s\n  reloaded, re-checking all skills\n", l.timestamp(), strings.Join(changed, ", "))
+	if !slices.Equal(files, l.files) {
+		l.inputs = watch.New(files)
+	}
+	l.opts, l.files = opts, files
+	return true
+}
+
+func (l *watchLoop) timestamp() string {
+	return l.now().Format(time.TimeOnly)
+}
+
+// report prints the findings added and removed since the previous run of a
+// skill, followed by its current status.
+func (l *watchLoop) report(path string, r *checker.Result, prev, next []finding) {
+	fmt.Fprintf(l.stdout, "[%s] %s\n", l.timestamp(), path)
+	added, removed := diffFindings(prev, next)
+	for _, f := range removed {
+		fmt.Fprintf(l.stdout, "  - %s\n", f)
+	}
+	for _, f := range added {
+		fmt.Fprintf(l.stdout, "  + %s\n", f)
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		fmt.Fprintf(l.stdout, "  findings unchanged\n")
+	}
+	fmt.Fprintf(l.stdout, "  %s\n", summaryLine(r))
+}
+
+// diffFindings returns the findings of next that were not in prev and those
+// of prev that are gone. Findings are matched without their location, so a
+// finding that only moved (e.g. after inserting a line above it) is unchanged.
+func diffFindings(prev, next []finding) (added, removed []finding) {
+	unmatched := make(map[string]int, len(prev))
+	for _, f := range prev {
+		unmatched[f.key()]++
+	}
+	for _, f := range next {
+		if unmatched[f.key()] > 0 {
+			unmatched[f.key()]--
+			continue
+		}
+		added = append(added, f)
+	}
+	for _, f := range prev {
+		if unmatched[f.key()] > 0 {
+			unmatched[f.key()]--
+			removed = append(removed, f)
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,38 @@
+// Package aglx is the public Go API of aglx, the Agent Skills examiner.
+//
+// It parses SKILL.md files, validates them against the Agent Skills
+// (agentskills.io) and Claude Code specifications, checks the accompanying
+// CLAUDE.md, and generates the <available_skills> XML prompt, exactly as the
+// aglx command does:
+//
+//	s, err := aglx.Parse("skills/pdf-processing")
+//	if err != nil {
+//		return err
+//	}
+//	result := aglx.Validate(s, &aglx.Options{Spec: aglx.SpecAgentSkills})
+//	for _, f := range result.Errors {
+//		fmt.Println(f)
+//	}
+//
+// Check validates a whole skill directory (or a .zip/.tar.gz bundle) in one
+// call; CheckFS and ParseFS accept any fs.FS, such as an embed.FS or a
+// testing/fstest.MapFS.
+//
+// # Compatibility
+//
+// This package follows semantic versioning. Within a major version:
+//
+//   - Exported identifiers are not removed or renamed, and their signatures
+//     and documented behavior do not change incompatibly. New functions,
+//     methods, struct fields and constants may be added, so construct
+//     structs with field names.
+//   - Rule IDs (Finding.Rule, Rule.ID, Options.Severities keys) are stable
+//     and are never reused for a different check. New rules may be added, so
+//     a skill that validates cleanly today may get new findings, usually as
+//     warnings first.
+//   - Finding messages are meant for humans and may be reworded; match on
+//     Rule and Field instead.
+//
+// Packages under internal/ and the command's text output are not covered
+// by this promise.
+package aglx
@@ -0,0 +1,122 @@
+package aglx_test
+
+import (
+	"context"
+	"fmt"
+	"testing/fstest"
+
+	"github.com/biwakonbu/aglx"
+)
+
+func ExampleParse() {
+	s, err := aglx.Parse("testdata/valid/with-metadata")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(s.Name)
+	fmt.Println(s.License)
+	fmt.Println(s.Tools())
+	// Output:
+	// with-metadata
+	// MIT
+	// [Bash(python:*) Read Write]
+}
+
+func ExampleValidate() {
+	s, err := aglx.Parse("testdata/invalid/uppercase-name")
+	if err != nil {
+		panic(err)
+	}
+	result := aglx.Validate(s, &aglx.Options{Spec: aglx.SpecAgentSkills})
+	fmt.Println("valid:", result.Valid())
+	for _, f := range result.Errors {
+		fmt.Println(f.Rule, f.Position)
+	}
+	// Output:
+	// valid: false
+	// name-format testdata/invalid/uppercase-name/SKILL.md:2:7
+	// name-format testdata/invalid/uppercase-name/SKILL.md:2:7
+	// name-dir-match testdata/invalid/uppercase-name/SKILL.md:2:7
+}
+
+func ExampleValidate_severities() {
+	s, err := aglx.Parse("testdata/valid/with-hidden-files")
+	if err != nil {
+		panic(err)
+	}
+
+	// Promote hidden files from a warning to an error.
+	result := aglx.Validate(s, &aglx.Options{
+		Severities: map[string]aglx.Severity{"hidden-files": aglx.SeverityError},
+	})
+	for _, f := range result.Errors {
+		fmt.Println(f)
+	}
+	// Output:
+	// scripts: contains hidden file or directory: ".hidden" (testdata/valid/with-hidden-files/scripts/.hidden) [hidden-files]
+}
+
+func ExampleCheck() {
+	result := aglx.Check("testdata/valid/simple-skill", nil)
+	fmt.Println("valid:", result.Valid())
+	fmt.Println("agent-skills errors:", len(result.AgentSkills.Errors))
+	fmt.Println("claude-code errors:", len(result.ClaudeCode.Errors))
+	fmt.Println("CLAUDE.md found:", result.ClaudeMd != nil)
+	// Output:
+	// valid: true
+	// agent-skills errors: 0
+	// claude-code errors: 0
+	// CLAUDE.md found: false
+}
+
+func ExampleCheckMultiple() {
+	paths := []string{"testdata/valid/simple-skill", "testdata/invalid/missing-name", "testdata/valid/pdf-processing"}
+	results, err := aglx.CheckMultiple(context.Background(), paths, &aglx.Options{Concurrency: 4})
+	if err != nil {
+		panic(err)
+	}
+	// Results come back in input order, however the work was scheduled.
+	for _, r := range results {
+		fmt.Println(r.Path, r.Valid())
+	}
+	// Output:
+	// testdata/valid/simple-skill true
+	// testdata/invalid/missing-name false
+	// testdata/valid/pdf-processing true
+}
+
+func ExampleCheckFS() {
+	fsys := fstest.MapFS{
+		"SKILL.md":        {Data: []byte("---\nname: in-memory\ndescription: Lives in memory.\n---\n\n# In Memory\n\nRun `scripts/main.sh`.\n")},
+		"scripts/.env":    {Data: []byte("TOKEN=x\n")},
+		"scripts/main.sh": {Data: []byte("#!/bin/sh\n")},
+	}
+	result := aglx.CheckFS(fsys, "in-memory", &aglx.Options{Spec: aglx.SpecClaudeCode})
+	fmt.Println("valid:", result.Valid())
+	for _, f := range result.ClaudeCode.Warnings {
+		fmt.Println(f.Rule)
+	}
+	// Output:
+	// valid: true
+	// hidden-files
+}
+
+func ExampleGenerateXMLPrompt() {
+	s, err := aglx.Parse("testdata/valid/simple-skill")
+	if err != nil {
+		panic(err)
+	}
+	out, err := aglx.GenerateXMLPrompt([]*aglx.Skill{s})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(out)
+	// Output:
+	// <available_skills>
+	//   <skill>
+	//     <name>simple-skill</name>
+	//     <description>A simple skill with minimal configuration.</description>
+	//     <location>testdata/valid/simple-skill/SKILL.md</location>
+	//   </skill>
+	// </available_skills>
+}
@@ -3,3 +3,5 @@ module github.com/biwakonbu/aglx
 go 1.23.12
 
 require gopkg.in/yaml.v3 v3.0.1
+
+require golang.org/x/text v0.28.0
@@ -0,0 +1,306 @@
+// Package bundle packages a skill directory into a distributable archive
+// (the target of `aglx bundle`), and reads such archives back as an fs.FS so
+// they can be validated without extracting them.
+//
+// Archives are deterministic: entries are sorted by path, every entry carries
+// the same modification time and normalized permissions, and no owner or
+// host information is recorded. Bundling the same files twice yields
+// byte-identical archives.
+package bundle
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Format is an archive format.
+type Format string
+
+const (
+	FormatZip   Format = "zip"
+	FormatTarGz Format = "tar.gz"
+)
+
+// ManifestName is the name of the manifest stored at the top of the skill folder.
+const ManifestName = "aglx-manifest.json"
+
+// ModTime is the modification time recorded for every entry (the earliest
+// time a zip file can represent).
+var ModTime = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// ParseFormat converts a --format value into a Format.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "zip":
+		return FormatZip, nil
+	case "tar.gz", "tgz":
+		return FormatTarGz, nil
+	default:
+		return "", fmt.Errorf("unknown archive format %q (expected zip or tar.gz)", s)
+	}
+}
+
+// FormatFromPath infers the format from an archive file name.
+func FormatFromPath(name string) (Format, bool) {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return FormatZip, true
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return FormatTarGz, true
+	default:
+		return "", false
+	}
+}
+
+// Manifest describes the bundled files.
+type Manifest struct {
+	// Name is the skill name, also the top-level folder of the archive.
+	Name string `json:"name"`
+
+	// Files lists every bundled file except the manifest, sorted by path.
+	Files []ManifestFile `json:"files"`
+}
+
+// ManifestFile is one bundled file.
+type ManifestFile struct {
+	// Path is slash-separated and relative to the skill directory.
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Excluded is a file left out of the archive (other than Options.Exclude).
+type Excluded struct {
+	Path   string
+	Reason string
+}
+
+// Options configures a bundle.
+type Options struct {
+	// Format is the archive format (FormatZip if empty).
+	Format Format
+
+	// Exclude lists additional paths to leave out silently, e.g. the archive
+	// itself when it is written inside the skill directory.
+	Exclude []string
+}
+
+// Result describes a written bundle.
+type Result struct {
+	Manifest *Manifest
+	Excluded []Excluded
+}
+
+// entry is a file or directory to archive.
+type entry struct {
+	path       string // slash-separated, relative to the skill directory
+	dir        bool
+	executable bool
+	content    []byte
+}
+
+// Write archives the skill directory dirPath into w. The archive contains a
+// single top-level folder named after the directory.
+func Write(w io.Writer, dirPath string, opts *Options) (*Result, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	format := opts.Format
+	if format == "" {
+		format = FormatZip
+	}
+
+	abs, err := filepath.Abs(dirPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", dirPath, err)
+	}
+	name := filepath.Base(abs)
+
+	entries, result, err := collect(abs, opts.Exclude)
+	if err != nil {
+		return nil, err
+	}
+	result.Manifest.Name = name
+
+	manifest, err := json.MarshalIndent(result.Manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	entries = append(entries, entry{path: ManifestName, content: append(manifest, '\n')})
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name("") < entries[j].name("") })
+
+	switch format {
+	case FormatZip:
+		err = writeZip(w, name, entries)
+	case FormatTarGz:
+		err = writeTarGz(w, name, entries)
+	default:
+		err = fmt.Errorf("unknown archive format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// collect reads the files to bundle. Hidden files and directories, anything
+// that is not a regular file and the excluded paths are left out.
+func collect(root string, exclude []string) ([]entry, *Result, error) {
+	excluded := make(map[string]bool)
+	for _, p := range exclude {
+		if abs, err := filepath.Abs(p); err == nil {
+			excluded[abs] = true
+		}
+	}
+
+	result := &Result{Manifest: &Manifest{Files: []ManifestFile{}}}
+	var entries []entry
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == root {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		exclude := func(reason string) error {
+			result.Excluded = append(result.Excluded, Excluded{Path: rel, Reason: reason})
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		switch {
+		case excluded[p]:
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		case strings.HasPrefix(d.Name(), "."):
+			return exclude("hidden file")
+		case rel == ManifestName:
+			return exclude("replaced by the generated manifest")
+		case d.IsDir():
+			entries = append(entries, entry{path: rel, dir: true})
+			return nil
+		case !d.Type().IsRegular():
+			return exclude("not a regular file")
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(content)
+		entries = append(entries, entry{path: rel, executable: info.Mode()&0111 != 0, content: content})
+		result.Manifest.Files = append(result.Manifest.Files, ManifestFile{
+			Path:   rel,
+			Size:   int64(len(content)),
+			SHA256: hex.EncodeToString(sum[:]),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read skill directory: %w", err)
+	}
+
+	sort.Slice(result.Manifest.Files, func(i, j int) bool {
+		return result.Manifest.Files[i].Path < result.Manifest.Files[j].Path
+	})
+	return entries, result, nil
+}
+
+// name returns the entry path as stored in the archive below the top-level
+// folder top; directories end in a slash.
+func (e entry) name(top string) string {
+	name := path.Join(top, e.path)
+	if e.dir {
+		name += "/"
+	}
+	return name
+}
+
+// mode returns the normalized permissions of an entry.
+func (e entry) mode() fs.FileMode {
+	switch {
+	case e.dir:
+		return fs.ModeDir | 0755
+	case e.executable:
+		return 0755
+	default:
+		return 0644
+	}
+}
+
+func writeZip(w io.Writer, name string, entries []entry) error {
+	zw := zip.NewWriter(w)
+	for _, e := range append([]entry{{dir: true}}, entries...) {
+		header := &zip.FileHeader{
+			Name:     e.name(name),
+			Method:   zip.Deflate,
+			Modified: ModTime,
+		}
+		if e.dir {
+			header.Method = zip.Store
+		}
+		header.SetMode(e.mode())
+		fw, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(e.content); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func writeTarGz(w io.Writer, name string, entries []entry) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, e := range append([]entry{{dir: true}}, entries...) {
+		header := &tar.Header{
+			Name:    e.name(name),
+			Mode:    int64(e.mode().Perm()),
+			ModTime: ModTime,
+			Size:    int64(len(e.content)),
+		}
+		header.Typeflag = tar.TypeReg
+		if e.dir {
+			header.Typeflag = tar.TypeDir
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(e.content); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
@@ -0,0 +1,210 @@
+package bundle
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeTree creates files (slash-separated path -> content) under a new skill directory.
+func writeTree(t *testing.T, name string, files map[string]string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), name)
+	for p, content := range files {
+		full := filepath.Join(dir, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+var skillFiles = map[string]string{
+	"SKILL.md":               "---\nname: demo\ndescription: Demo.\n---\n\n# Demo\n",
+	"scripts/run.sh":         "#!/bin/sh\necho run\n",
+	"scripts/.env":           "SECRET=1\n",
+	"references/a-b.md":      "# A-B\n",
+	"references/a/nested.md": "# Nested\n",
+	".git/config":            "[core]\n",
+}
+
+func TestWrite_Zip(t *testing.T) {
+	dir := writeTree(t, "demo", skillFiles)
+	if err := os.Chmod(filepath.Join(dir, "scripts", "run.sh"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	result, err := Write(&buf, dir, &Options{Format: FormatZip})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		if !f.Modified.Equal(ModTime) {
+			t.Errorf("%s: Modified = %v, want %v", f.Name, f.Modified, ModTime)
+		}
+	}
+	want := []string{
+		"demo/",
+		"demo/SKILL.md",
+		"demo/aglx-manifest.json",
+		"demo/references/",
+		"demo/references/a-b.md",
+		"demo/references/a/",
+		"demo/references/a/nested.md",
+		"demo/scripts/",
+		"demo/scripts/run.sh",
+	}
+	if strings.Join(names, "\n") != strings.Join(want, "\n") {
+		t.Errorf("entries =\n%s\nwant:\n%s", strings.Join(names, "\n"), strings.Join(want, "\n"))
+	}
+	for _, f := range zr.File {
+		if f.Name == "demo/scripts/run.sh" && f.Mode().Perm() != 0755 {
+			t.Errorf("run.sh mode = %v, want 0755", f.Mode().Perm())
+		}
+		if f.Name == "demo/SKILL.md" && f.Mode().Perm() != 0644 {
+			t.Errorf("SKILL.md mode = %v, want 0644", f.Mode().Perm())
+		}
+	}
+
+	var excluded []string
+	for _, e := range result.Excluded {
+		excluded = append(excluded, e.Path)
+	}
+	if strings.Join(excluded, ",") != ".git,scripts/.env" {
+		t.Errorf("excluded = %v", excluded)
+	}
+
+	// The stored manifest matches the returned one and the file contents.
+	rc, err := zr.Open("demo/" + ManifestName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	var manifest Manifest
+	if err := json.NewDecoder(rc).Decode(&manifest); err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Name != "demo" || len(manifest.Files) != 4 {
+		t.Fatalf("unexpected manifest: %+v", manifest)
+	}
+	for _, f := range manifest.Files {
+		sum := sha256.Sum256([]byte(skillFiles[f.Path]))
+		if f.SHA256 != hex.EncodeToString(sum[:]) || f.Size != int64(len(skillFiles[f.Path])) {
+			t.Errorf("manifest entry %+v does not match the file", f)
+		}
+	}
+}
+
+func TestWrite_TarGz(t *testing.T) {
+	dir := writeTree(t, "demo", skillFiles)
+
+	var buf bytes.Buffer
+	if _, err := Write(&buf, dir, &Options{Format: FormatTarGz}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	gz, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	var names []string
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, h.Name)
+		if !h.ModTime.Equal(ModTime) || h.Uid != 0 || h.Uname != "" {
+			t.Errorf("%s: header not normalized: %+v", h.Name, h)
+		}
+		if h.Name == "demo/SKILL.md" {
+			content, _ := io.ReadAll(tr)
+			if string(content) != skillFiles["SKILL.md"] {
+				t.Errorf("SKILL.md content = %q", content)
+			}
+		}
+	}
+	if len(names) != 9 || names[0] != "demo/" || names[2] != "demo/aglx-manifest.json" {
+		t.Errorf("unexpected entries: %v", names)
+	}
+}
+
+func TestWrite_Deterministic(t *testing.T) {
+	for _, format := range []Format{FormatZip, FormatTarGz} {
+		t.Run(string(format), func(t *testing.T) {
+			dir := writeTree(t, "demo", skillFiles)
+
+			var first, second bytes.Buffer
+			if _, err := Write(&first, dir, &Options{Format: format}); err != nil {
+				t.Fatal(err)
+			}
+			// Touch every file: modification times must not leak into the archive.
+			later := time.Now().Add(time.Hour)
+			for p := range skillFiles {
+				if err := os.Chtimes(filepath.Join(dir, filepath.FromSlash(p)), later, later); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if _, err := Write(&second, dir, &Options{Format: format}); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(first.Bytes(), second.Bytes()) {
+				t.Error("bundling the same files twice produced different archives")
+			}
+		})
+	}
+}
+
+func TestWrite_ExcludesArchive(t *testing.T) {
+	dir := writeTree(t, "demo", map[string]string{"SKILL.md": "---\nname: demo\n---\n", "demo.zip": "old"})
+
+	var buf bytes.Buffer
+	result, err := Write(&buf, dir, &Options{Exclude: []string{filepath.Join(dir, "demo.zip")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Manifest.Files) != 1 || result.Manifest.Files[0].Path != "SKILL.md" || len(result.Excluded) != 0 {
+		t.Errorf("expected demo.zip to be excluded, got %+v", result)
+	}
+}
+
+func TestFormats(t *testing.T) {
+	for in, want := range map[string]Format{"zip": FormatZip, "tar.gz": FormatTarGz, "tgz": FormatTarGz} {
+		if got, err := ParseFormat(in); err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v", in, got, err)
+		}
+	}
+	if _, err := ParseFormat("rar"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+	for in, want := range map[string]Format{"x.zip": FormatZip, "x.tar.gz": FormatTarGz, "X.TGZ": FormatTarGz, "x.tar": ""} {
+		if got, _ := FormatFromPath(in); got != want {
+			t.Errorf("FormatFromPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package bundle
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// memFS is a read-only in-memory file system holding the entries of an archive.
+// Parent directories are created implicitly.
+type memFS struct {
+	files map[string]*memFile
+}
+
+type memFile struct {
+	name string // full slash-separated path
+	data []byte
+	mode fs.FileMode
+
+	// children lists the entries of a directory, sorted by name.
+	children []string
+}
+
+func newMemFS() *memFS {
+	return &memFS{files: map[string]*memFile{".": {name: ".", mode: fs.ModeDir | 0755}}}
+}
+
+// mkdir creates the directory name and its parents.
+func (m *memFS) mkdir(name string) *memFile {
+	if f, ok := m.files[name]; ok {
+		return f
+	}
+	parent := m.mkdir(path.Dir(name))
+	f := &memFile{name: name, mode: fs.ModeDir | 0755}
+	m.files[name] = f
+	parent.addChild(path.Base(name))
+	return f
+}
+
+// add stores a regular file, replacing an earlier entry with the same name.
+func (m *memFS) add(name string, data []byte, mode fs.FileMode) {
+	if _, ok := m.files[name]; !ok {
+		m.mkdir(path.Dir(name)).addChild(path.Base(name))
+	}
+	m.files[name] = &memFile{name: name, data: data, mode: mode.Perm()}
+}
+
+func (f *memFile) addChild(name string) {
+	i := sort.SearchStrings(f.children, name)
+	if i < len(f.children) && f.children[i] == name {
+		return
+	}
+	f.children = append(f.children, "")
+	copy(f.children[i+1:], f.children[i:])
+	f.children[i] = name
+}
+
+// Open implements fs.FS.
+func (m *memFS) Open(name string) (fs.File, error) {
+	f, err := m.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+	if f.mode.IsDir() {
+		return &memDir{fs: m, file: f}, nil
+	}
+	return &memReader{file: f, Reader: bytes.NewReader(f.data)}, nil
+}
+
+// Stat implements fs.StatFS.
+func (m *memFS) Stat(name string) (fs.FileInfo, error) {
+	f, err := m.lookup("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return fileInfo{f}, nil
+}
+
+// ReadDir implements fs.ReadDirFS.
+func (m *memFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	f, err := m.lookup("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	if !f.mode.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	return m.entries(f), nil
+}
+
+func (m *memFS) lookup(op, name string) (*memFile, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	f, ok := m.files[name]
+	if !ok {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	return f, nil
+}
+
+func (m *memFS) entries(dir *memFile) []fs.DirEntry {
+	entries := make([]fs.DirEntry, len(dir.children))
+	for i, child := range dir.children {
+		entries[i] = fs.FileInfoToDirEntry(fileInfo{m.files[path.Join(dir.name, child)]})
+	}
+	return entries
+}
+
+// fileInfo implements fs.FileInfo for a memFile.
+type fileInfo struct{ f *memFile }
+
+func (i fileInfo) Name() string       { return path.Base(i.f.name) }
+func (i fileInfo) Size() int64        { return int64(len(i.f.data)) }
+func (i fileInfo) Mode() fs.FileMode  { return i.f.mode }
+func (i fileInfo) ModTime() time.Time { return ModTime }
+func (i fileInfo) IsDir() bool        { return i.f.mode.IsDir() }
+func (i fileInfo) Sys() any           { return nil }
+
+// memReader is an open regular file.
+type memReader struct {
+	file *memFile
+	*bytes.Reader
+}
+
+func (r *memReader) Stat() (fs.FileInfo, error) { return fileInfo{r.file}, nil }
+func (r *memReader) Close() error               { return nil }
+
+// memDir is an open directory.
+type memDir struct {
+	fs     *memFS
+	file   *memFile
+	offset int
+}
+
+func (d *memDir) Stat() (fs.FileInfo, error) { return fileInfo{d.file}, nil }
+func (d *memDir) Close() error               { return nil }
+
+func (d *memDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.file.name, Err: fs.ErrInvalid}
+}
+
+// ReadDir implements fs.ReadDirFile.
+func (d *memDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	entries := d.fs.entries(d.file)[d.offset:]
+	if n > 0 && len(entries) == 0 {
+		return nil, io.EOF
+	}
+	if n > 0 && len(entries) > n {
+		entries = entries[:n]
+	}
+	d.offset += len(entries)
+	return entries, nil
+}
+
+// topLevel returns the names of the entries at the root, ignoring hidden
+// entries and the "__MACOSX" folder some zip tools add.
+func (m *memFS) topLevel() []string {
+	var names []string
+	for _, name := range m.files["."].children {
+		if strings.HasPrefix(name, ".") || name == "__MACOSX" {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
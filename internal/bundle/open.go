@@ -0,0 +1,238 @@
+package bundle
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// Limits bounds the resources spent reading an untrusted archive.
+// Sizes are checked against the bytes actually decompressed, not the sizes
+// the archive claims.
+type Limits struct {
+	// MaxFiles is the maximum number of entries.
+	MaxFiles int
+
+	// MaxFileSize is the maximum decompressed size of a single file.
+	MaxFileSize int64
+
+	// MaxTotalSize is the maximum decompressed size of all files together.
+	MaxTotalSize int64
+}
+
+// DefaultLimits are used when Open is called without limits.
+var DefaultLimits = Limits{
+	MaxFiles:     10000,
+	MaxFileSize:  10 << 20,
+	MaxTotalSize: 100 << 20,
+}
+
+// IsArchive reports whether path names an existing regular file with a
+// supported archive extension.
+func IsArchive(path string) bool {
+	if _, ok := FormatFromPath(path); !ok {
+		return false
+	}
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// Open reads the zip or tar.gz archive at path into memory and returns a file
+// system rooted at its top-level folder, along with the folder's name. The
+// archive must contain a single top-level folder (the skill directory), as
+// written by Write. Entries with unsafe paths (absolute, containing "..")
+// and links are rejected.
+func Open(path string, limits *Limits) (fs.FS, string, error) {
+	if limits == nil {
+		limits = &DefaultLimits
+	}
+	format, ok := FormatFromPath(path)
+	if !ok {
+		return nil, "", fmt.Errorf("%s: unsupported archive format (expected .zip, .tar.gz or .tgz)", path)
+	}
+
+	r := &archiveReader{fsys: newMemFS(), limits: limits}
+	var err error
+	switch format {
+	case FormatZip:
+		err = r.readZip(path)
+	case FormatTarGz:
+		err = r.readTarGz(path)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", path, err)
+	}
+
+	top := r.fsys.topLevel()
+	if len(top) != 1 || !r.fsys.files[top[0]].mode.IsDir() {
+		return nil, "", fmt.Errorf("%s: archive must contain a single top-level skill folder (found %s)", path, describe(top))
+	}
+	sub, err := fs.Sub(r.fsys, top[0])
+	if err != nil {
+		return nil, "", err
+	}
+	return sub, top[0], nil
+}
+
+func describe(names []string) string {
+	if len(names) == 0 {
+		return "nothing"
+	}
+	return strings.Join(names, ", ")
+}
+
+// archiveReader loads archive entries into a memFS while enforcing limits.
+type archiveReader struct {
+	fsys   *memFS
+	limits *Limits
+	files  int
+	total  int64
+}
+
+// entryName validates an entry name and returns it as a clean fs.FS path.
+// Leading "./" prefixes, as written by `tar -C dir .`, are accepted.
+func entryName(name string) (string, error) {
+	clean := strings.TrimSuffix(name, "/")
+	for strings.HasPrefix(clean, "./") {
+		clean = clean[2:]
+	}
+	if clean == "" || clean == "." {
+		return ".", nil
+	}
+	if strings.Contains(clean, `\`) || !fs.ValidPath(clean) {
+		return "", fmt.Errorf("unsafe path %q in archive", name)
+	}
+	return clean, nil
+}
+
+// add reads one file entry from r.
+func (a *archiveReader) add(name string, r io.Reader, mode fs.FileMode) error {
+	a.files++
+	if a.files > a.limits.MaxFiles {
+		return fmt.Errorf("archive has more than %d entries", a.limits.MaxFiles)
+	}
+
+	// Read one byte past the limit to detect oversized files without trusting headers.
+	data, err := io.ReadAll(io.LimitReader(r, a.limits.MaxFileSize+1))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if int64(len(data)) > a.limits.MaxFileSize {
+		return fmt.Errorf("%s exceeds the maximum file size of %d bytes", name, a.limits.MaxFileSize)
+	}
+	a.total += int64(len(data))
+	if a.total > a.limits.MaxTotalSize {
+		return fmt.Errorf("archive exceeds the maximum total size of %d bytes", a.limits.MaxTotalSize)
+	}
+
+	if f, ok := a.fsys.files[name]; ok && f.mode.IsDir() {
+		return fmt.Errorf("%s is both a file and a directory", name)
+	}
+	a.fsys.add(name, data, mode)
+	return nil
+}
+
+func (a *archiveReader) mkdir(name string) error {
+	if f, ok := a.fsys.files[name]; ok && !f.mode.IsDir() {
+		return fmt.Errorf("%s is both a file and a directory", name)
+	}
+	if name != "." {
+		a.fsys.mkdir(name)
+	}
+	return nil
+}
+
+func (a *archiveReader) readZip(archive string) error {
+	zr, err := zip.OpenReader(archive)
+	if err != nil && !errors.Is(err, zip.ErrInsecurePath) {
+		return err
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		name, err := entryName(f.Name)
+		if err != nil {
+			return err
+		}
+		mode := f.Mode()
+		switch {
+		case mode.IsDir():
+			err = a.mkdir(name)
+		case mode&fs.ModeSymlink != 0:
+			err = fmt.Errorf("link %q in archive is not supported", f.Name)
+		case !mode.IsRegular():
+			err = fmt.Errorf("unsupported entry %q in archive", f.Name)
+		case f.UncompressedSize64 > uint64(a.limits.MaxFileSize):
+			// Reject early on the declared size; add checks the real size.
+			err = fmt.Errorf("%s exceeds the maximum file size of %d bytes", name, a.limits.MaxFileSize)
+		default:
+			err = a.readZipFile(f, name, mode)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *archiveReader) readZipFile(f *zip.File, name string, mode fs.FileMode) error {
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	defer rc.Close()
+	return a.add(name, rc, mode)
+}
+
+func (a *archiveReader) readTarGz(archive string) error {
+	file, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch h.Typeflag {
+		case tar.TypeXGlobalHeader:
+			continue
+		case tar.TypeSymlink, tar.TypeLink:
+			return fmt.Errorf("link %q in archive is not supported", h.Name)
+		}
+		name, err := entryName(h.Name)
+		if err != nil {
+			return err
+		}
+		switch h.Typeflag {
+		case tar.TypeDir:
+			err = a.mkdir(name)
+		case tar.TypeReg:
+			err = a.add(name, tr, fs.FileMode(h.Mode).Perm())
+		default:
+			err = fmt.Errorf("unsupported entry %q in archive", h.Name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
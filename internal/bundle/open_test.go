@@ -0,0 +1,235 @@
+package bundle
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// writeArchiveFile bundles dir into a file named name in a temporary directory.
+func writeArchiveFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	format, _ := FormatFromPath(name)
+	var buf bytes.Buffer
+	if _, err := Write(&buf, dir, &Options{Format: format}); err != nil {
+		t.Fatal(err)
+	}
+	target := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(target, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return target
+}
+
+// zipFile writes a zip archive with the given entries (name -> content;
+// names ending in "/" are directories).
+func zipFile(t *testing.T, entries ...[2]string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, e := range entries {
+		w, err := zw.Create(e[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(e[1]))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	target := filepath.Join(t.TempDir(), "test.zip")
+	if err := os.WriteFile(target, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return target
+}
+
+// tarGzFile writes a tar.gz archive with the given headers and contents.
+func tarGzFile(t *testing.T, headers []*tar.Header, contents []string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for i, h := range headers {
+		h.Size = int64(len(contents[i]))
+		if err := tw.WriteHeader(h); err != nil {
+			t.Fatal(err)
+		}
+		tw.Write([]byte(contents[i]))
+	}
+	tw.Close()
+	gz.Close()
+	target := filepath.Join(t.TempDir(), "test.tar.gz")
+	if err := os.WriteFile(target, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return target
+}
+
+func TestOpen_RoundTrip(t *testing.T) {
+	dir := writeTree(t, "demo", skillFiles)
+	for _, name := range []string{"demo.zip", "demo.tar.gz"} {
+		t.Run(name, func(t *testing.T) {
+			fsys, top, err := Open(writeArchiveFile(t, dir, name), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if top != "demo" {
+				t.Errorf("top = %q, want demo", top)
+			}
+			content, err := fs.ReadFile(fsys, "SKILL.md")
+			if err != nil || string(content) != skillFiles["SKILL.md"] {
+				t.Errorf("SKILL.md = %q, %v", content, err)
+			}
+			if err := fstest.TestFS(fsys, "SKILL.md", ManifestName, "scripts/run.sh", "references/a/nested.md"); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestOpen_DotSlashPrefix(t *testing.T) {
+	archive := tarGzFile(t,
+		[]*tar.Header{
+			{Name: "./", Typeflag: tar.TypeDir, Mode: 0755},
+			{Name: "./demo/", Typeflag: tar.TypeDir, Mode: 0755},
+			{Name: "./demo/SKILL.md", Typeflag: tar.TypeReg, Mode: 0644},
+		},
+		[]string{"", "", "---\nname: demo\n---\n"},
+	)
+	fsys, top, err := Open(archive, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := fs.Stat(fsys, "SKILL.md"); err != nil || top != "demo" {
+		t.Errorf("top = %q, stat error = %v", top, err)
+	}
+}
+
+func TestOpen_Rejects(t *testing.T) {
+	small := &Limits{MaxFiles: 2, MaxFileSize: 16, MaxTotalSize: 24}
+	tests := []struct {
+		name    string
+		archive func(t *testing.T) string
+		limits  *Limits
+		wantErr string
+	}{
+		{
+			name:    "parent directory",
+			archive: func(t *testing.T) string { return zipFile(t, [2]string{"demo/../../evil.sh", "x"}) },
+			wantErr: "unsafe path",
+		},
+		{
+			name:    "absolute path",
+			archive: func(t *testing.T) string { return zipFile(t, [2]string{"/etc/evil", "x"}) },
+			wantErr: "unsafe path",
+		},
+		{
+			name:    "backslash",
+			archive: func(t *testing.T) string { return zipFile(t, [2]string{`demo\..\..\evil`, "x"}) },
+			wantErr: "unsafe path",
+		},
+		{
+			name: "symlink",
+			archive: func(t *testing.T) string {
+				return tarGzFile(t, []*tar.Header{{Name: "demo/SKILL.md", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"}}, []string{""})
+			},
+			wantErr: "link",
+		},
+		{
+			name: "tar parent directory",
+			archive: func(t *testing.T) string {
+				return tarGzFile(t, []*tar.Header{{Name: "../evil", Typeflag: tar.TypeReg, Mode: 0644}}, []string{"x"})
+			},
+			wantErr: "unsafe path",
+		},
+		{
+			name:    "file too large",
+			archive: func(t *testing.T) string { return zipFile(t, [2]string{"demo/SKILL.md", strings.Repeat("a", 17)}) },
+			limits:  small,
+			wantErr: "maximum file size",
+		},
+		{
+			name: "tar file too large",
+			archive: func(t *testing.T) string {
+				return tarGzFile(t, []*tar.Header{{Name: "demo/SKILL.md", Typeflag: tar.TypeReg, Mode: 0644}}, []string{strings.Repeat("a", 17)})
+			},
+			limits:  small,
+			wantErr: "maximum file size",
+		},
+		{
+			name: "total too large",
+			archive: func(t *testing.T) string {
+				return zipFile(t, [2]string{"demo/a", strings.Repeat("a", 16)}, [2]string{"demo/b", strings.Repeat("b", 16)})
+			},
+			limits:  small,
+			wantErr: "maximum total size",
+		},
+		{
+			name: "too many files",
+			archive: func(t *testing.T) string {
+				return zipFile(t, [2]string{"demo/a", ""}, [2]string{"demo/b", ""}, [2]string{"demo/c", ""})
+			},
+			limits:  small,
+			wantErr: "more than 2 entries",
+		},
+		{
+			name: "several top-level folders",
+			archive: func(t *testing.T) string {
+				return zipFile(t, [2]string{"a/SKILL.md", ""}, [2]string{"b/SKILL.md", ""})
+			},
+			wantErr: "single top-level skill folder (found a, b)",
+		},
+		{
+			name:    "no folder",
+			archive: func(t *testing.T) string { return zipFile(t, [2]string{"SKILL.md", ""}) },
+			wantErr: "single top-level skill folder (found SKILL.md)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := Open(tt.archive(t), tt.limits)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Open() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOpen_IgnoresMacOSMetadata(t *testing.T) {
+	archive := zipFile(t,
+		[2]string{"demo/SKILL.md", "---\nname: demo\n---\n"},
+		[2]string{"__MACOSX/demo/._SKILL.md", ""},
+		[2]string{".DS_Store", ""},
+	)
+	if _, top, err := Open(archive, nil); err != nil || top != "demo" {
+		t.Errorf("Open() = %q, %v", top, err)
+	}
+}
+
+func TestIsArchive(t *testing.T) {
+	dir := writeTree(t, "demo", skillFiles)
+	if !IsArchive(writeArchiveFile(t, dir, "demo.tgz")) {
+		t.Error("expected a .tgz file to be an archive")
+	}
+	if IsArchive(dir) || IsArchive(filepath.Join(dir, "missing.zip")) {
+		t.Error("directories and missing files are not archives")
+	}
+
+	// A directory named like an archive is still a directory.
+	named := filepath.Join(t.TempDir(), "skill.zip")
+	if err := os.Mkdir(named, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if IsArchive(named) {
+		t.Error("a directory named skill.zip is not an archive")
+	}
+}
@@ -0,0 +1,56 @@
+package checker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeCorpus generates n valid skills with a body and resource directories
+// under root and returns their directories.
+func writeCorpus(tb testing.TB, root string, n int) []string {
+	tb.Helper()
+	body := strings.Repeat("Use this skill to process files step by step.\n", 200)
+	dirs := make([]string, n)
+	for i := range dirs {
+		name := fmt.Sprintf("skill-%04d", i)
+		dir := filepath.Join(root, name)
+		files := map[string]string{
+			"SKILL.md":             fmt.Sprintf("---\nname: %s\ndescription: Generated skill number %d.\nallowed-tools: Read, Grep, Bash(git:*)\n---\n\n# %s\n\n%s", name, i, name, body),
+			"CLAUDE.md":            "# Notes\n\n" + body,
+			"scripts/run.sh":       "#!/bin/sh\necho run\n",
+			"references/GUIDE.md":  "# Guide\n",
+			"references/.DS_Store": "",
+			"assets/template.json": "{}\n",
+		}
+		for p, content := range files {
+			full := filepath.Join(dir, filepath.FromSlash(p))
+			if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+				tb.Fatal(err)
+			}
+			if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+				tb.Fatal(err)
+			}
+		}
+		dirs[i] = dir
+	}
+	return dirs
+}
+
+func BenchmarkCheckMultiple(b *testing.B) {
+	dirs := writeCorpus(b, b.TempDir(), 300)
+	for _, concurrency := range []int{1, 4, 0} {
+		name := fmt.Sprintf("concurrency=%d", concurrency)
+		if concurrency == 0 {
+			name = "concurrency=cpus"
+		}
+		b.Run(name, func(b *testing.B) {
+			opts := &CheckOptions{Concurrency: concurrency}
+			for i := 0; i < b.N; i++ {
+				CheckMultipleWithOptions(dirs, opts)
+			}
+		})
+	}
+}
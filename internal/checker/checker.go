@@ -2,6 +2,15 @@
 package checker
 
 import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/biwakonbu/aglx/internal/bundle"
+	"github.com/biwakonbu/aglx/internal/claude"
+	"github.com/biwakonbu/aglx/internal/parallel"
+	"github.com/biwakonbu/aglx/internal/policy"
 	"github.com/biwakonbu/aglx/internal/skill"
 )
 
@@ -37,6 +46,27 @@ type CheckOptions struct {
 	// SpecAgentSkills: validates against agentskills.io specification only
 	// SpecClaudeCode: validates against Claude Code specification only
 	Spec skill.Spec
+
+	// Severities overrides the default severity of SKILL.md rules by rule ID.
+	Severities map[string]skill.Severity
+
+	// MaxBodyTokens and MaxBodyLines override the SKILL.md body thresholds (0 uses the defaults).
+	MaxBodyTokens int
+	MaxBodyLines  int
+
+	// Tools lists custom and MCP tool names allowed-tools may use in
+	// addition to the built-in catalogue.
+	Tools []string
+
+	// Policy is the allowed-tools security policy (nil for none).
+	Policy *policy.Policy
+
+	// ClaudeMd configures CLAUDE.md validation.
+	ClaudeMd claude.ValidationOptions
+
+	// Concurrency is the maximum number of directories CheckMultipleWithOptions
+	// checks at once (0 uses one per CPU, 1 checks them one by one).
+	Concurrency int
 }
 
 // SpecResult holds the validation result for a single specification.
@@ -45,10 +75,13 @@ type SpecResult struct {
 	Status           Status
 }
 
-// Result holds the validation result for SKILL.md.
+// Result holds the validation result for SKILL.md and CLAUDE.md.
 type Result struct {
 	Path string
 
+	// Spec is the specification selection the check was run with.
+	Spec skill.Spec
+
 	// Parsed skill (shared between specs)
 	Skill      *skill.Skill
 	ParseError error
@@ -56,6 +89,24 @@ type Result struct {
 	// Results per specification
 	AgentSkillsResult *SpecResult
 	ClaudeCodeResult  *SpecResult
+
+	// CLAUDE.md result (nil if no CLAUDE.md was found)
+	ClaudeMdResult *claude.ValidationResult
+	ClaudeMdError  error
+}
+
+// ClaudeMdStatus returns the status of the CLAUDE.md validation.
+func (r *Result) ClaudeMdStatus() Status {
+	switch {
+	case r.ClaudeMdError != nil:
+		return StatusFail
+	case r.ClaudeMdResult == nil:
+		return StatusNotFound
+	case r.ClaudeMdResult.HasWarnings():
+		return StatusWarning
+	default:
+		return StatusPass
+	}
 }
 
 // Check validates SKILL.md in the given directory.
@@ -64,7 +115,34 @@ func Check(dirPath string) *Result {
 }
 
 // CheckWithOptions validates SKILL.md with custom options.
+// If dirPath is a .zip, .tar.gz or .tgz file, the skill inside the archive
+// is validated (see CheckArchive).
 func CheckWithOptions(dirPath string, opts *CheckOptions) *Result {
+	if bundle.IsArchive(dirPath) {
+		return CheckArchive(dirPath, opts)
+	}
+	return CheckFS(os.DirFS(dirPath), dirPath, opts)
+}
+
+// CheckArchive validates the skill in a zip or tar.gz archive without
+// extracting it. The archive must hold a single top-level skill folder; the
+// result is reported under "<archive>/<folder>", so the skill name must match
+// the folder name.
+func CheckArchive(archivePath string, opts *CheckOptions) *Result {
+	fsys, top, err := bundle.Open(archivePath, nil)
+	if err != nil {
+		result := &Result{Path: archivePath, ParseError: err}
+		if opts != nil {
+			result.Spec = opts.Spec
+		}
+		return result
+	}
+	return CheckFS(fsys, filepath.Join(archivePath, top), opts)
+}
+
+// CheckFS validates the skill directory held by fsys (rooted at the
+// directory). dirPath is the path results are reported under.
+func CheckFS(fsys fs.FS, dirPath string, opts *CheckOptions) *Result {
 	result := &Result{
 		Path: dirPath,
 	}
@@ -72,9 +150,13 @@ func CheckWithOptions(dirPath string, opts *CheckOptions) *Result {
 	if opts == nil {
 		opts = &CheckOptions{}
 	}
+	result.Spec = opts.Spec
+
+	// CLAUDE.md is optional and independent of SKILL.md
+	checkClaudeMd(fsys, dirPath, result, &opts.ClaudeMd)
 
 	// Parse SKILL.md
-	parsedSkill, err := skill.Parse(dirPath)
+	parsedSkill, err := skill.ParseFS(fsys, dirPath)
 	if err != nil {
 		result.ParseError = err
 		return result
@@ -86,21 +168,54 @@ func CheckWithOptions(dirPath string, opts *CheckOptions) *Result {
 	switch opts.Spec {
 	case skill.SpecAuto:
 		// Validate against both specifications
-		result.AgentSkillsResult = validateWithSpec(parsedSkill, skill.SpecAgentSkills)
-		result.ClaudeCodeResult = validateWithSpec(parsedSkill, skill.SpecClaudeCode)
+		result.AgentSkillsResult = validateWithSpec(parsedSkill, skill.SpecAgentSkills, opts)
+		result.ClaudeCodeResult = validateWithSpec(parsedSkill, skill.SpecClaudeCode, opts)
 	case skill.SpecAgentSkills:
-		result.AgentSkillsResult = validateWithSpec(parsedSkill, skill.SpecAgentSkills)
+		result.AgentSkillsResult = validateWithSpec(parsedSkill, skill.SpecAgentSkills, opts)
 	case skill.SpecClaudeCode:
-		result.ClaudeCodeResult = validateWithSpec(parsedSkill, skill.SpecClaudeCode)
+		result.ClaudeCodeResult = validateWithSpec(parsedSkill, skill.SpecClaudeCode, opts)
 	}
 
 	return result
 }
 
-func validateWithSpec(parsedSkill *skill.Skill, spec skill.Spec) *SpecResult {
-	validationResult := skill.ValidateWithOptions(parsedSkill, &skill.ValidationOptions{
-		Spec: spec,
-	})
+func checkClaudeMd(fsys fs.FS, dirPath string, result *Result, opts *claude.ValidationOptions) {
+	claudeSkill, err := claude.ParseFromFS(fsys, dirPath)
+	if err != nil {
+		result.ClaudeMdError = err
+		return
+	}
+	if claudeSkill == nil {
+		return
+	}
+	result.ClaudeMdResult = claude.ValidateWithOptions(claudeSkill, opts)
+}
+
+// ValidationOptions returns the SKILL.md validation options for one
+// specification. Use it to validate a skill the way Check does when only
+// the SKILL.md content is at hand.
+func (o *CheckOptions) ValidationOptions(spec skill.Spec) *skill.ValidationOptions {
+	return &skill.ValidationOptions{
+		Spec:          spec,
+		Severities:    o.Severities,
+		MaxBodyTokens: o.MaxBodyTokens,
+		MaxBodyLines:  o.MaxBodyLines,
+		Tools:         o.Tools,
+		Policy:        o.Policy,
+	}
+}
+
+// Specs returns the specifications Check validates SKILL.md against: both
+// for SpecAuto, otherwise the selected one.
+func (o *CheckOptions) Specs() []skill.Spec {
+	if o.Spec == skill.SpecAuto {
+		return []skill.Spec{skill.SpecAgentSkills, skill.SpecClaudeCode}
+	}
+	return []skill.Spec{o.Spec}
+}
+
+func validateWithSpec(parsedSkill *skill.Skill, spec skill.Spec, opts *CheckOptions) *SpecResult {
+	validationResult := skill.ValidateWithOptions(parsedSkill, opts.ValidationOptions(spec))
 
 	var status Status
 	if validationResult.IsValid() {
@@ -125,10 +240,22 @@ func CheckMultiple(dirPaths []string) []*Result {
 }
 
 // CheckMultipleWithOptions validates multiple directories with custom options.
+// Results are in the order of dirPaths.
 func CheckMultipleWithOptions(dirPaths []string, opts *CheckOptions) []*Result {
-	var results []*Result
-	for _, dirPath := range dirPaths {
-		results = append(results, CheckWithOptions(dirPath, opts))
-	}
+	results, _ := CheckMultipleContext(context.Background(), dirPaths, opts)
 	return results
 }
+
+// CheckMultipleContext validates directories on a pool of opts.Concurrency
+// workers. Results are in the order of dirPaths however the work is
+// scheduled. If ctx is cancelled, no new directories are started and
+// ctx.Err() is returned without results.
+func CheckMultipleContext(ctx context.Context, dirPaths []string, opts *CheckOptions) ([]*Result, error) {
+	var workers int
+	if opts != nil {
+		workers = opts.Concurrency
+	}
+	return parallel.Map(ctx, workers, dirPaths, func(dirPath string) *Result {
+		return CheckWithOptions(dirPath, opts)
+	})
+}
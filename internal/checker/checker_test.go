@@ -1,10 +1,13 @@
 package checker
 
 import (
+	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/biwakonbu/aglx/internal/bundle"
 	"github.com/biwakonbu/aglx/internal/skill"
 )
 
@@ -89,3 +92,105 @@ func TestStatus_String(t *testing.T) {
 		t.Error("StatusNotFound.String() failed")
 	}
 }
+
+func TestCheck_ClaudeMd(t *testing.T) {
+	tmpDir := t.TempDir()
+	agentDir := filepath.Join(tmpDir, "my-skill")
+	os.Mkdir(agentDir, 0755)
+	os.WriteFile(filepath.Join(agentDir, "SKILL.md"), []byte("---\nname: my-skill\ndescription: test\n---"), 0644)
+
+	result := Check(agentDir)
+	if result.ClaudeMdResult != nil {
+		t.Error("expected no ClaudeMdResult without CLAUDE.md")
+	}
+	if result.ClaudeMdStatus() != StatusNotFound {
+		t.Errorf("expected N/A, got %s", result.ClaudeMdStatus())
+	}
+
+	os.WriteFile(filepath.Join(agentDir, "CLAUDE.md"), []byte(""), 0644)
+	result = Check(agentDir)
+	if result.ClaudeMdResult == nil {
+		t.Fatal("expected ClaudeMdResult")
+	}
+	if result.ClaudeMdStatus() != StatusWarning {
+		t.Errorf("expected WARN for empty CLAUDE.md, got %s", result.ClaudeMdStatus())
+	}
+}
+
+func TestCheck_Archive(t *testing.T) {
+	tmpDir := t.TempDir()
+	agentDir := filepath.Join(tmpDir, "my-skill")
+	os.MkdirAll(filepath.Join(agentDir, "scripts"), 0755)
+	os.WriteFile(filepath.Join(agentDir, "SKILL.md"), []byte("---\nname: other-name\ndescription: test\n---"), 0644)
+	os.WriteFile(filepath.Join(agentDir, "CLAUDE.md"), []byte("# Notes\n"), 0644)
+
+	// The archive file name does not matter; the skill name must match the top-level folder.
+	archive := filepath.Join(tmpDir, "renamed.zip")
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bundle.Write(f, agentDir, nil); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	result := Check(archive)
+	if result.ParseError != nil {
+		t.Fatalf("unexpected ParseError: %v", result.ParseError)
+	}
+	if want := filepath.Join(archive, "my-skill"); result.Path != want {
+		t.Errorf("Path = %q, want %q", result.Path, want)
+	}
+	if result.ClaudeMdResult == nil {
+		t.Error("expected CLAUDE.md inside the archive to be validated")
+	}
+
+	rules := make(map[string]bool)
+	for _, e := range result.AgentSkillsResult.ValidationResult.Errors {
+		rules[e.Rule] = true
+	}
+	if !rules[skill.RuleNameDirMatch] {
+		t.Errorf("expected %s against the archive folder, got %+v", skill.RuleNameDirMatch, result.AgentSkillsResult.ValidationResult.Errors)
+	}
+	// Directory rules run against the archive contents too.
+	if !rules[skill.RuleOptionalDirs] {
+		t.Errorf("expected %s for the empty scripts/ folder", skill.RuleOptionalDirs)
+	}
+
+	os.WriteFile(archive, []byte("not a zip"), 0644)
+	if result := Check(archive); result.ParseError == nil {
+		t.Error("expected ParseError for a corrupt archive")
+	}
+}
+
+func TestCheckMultipleContext(t *testing.T) {
+	dirs := writeCorpus(t, t.TempDir(), 40)
+	dirs = append(dirs, filepath.Join(t.TempDir(), "missing"))
+
+	sequential, err := CheckMultipleContext(context.Background(), dirs, &CheckOptions{Concurrency: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parallel, err := CheckMultipleContext(context.Background(), dirs, &CheckOptions{Concurrency: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range parallel {
+		if r.Path != dirs[i] || r.Path != sequential[i].Path {
+			t.Fatalf("result %d is for %s, want %s", i, r.Path, dirs[i])
+		}
+		if (r.ParseError == nil) != (sequential[i].ParseError == nil) {
+			t.Errorf("%s: parse error differs between runs", r.Path)
+		}
+	}
+	if parallel[len(dirs)-1].ParseError == nil {
+		t.Error("expected a ParseError for the missing directory")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if results, err := CheckMultipleContext(ctx, dirs, nil); !errors.Is(err, context.Canceled) || results != nil {
+		t.Errorf("expected context.Canceled, got %v results and %v", len(results), err)
+	}
+}
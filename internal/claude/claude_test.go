@@ -1,45 +1,55 @@
 package claude
 
 import (
-	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 func TestFindClaudeMd(t *testing.T) {
-	tmpDir := t.TempDir()
+	fsys := fstest.MapFS{}
 
 	// 1. None found
-	if found := FindClaudeMd(tmpDir); found != "" {
+	if found := FindClaudeMdFS(fsys); found != "" {
 		t.Errorf("expected empty string, got %q", found)
 	}
 
 	// 2. Root CLAUDE.md
-	rootPath := filepath.Join(tmpDir, "CLAUDE.md")
-	os.WriteFile(rootPath, []byte("root"), 0644)
-	if found := FindClaudeMd(tmpDir); found != rootPath {
-		t.Errorf("expected %q, got %q", rootPath, found)
+	fsys["CLAUDE.md"] = &fstest.MapFile{Data: []byte("root")}
+	if found := FindClaudeMdFS(fsys); found != "CLAUDE.md" {
+		t.Errorf("expected CLAUDE.md, got %q", found)
 	}
 
 	// 3. .claude/CLAUDE.md (should take priority)
-	claudeDir := filepath.Join(tmpDir, ".claude")
-	os.Mkdir(claudeDir, 0755)
-	nestedPath := filepath.Join(claudeDir, "CLAUDE.md")
-	os.WriteFile(nestedPath, []byte("nested"), 0644)
-	if found := FindClaudeMd(tmpDir); found != nestedPath {
-		t.Errorf("expected %q, got %q", nestedPath, found)
+	fsys[".claude/CLAUDE.md"] = &fstest.MapFile{Data: []byte("nested")}
+	if found := FindClaudeMdFS(fsys); found != ".claude/CLAUDE.md" {
+		t.Errorf("expected .claude/CLAUDE.md, got %q", found)
+	}
+
+	// ParseFromFS reports the file under the directory path.
+	skill, err := ParseFromFS(fsys, "my-skill")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := filepath.Join("my-skill", ".claude", "CLAUDE.md"); skill.Path != want || skill.Body != "nested" {
+		t.Errorf("expected %s with body \"nested\", got %s with %q", want, skill.Path, skill.Body)
+	}
+}
+
+func TestFindClaudeMd_OS(t *testing.T) {
+	dir := "../../testdata/valid/with-suppressions"
+	if found, want := FindClaudeMd(dir), filepath.Join(dir, "CLAUDE.md"); found != want {
+		t.Errorf("expected %q, got %q", want, found)
 	}
 }
 
 func TestParse(t *testing.T) {
-	tmpDir := t.TempDir()
-	filePath := filepath.Join(tmpDir, "CLAUDE.md")
+	filePath := filepath.Join("my-skill", "CLAUDE.md")
 
 	t.Run("Plain Markdown", func(t *testing.T) {
 		content := "# Hello\nWorld"
-		os.WriteFile(filePath, []byte(content), 0644)
-		skill, err := Parse(filePath)
+		skill, err := ParseReader(strings.NewReader(content), filePath)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -53,8 +63,7 @@ func TestParse(t *testing.T) {
 
 	t.Run("With Frontmatter", func(t *testing.T) {
 		content := "---\nname: test\n---\n# Body"
-		os.WriteFile(filePath, []byte(content), 0644)
-		skill, err := Parse(filePath)
+		skill, err := ParseReader(strings.NewReader(content), filePath)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -67,12 +76,29 @@ func TestParse(t *testing.T) {
 		if skill.Body != "# Body" {
 			t.Errorf("expected body '# Body', got %q", skill.Body)
 		}
+		if skill.BodyLine != 4 {
+			t.Errorf("expected body on line 4, got %d", skill.BodyLine)
+		}
+	})
+
+	t.Run("Blank Lines After Frontmatter", func(t *testing.T) {
+		content := "---\nname: test\n---\n\n\n# Body"
+		skill, err := ParseReader(strings.NewReader(content), filePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if skill.BodyLine != 6 {
+			t.Errorf("expected body on line 6, got %d", skill.BodyLine)
+		}
+		result := Validate(&ClaudeSkill{Path: filePath, BodyLine: skill.BodyLine})
+		if len(result.Warnings) != 1 || result.Warnings[0].Pos.Line != 6 || result.Warnings[0].Pos.File != filePath {
+			t.Errorf("expected warning positioned at body start, got %v", result.Warnings)
+		}
 	})
 
 	t.Run("Malformed Frontmatter", func(t *testing.T) {
 		content := "---\nname: test\nNo closing"
-		os.WriteFile(filePath, []byte(content), 0644)
-		skill, err := Parse(filePath)
+		skill, err := ParseReader(strings.NewReader(content), filePath)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -84,9 +110,23 @@ func TestParse(t *testing.T) {
 		}
 	})
 
+	t.Run("CRLF Line Endings", func(t *testing.T) {
+		content := "---\r\nname: test\r\n---\r\n\r\n# Body\r\nText\r\n"
+		skill, err := ParseReader(strings.NewReader(content), filePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if skill.Frontmatter["name"] != "test" || skill.Body != "# Body\nText" || skill.BodyLine != 5 {
+			t.Errorf("unexpected parse: frontmatter %v, body %q on line %d", skill.Frontmatter, skill.Body, skill.BodyLine)
+		}
+		result := Validate(skill)
+		if len(result.Warnings) != 1 || result.Warnings[0].Rule != RuleFileFormat || result.Warnings[0].Pos.Line != 1 {
+			t.Errorf("expected one %s warning on line 1, got %v", RuleFileFormat, result.Warnings)
+		}
+	})
+
 	t.Run("Empty File", func(t *testing.T) {
-		os.WriteFile(filePath, []byte(""), 0644)
-		skill, err := Parse(filePath)
+		skill, err := ParseReader(strings.NewReader(""), filePath)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -144,3 +184,47 @@ func TestValidate(t *testing.T) {
 		}
 	})
 }
+
+func TestValidateWithOptions(t *testing.T) {
+	t.Run("Custom thresholds", func(t *testing.T) {
+		skill := &ClaudeSkill{BodySize: 30000}
+		result := ValidateWithOptions(skill, &ValidationOptions{WarningBodySize: 40000})
+		if result.HasWarnings() {
+			t.Errorf("expected no warnings below custom threshold, got %v", result.Warnings)
+		}
+
+		result = ValidateWithOptions(skill, &ValidationOptions{WarningBodySize: 10000, MaxBodySize: 25000})
+		if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0].Message, "very large (>25KB)") {
+			t.Errorf("expected very large warning at custom limit, got %v", result.Warnings)
+		}
+	})
+
+	t.Run("Disabled rule", func(t *testing.T) {
+		skill := &ClaudeSkill{BodySize: 0}
+		result := ValidateWithOptions(skill, &ValidationOptions{Disabled: map[string]bool{RuleBodyEmpty: true}})
+		if result.HasWarnings() {
+			t.Errorf("expected disabled rule to stay silent, got %v", result.Warnings)
+		}
+	})
+}
+
+func TestValidate_Suppressions(t *testing.T) {
+	skill, err := Parse("../../testdata/valid/with-suppressions/CLAUDE.md")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(skill.Suppressions) != 1 || skill.Suppressions[0].Pos.Line != 3 {
+		t.Fatalf("unexpected suppressions: %+v", skill.Suppressions)
+	}
+
+	result := Validate(skill)
+	if len(result.Warnings) != 1 || result.Warnings[0].Rule != RuleUnusedSuppression {
+		t.Fatalf("expected an unused suppression warning, got %v", result.Warnings)
+	}
+
+	skill.BodySize = 30000
+	result = Validate(skill)
+	if result.HasWarnings() {
+		t.Errorf("expected the body size warning to be suppressed, got %v", result.Warnings)
+	}
+}
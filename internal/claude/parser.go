@@ -2,32 +2,37 @@
 package claude
 
 import (
-	"bufio"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
-	"strings"
 
+	"github.com/biwakonbu/aglx/internal/frontmatter"
+	"github.com/biwakonbu/aglx/internal/suppress"
 	"gopkg.in/yaml.v3"
 )
 
-const frontmatterDelimiter = "---"
-
 // FindClaudeMd searches for CLAUDE.md in the given directory and its .claude subdirectory.
 // Returns the path if found, empty string if not found.
 func FindClaudeMd(dirPath string) string {
-	// Check .claude/CLAUDE.md first
-	claudeDirPath := filepath.Join(dirPath, ClaudeDir, ClaudeFileName)
-	if _, err := os.Stat(claudeDirPath); err == nil {
-		return claudeDirPath
+	name := FindClaudeMdFS(os.DirFS(dirPath))
+	if name == "" {
+		return ""
 	}
+	return filepath.Join(dirPath, filepath.FromSlash(name))
+}
 
-	// Check CLAUDE.md in the root directory
-	rootPath := filepath.Join(dirPath, ClaudeFileName)
-	if _, err := os.Stat(rootPath); err == nil {
-		return rootPath
+// FindClaudeMdFS returns the slash-separated name of CLAUDE.md within fsys,
+// or "" if there is none.
+func FindClaudeMdFS(fsys fs.FS) string {
+	// Check .claude/CLAUDE.md first, then CLAUDE.md in the root directory
+	for _, name := range []string{path.Join(ClaudeDir, ClaudeFileName), ClaudeFileName} {
+		if _, err := fs.Stat(fsys, name); err == nil {
+			return name
+		}
 	}
-
 	return ""
 }
 
@@ -41,86 +46,65 @@ func Parse(filePath string) (*ClaudeSkill, error) {
 		return nil, fmt.Errorf("failed to open CLAUDE.md: %w", err)
 	}
 	defer file.Close()
+	return ParseReader(file, filePath)
+}
 
-	skill := &ClaudeSkill{
-		Path: filePath,
-	}
-
-	// Read all content
-	scanner := bufio.NewScanner(file)
-	var lines []string
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading file: %w", err)
+// ParseReader parses CLAUDE.md content read from r; filePath is the path
+// positions and findings are reported under.
+func ParseReader(r io.Reader, filePath string) (*ClaudeSkill, error) {
+	file, err := frontmatter.Read(r)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(lines) == 0 {
-		skill.Body = ""
-		skill.BodySize = 0
-		return skill, nil
+	// A frontmatter block that is never closed is treated as Markdown.
+	skill := &ClaudeSkill{
+		Path:           filePath,
+		HasFrontmatter: file.HasFrontmatter,
+		Body:           file.Body,
+		BodySize:       len(file.Body),
+		BodyLine:       file.BodyLine,
+		FileIssues:     file.Issues,
 	}
 
-	// Check for frontmatter
-	if strings.TrimSpace(lines[0]) == frontmatterDelimiter {
-		frontmatter, bodyStart, err := extractFrontmatter(lines)
-		if err != nil {
-			// If frontmatter extraction fails, treat entire file as body
-			skill.HasFrontmatter = false
-			skill.Body = strings.Join(lines, "\n")
-			skill.BodySize = len(skill.Body)
-			return skill, nil
-		}
-
-		skill.HasFrontmatter = true
-
-		// Parse frontmatter as YAML
+	// Parse frontmatter as YAML, keeping the node tree for suppression positions
+	var doc yaml.Node
+	if file.HasFrontmatter {
 		var fm map[string]interface{}
-		if err := yaml.Unmarshal([]byte(frontmatter), &fm); err != nil {
+		err = yaml.Unmarshal([]byte(file.Frontmatter), &doc)
+		if err == nil && len(doc.Content) > 0 {
+			err = doc.Decode(&fm)
+		}
+		if err != nil {
 			// If YAML parsing fails, still record that frontmatter exists
 			skill.Frontmatter = make(map[string]interface{})
+			doc = yaml.Node{}
 		} else {
 			skill.Frontmatter = fm
 		}
-
-		// Set body
-		if bodyStart < len(lines) {
-			skill.Body = strings.TrimLeft(strings.Join(lines[bodyStart:], "\n"), "\n")
-		}
-	} else {
-		// No frontmatter, entire file is body
-		skill.HasFrontmatter = false
-		skill.Body = strings.Join(lines, "\n")
 	}
 
-	skill.BodySize = len(skill.Body)
+	skill.Suppressions = suppress.Parse(filePath, &doc, skill.Body, skill.BodyLine)
 	return skill, nil
 }
 
-// extractFrontmatter extracts YAML frontmatter from lines.
-// Returns the frontmatter content, the line index where body starts, and any error.
-func extractFrontmatter(lines []string) (string, int, error) {
-	if len(lines) == 0 || strings.TrimSpace(lines[0]) != frontmatterDelimiter {
-		return "", 0, fmt.Errorf("no frontmatter delimiter at start")
-	}
-
-	// Find closing delimiter
-	for i := 1; i < len(lines); i++ {
-		if strings.TrimSpace(lines[i]) == frontmatterDelimiter {
-			frontmatter := strings.Join(lines[1:i], "\n")
-			return frontmatter, i + 1, nil
-		}
-	}
-
-	return "", 0, fmt.Errorf("no closing frontmatter delimiter")
-}
-
 // ParseFromDir finds and parses CLAUDE.md from the given directory.
 func ParseFromDir(dirPath string) (*ClaudeSkill, error) {
-	filePath := FindClaudeMd(dirPath)
-	if filePath == "" {
+	return ParseFromFS(os.DirFS(dirPath), dirPath)
+}
+
+// ParseFromFS finds and parses CLAUDE.md in fsys, which holds a skill
+// directory reported under dirPath.
+func ParseFromFS(fsys fs.FS, dirPath string) (*ClaudeSkill, error) {
+	name := FindClaudeMdFS(fsys)
+	if name == "" {
 		return nil, nil // Not found, but not an error
 	}
-	return Parse(filePath)
+
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CLAUDE.md: %w", err)
+	}
+	defer file.Close()
+	return ParseReader(file, filepath.Join(dirPath, filepath.FromSlash(name)))
 }
@@ -1,6 +1,12 @@
 // Package claude provides types and utilities for parsing and validating Claude Skills (CLAUDE.md).
 package claude
 
+import (
+	"github.com/biwakonbu/aglx/internal/frontmatter"
+	"github.com/biwakonbu/aglx/internal/source"
+	"github.com/biwakonbu/aglx/internal/suppress"
+)
+
 // ClaudeSkill represents a parsed CLAUDE.md file.
 type ClaudeSkill struct {
 	// Path is the file path to the CLAUDE.md file.
@@ -17,6 +23,21 @@ type ClaudeSkill struct {
 
 	// BodySize is the size of the body in bytes.
 	BodySize int
+
+	// BodyLine is the 1-based line on which the body starts (0 if unknown).
+	BodyLine int
+
+	// Suppressions lists the rules disabled by inline directives.
+	Suppressions []suppress.Directive
+
+	// FileIssues lists the irregularities found while reading the file
+	// (byte order mark, CRLF line endings, long lines, unusual delimiters).
+	FileIssues []frontmatter.Issue
+}
+
+// BodyPosition returns the position where the Markdown body starts.
+func (s *ClaudeSkill) BodyPosition() source.Position {
+	return source.Position{File: s.Path, Line: s.BodyLine}
 }
 
 // Locations where CLAUDE.md files can be found.
@@ -1,10 +1,22 @@
 // Package claude provides types and utilities for parsing and validating Claude Skills (CLAUDE.md).
 package claude
 
+import (
+	"fmt"
+
+	"github.com/biwakonbu/aglx/internal/source"
+	"github.com/biwakonbu/aglx/internal/suppress"
+)
+
 // ValidationWarning represents a non-fatal warning during validation.
 type ValidationWarning struct {
+	// Rule is the ID of the rule that produced the warning.
+	Rule    string
 	Field   string
 	Message string
+
+	// Pos is the location of the finding (file and line when known).
+	Pos source.Position
 }
 
 // ValidationResult holds the result of validating a Claude skill.
@@ -24,6 +36,23 @@ func (r *ValidationResult) HasWarnings() bool {
 	return len(r.Warnings) > 0
 }
 
+// Rule IDs for CLAUDE.md checks. They share a namespace with the skill
+// package's rule registry, so they carry a "claude-md-" prefix.
+const (
+	RuleBodySize          = "claude-md-body-size"
+	RuleBodyEmpty         = "claude-md-empty"
+	RuleUnusedSuppression = "claude-md-unused-suppression"
+	RuleFileFormat        = "claude-md-file-format"
+)
+
+// RuleDescriptions maps each CLAUDE.md rule ID to a one-line summary.
+var RuleDescriptions = map[string]string{
+	RuleBodySize:          "CLAUDE.md should stay small enough for the context window",
+	RuleBodyEmpty:         "CLAUDE.md should not be empty",
+	RuleUnusedSuppression: "CLAUDE.md suppression directives should name a known rule that reports something",
+	RuleFileFormat:        "CLAUDE.md should be plain UTF-8 with LF line endings and \"---\" delimiters",
+}
+
 const (
 	// RecommendedMaxBodySize is the recommended maximum body size in bytes.
 	// This is a soft limit based on typical context window constraints.
@@ -33,35 +62,103 @@ const (
 	WarningBodySize = 20000 // ~20KB
 )
 
+// ValidationOptions configures CLAUDE.md validation.
+type ValidationOptions struct {
+	// WarningBodySize overrides WarningBodySize (0 uses the default).
+	WarningBodySize int
+
+	// MaxBodySize overrides RecommendedMaxBodySize (0 uses the default).
+	MaxBodySize int
+
+	// Disabled lists rule IDs that must not report warnings.
+	Disabled map[string]bool
+}
+
+func (o *ValidationOptions) warningBodySize() int {
+	if o.WarningBodySize > 0 {
+		return o.WarningBodySize
+	}
+	return WarningBodySize
+}
+
+func (o *ValidationOptions) maxBodySize() int {
+	if o.MaxBodySize > 0 {
+		return o.MaxBodySize
+	}
+	return RecommendedMaxBodySize
+}
+
 // Validate checks a Claude skill and returns warnings for potential issues.
 // Unlike Agent Skills, Claude Skills validation is more lenient.
 func Validate(skill *ClaudeSkill) *ValidationResult {
+	return ValidateWithOptions(skill, nil)
+}
+
+// ValidateWithOptions checks a Claude skill with custom thresholds and disabled rules.
+func ValidateWithOptions(skill *ClaudeSkill, opts *ValidationOptions) *ValidationResult {
 	result := &ValidationResult{Skill: skill}
 
 	if skill == nil {
 		return result
 	}
+	if opts == nil {
+		opts = &ValidationOptions{}
+	}
 
-	// Warning: Large body size
-	if skill.BodySize > RecommendedMaxBodySize {
-		result.Warnings = append(result.Warnings, ValidationWarning{
-			Field:   "body",
-			Message: "file is very large (>50KB), may impact context window usage",
-		})
-	} else if skill.BodySize > WarningBodySize {
+	suppressions := suppress.NewTracker(skill.Suppressions)
+	report := func(rule, field, message string, pos source.Position) {
+		if opts.Disabled[rule] || suppressions.Suppresses(rule) {
+			return
+		}
 		result.Warnings = append(result.Warnings, ValidationWarning{
-			Field:   "body",
-			Message: "file is moderately large (>20KB), consider splitting",
+			Rule:    rule,
+			Field:   field,
+			Pos:     pos,
+			Message: message,
 		})
 	}
+	warn := func(rule, message string) {
+		report(rule, "body", message, skill.BodyPosition())
+	}
+
+	// Warning: Irregularities found while reading the file
+	for _, issue := range skill.FileIssues {
+		report(RuleFileFormat, "file", issue.Message, source.Position{File: skill.Path, Line: issue.Line})
+	}
+
+	// Warning: Large body size
+	if maxSize := opts.maxBodySize(); skill.BodySize > maxSize {
+		warn(RuleBodySize, fmt.Sprintf("file is very large (>%s), may impact context window usage", formatSize(maxSize)))
+	} else if warnSize := opts.warningBodySize(); skill.BodySize > warnSize {
+		warn(RuleBodySize, fmt.Sprintf("file is moderately large (>%s), consider splitting", formatSize(warnSize)))
+	}
 
 	// Warning: Empty body
 	if skill.BodySize == 0 {
-		result.Warnings = append(result.Warnings, ValidationWarning{
-			Field:   "body",
-			Message: "file is empty",
-		})
+		warn(RuleBodyEmpty, "file is empty")
+	}
+
+	// Warning: Suppressions that did not suppress anything (checked last)
+	for _, d := range suppressions.Unused() {
+		switch _, known := RuleDescriptions[d.Rule]; {
+		case d.Rule == RuleUnusedSuppression, opts.Disabled[d.Rule]:
+			continue
+		case d.Rule == "":
+			report(RuleUnusedSuppression, "suppression", "aglx-disable directive does not name any rule", d.Pos)
+		case !known:
+			report(RuleUnusedSuppression, "suppression", fmt.Sprintf("unknown rule %q", d.Rule), d.Pos)
+		default:
+			report(RuleUnusedSuppression, "suppression", fmt.Sprintf("rule %q reported nothing to suppress", d.Rule), d.Pos)
+		}
 	}
 
 	return result
 }
+
+// formatSize renders a byte threshold as "20KB", or "1500 bytes" when not a whole number of KB.
+func formatSize(n int) string {
+	if n >= 1000 && n%1000 == 0 {
+		return fmt.Sprintf("%dKB", n/1000)
+	}
+	return fmt.Sprintf("%d bytes", n)
+}
@@ -0,0 +1,189 @@
+// Package config loads the per-repository aglx configuration file (.aglx.yaml).
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/biwakonbu/aglx/internal/checker"
+	"github.com/biwakonbu/aglx/internal/claude"
+	"github.com/biwakonbu/aglx/internal/policy"
+	"github.com/biwakonbu/aglx/internal/skill"
+	"github.com/biwakonbu/aglx/internal/source"
+)
+
+// FileNames lists the config file names searched for, in order of preference.
+var FileNames = []string{".aglx.yaml", ".aglx.yml"}
+
+// Config is the parsed contents of a config file.
+type Config struct {
+	// Path is the file the config was loaded from ("" for the default config).
+	Path string
+
+	// Spec is the specification to validate against unless overridden by --spec.
+	Spec skill.Spec
+
+	// Rules overrides rule severities by rule ID.
+	Rules map[string]skill.Severity
+
+	// Thresholds overrides size limits. Zero values keep the built-in defaults.
+	Thresholds Thresholds
+
+	// Tools lists custom and MCP tools known in addition to the built-in
+	// tool catalogue ("mcp__server" allows every tool of a server).
+	Tools []string
+
+	// Policy is the allowed-tools policy named by the config (nil if none).
+	Policy *policy.Policy
+}
+
+// Thresholds holds the configurable size limits.
+type Thresholds struct {
+	// MaxBodyTokens replaces skill.MaxBodyTokensRecommended.
+	MaxBodyTokens int
+	// MaxBodyLines replaces skill.MaxBodyLinesClaudeCode.
+	MaxBodyLines int
+	// ClaudeMdWarningBodySize replaces claude.WarningBodySize (bytes).
+	ClaudeMdWarningBodySize int
+	// ClaudeMdMaxBodySize replaces claude.RecommendedMaxBodySize (bytes).
+	ClaudeMdMaxBodySize int
+}
+
+// Problem is a single schema violation in a config file.
+type Problem struct {
+	Pos     source.Position
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.Pos, p.Message)
+}
+
+// SchemaError reports every schema violation found in a config or policy file.
+type SchemaError struct {
+	Path     string
+	Problems []Problem
+
+	// kind names the file in the message ("" for a config file).
+	kind string
+}
+
+func (e *SchemaError) Error() string {
+	kind := e.kind
+	if kind == "" {
+		kind = "config"
+	}
+	lines := make([]string, 0, len(e.Problems)+1)
+	lines = append(lines, fmt.Sprintf("invalid %s file %s:", kind, e.Path))
+	for _, p := range e.Problems {
+		lines = append(lines, "  "+p.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Find searches dir and its parents for a config file.
+// It returns "" without error when no config file exists.
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		for _, name := range FileNames {
+			path := filepath.Join(dir, name)
+			info, err := os.Stat(path)
+			if err == nil && !info.IsDir() {
+				return path, nil
+			}
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return "", err
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// LoadNearest loads the config file found by Find(dir), or returns an empty
+// config if there is none.
+func LoadNearest(dir string) (*Config, error) {
+	path, err := Find(dir)
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return &Config{}, nil
+	}
+	return Load(path)
+}
+
+// Load reads and validates the config file at path.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return Parse(path, data)
+}
+
+// Parse validates data against the config schema. path is used in error positions only.
+func Parse(path string, data []byte) (*Config, error) {
+	cfg := &Config{Path: path}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		return cfg, nil // empty file
+	}
+
+	p := &schemaParser{path: path, cfg: cfg}
+	p.parseRoot(doc.Content[0])
+	p.checkThresholds()
+	if len(p.problems) > 0 {
+		return nil, &SchemaError{Path: path, Problems: p.problems}
+	}
+	return cfg, nil
+}
+
+// CheckOptions converts the config into checker options.
+// Severities for CLAUDE.md rules become disabled rules.
+func (c *Config) CheckOptions() *checker.CheckOptions {
+	opts := &checker.CheckOptions{
+		Spec:          c.Spec,
+		MaxBodyTokens: c.Thresholds.MaxBodyTokens,
+		MaxBodyLines:  c.Thresholds.MaxBodyLines,
+		Tools:         c.Tools,
+		Policy:        c.Policy,
+		ClaudeMd: claude.ValidationOptions{
+			WarningBodySize: c.Thresholds.ClaudeMdWarningBodySize,
+			MaxBodySize:     c.Thresholds.ClaudeMdMaxBodySize,
+		},
+	}
+	for id, sev := range c.Rules {
+		if _, ok := claude.RuleDescriptions[id]; ok {
+			if sev == skill.SeverityOff {
+				if opts.ClaudeMd.Disabled == nil {
+					opts.ClaudeMd.Disabled = make(map[string]bool)
+				}
+				opts.ClaudeMd.Disabled[id] = true
+			}
+			continue
+		}
+		if opts.Severities == nil {
+			opts.Severities = make(map[string]skill.Severity)
+		}
+		opts.Severities[id] = sev
+	}
+	return opts
+}
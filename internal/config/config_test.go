@@ -0,0 +1,270 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/biwakonbu/aglx/internal/policy"
+	"github.com/biwakonbu/aglx/internal/skill"
+)
+
+func TestParse_Valid(t *testing.T) {
+	data := []byte(`# project policy
+spec: claude-code
+rules:
+  body-size: error
+  hidden-files: off
+  claude-md-empty: off
+thresholds:
+  max-body-tokens: 8000
+  max-body-lines: 300
+  claude-md-warning-body-size: 30000
+  claude-md-max-body-size: 60000
+tools:
+  - DeployPreview
+  - mcp__github
+`)
+
+	cfg, err := Parse(".aglx.yaml", data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Spec != skill.SpecClaudeCode {
+		t.Errorf("Spec = %q, want %q", cfg.Spec, skill.SpecClaudeCode)
+	}
+	if cfg.Rules[skill.RuleBodySize] != skill.SeverityError || cfg.Rules[skill.RuleHiddenFiles] != skill.SeverityOff {
+		t.Errorf("unexpected rules: %v", cfg.Rules)
+	}
+	want := Thresholds{MaxBodyTokens: 8000, MaxBodyLines: 300, ClaudeMdWarningBodySize: 30000, ClaudeMdMaxBodySize: 60000}
+	if cfg.Thresholds != want {
+		t.Errorf("Thresholds = %+v, want %+v", cfg.Thresholds, want)
+	}
+
+	opts := cfg.CheckOptions()
+	if opts.Spec != skill.SpecClaudeCode || opts.MaxBodyTokens != 8000 || opts.MaxBodyLines != 300 {
+		t.Errorf("unexpected check options: %+v", opts)
+	}
+	if opts.Severities[skill.RuleBodySize] != skill.SeverityError {
+		t.Errorf("expected body-size severity in check options, got %v", opts.Severities)
+	}
+	if strings.Join(opts.Tools, ",") != "DeployPreview,mcp__github" {
+		t.Errorf("Tools = %v, want [DeployPreview mcp__github]", opts.Tools)
+	}
+	if _, ok := opts.Severities["claude-md-empty"]; ok {
+		t.Error("CLAUDE.md rules must not leak into SKILL.md severities")
+	}
+	if !opts.ClaudeMd.Disabled["claude-md-empty"] || opts.ClaudeMd.WarningBodySize != 30000 || opts.ClaudeMd.MaxBodySize != 60000 {
+		t.Errorf("unexpected CLAUDE.md options: %+v", opts.ClaudeMd)
+	}
+}
+
+func TestParse_Empty(t *testing.T) {
+	cfg, err := Parse(".aglx.yaml", []byte("# nothing configured\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Spec != skill.SpecAuto || len(cfg.Rules) != 0 || cfg.Thresholds != (Thresholds{}) {
+		t.Errorf("expected default config, got %+v", cfg)
+	}
+}
+
+func TestParse_SchemaErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"not a mapping", "- spec\n", "1:1: config must be a mapping"},
+		{"unknown key", "specs: claude-code\n", `1:1: unknown key "specs"`},
+		{"duplicate key", "spec: auto\nspec: claude-code\n", `2:1: duplicate key "spec"`},
+		{"unknown spec", "spec: gpt\n", `1:7: unknown spec "gpt"`},
+		{"spec not a string", "spec: [auto]\n", "1:7: spec must be a string"},
+		{"unknown rule", "rules:\n  body-sise: off\n", `2:3: unknown rule "body-sise"`},
+		{"bad severity", "rules:\n  body-size: fatal\n", `2:14: unknown severity "fatal"`},
+		{"claude rule as error", "rules:\n  claude-md-empty: error\n", `2:20: CLAUDE.md rule "claude-md-empty" only supports off or warning`},
+		{"rules not a mapping", "rules: body-size\n", "1:8: rules must be a mapping"},
+		{"unknown threshold", "thresholds:\n  max-body-size: 10\n", `2:3: unknown threshold "max-body-size"`},
+		{"negative threshold", "thresholds:\n  max-body-lines: -1\n", "2:19: max-body-lines must be a positive integer"},
+		{"string threshold", "thresholds:\n  max-body-tokens: \"100\"\n", "2:20: max-body-tokens must be a positive integer"},
+		{"tools not a list", "tools: Read\n", "1:8: tools must be a list of tool names"},
+		{"tool with arguments", "tools:\n  - Bash(git:*)\n", `2:5: invalid tool name "Bash(git:*)"`},
+		{"two tools in one entry", "tools:\n  - Read Grep\n", `2:5: invalid tool name "Read Grep"`},
+		{"malformed tool", "tools:\n  - mcp__\n", `2:5: invalid tool name "mcp__": missing MCP server name`},
+		{"tool not a string", "tools:\n  - 42\n", "2:5: tool names must be strings"},
+		{"inverted claude thresholds", "thresholds:\n  claude-md-warning-body-size: 70000\n", "claude-md-warning-body-size (70000) must not exceed claude-md-max-body-size (50000)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(".aglx.yaml", []byte(tt.data))
+			var schemaErr *SchemaError
+			if !errors.As(err, &schemaErr) {
+				t.Fatalf("expected *SchemaError, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %q does not contain %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_ReportsAllProblems(t *testing.T) {
+	_, err := Parse(".aglx.yaml", []byte("spec: gpt\nrules:\n  nope: off\n"))
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("expected *SchemaError, got %v", err)
+	}
+	if len(schemaErr.Problems) != 2 {
+		t.Errorf("expected 2 problems, got %v", schemaErr.Problems)
+	}
+}
+
+func TestParse_InvalidYAML(t *testing.T) {
+	_, err := Parse(".aglx.yaml", []byte("spec: [\n"))
+	if err == nil {
+		t.Fatal("expected error for invalid YAML")
+	}
+	var schemaErr *SchemaError
+	if errors.As(err, &schemaErr) {
+		t.Errorf("YAML syntax errors should not be schema errors: %v", err)
+	}
+}
+
+func TestLoad_Policy(t *testing.T) {
+	cfg, err := Load("../../testdata/config/policy/.aglx.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Policy == nil || len(cfg.Policy.Rules) != 2 {
+		t.Fatalf("expected the policy of security.yaml, got %+v", cfg.Policy)
+	}
+	if opts := cfg.CheckOptions(); opts.Policy != cfg.Policy {
+		t.Error("policy must be passed to the check options")
+	}
+	r := cfg.Policy.Rules[0]
+	if r.ID != "restricted-shell-and-write" || r.MaxBreadth != policy.BreadthPattern || strings.Join(r.Tools, ",") != "Bash,Write" {
+		t.Errorf("unexpected first rule: %+v", r)
+	}
+}
+
+func TestParsePolicy_SchemaErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"unknown top-level key", "policies: []\n", `1:1: unknown key "policies" (expected rules)`},
+		{"rules not a list", "rules: {}\n", "1:8: rules must be a list"},
+		{"missing id", "rules:\n  - deny: [Bash]\n", "2:5: rule must have an id"},
+		{"bad id", "rules:\n  - id: No_Bash\n    deny: [Bash]\n", `2:9: id "No_Bash" must be lowercase kebab-case`},
+		{"duplicate id", "rules:\n  - id: a\n    deny: [Bash]\n  - id: a\n    deny: [Write]\n", `4:5: duplicate rule ID "a"`},
+		{"no requirement", "rules:\n  - id: a\n", `2:5: rule "a" must set at least one of deny, allow, max-breadth or max-tools`},
+		{"tools alone", "rules:\n  - id: a\n    tools: [Bash]\n    deny: [Write]\n", `2:5: rule "a": tools only applies to allow and max-breadth`},
+		{"invalid pattern", "rules:\n  - id: a\n    deny: [\"Bash(rm:*\"]\n", `3:12: invalid tool pattern "Bash(rm:*": unclosed "("`},
+		{"two patterns in one entry", "rules:\n  - id: a\n    deny: [\"Bash Write\"]\n", `3:12: invalid tool pattern "Bash Write"`},
+		{"tools with arguments", "rules:\n  - id: a\n    tools: [\"Bash(ls)\"]\n    max-breadth: exact\n", `3:12: tools must list tool names without arguments`},
+		{"unknown breadth", "rules:\n  - id: a\n    max-breadth: wide\n", `3:18: unknown breadth "wide"`},
+		{"bad max-tools", "rules:\n  - id: a\n    max-tools: 0\n", "3:16: max-tools must be a positive integer"},
+		{"unknown rule key", "rules:\n  - id: a\n    forbid: [Bash]\n", `3:5: unknown key "forbid"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePolicy("policy.yaml", []byte(tt.data))
+			var schemaErr *SchemaError
+			if !errors.As(err, &schemaErr) {
+				t.Fatalf("expected *SchemaError, got %v", err)
+			}
+			if !strings.Contains(err.Error(), "invalid policy file policy.yaml") || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %q does not contain %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_PolicyProblems(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "policy.yaml"), []byte("rules:\n  - id: a\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := Parse(filepath.Join(dir, ".aglx.yaml"), []byte("policy: policy.yaml\n"))
+	if err == nil || !strings.Contains(err.Error(), filepath.Join(dir, "policy.yaml")+":2:5: rule \"a\" must set") {
+		t.Errorf("expected the policy problem with its position, got %v", err)
+	}
+
+	_, err = Parse(filepath.Join(dir, ".aglx.yaml"), []byte("policy: missing.yaml\n"))
+	if err == nil || !strings.Contains(err.Error(), "1:9: failed to read policy file") {
+		t.Errorf("expected a missing policy file error, got %v", err)
+	}
+}
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	configPath := filepath.Join(root, ".aglx.yml")
+	if err := os.WriteFile(configPath, []byte("spec: auto\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Find(nested)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != configPath {
+		t.Errorf("Find = %q, want %q", got, configPath)
+	}
+
+	// .aglx.yaml takes precedence over .aglx.yml in the same directory.
+	preferred := filepath.Join(root, ".aglx.yaml")
+	if err := os.WriteFile(preferred, []byte("spec: auto\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := Find(nested); got != preferred {
+		t.Errorf("Find = %q, want %q", got, preferred)
+	}
+
+	// The nearest config wins.
+	nearer := filepath.Join(root, "a", ".aglx.yaml")
+	if err := os.WriteFile(nearer, []byte("spec: auto\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := Find(nested); got != nearer {
+		t.Errorf("Find = %q, want %q", got, nearer)
+	}
+}
+
+func TestLoadNearest(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "skills", "demo")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".aglx.yaml"), []byte("spec: claude-code\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadNearest(nested)
+	if err != nil || cfg.Spec != skill.SpecClaudeCode {
+		t.Errorf("LoadNearest() = %+v, %v", cfg, err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	cfg, err := Load("../../testdata/config/claude-code/.aglx.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Spec != skill.SpecClaudeCode {
+		t.Errorf("Spec = %q, want %q", cfg.Spec, skill.SpecClaudeCode)
+	}
+
+	if _, err := Load("../../testdata/config/does-not-exist.yaml"); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
@@ -0,0 +1,185 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/biwakonbu/aglx/internal/policy"
+	"github.com/biwakonbu/aglx/internal/toolspec"
+)
+
+// Keys of the rules of a policy file (which has a single key, rules). The
+// tools key is shared with the config file.
+const (
+	keyID          = "id"
+	keyDescription = "description"
+	keyDeny        = "deny"
+	keyAllow       = "allow"
+	keyMaxBreadth  = "max-breadth"
+	keyMaxTools    = "max-tools"
+)
+
+// policyIDPattern matches policy rule IDs: lowercase kebab-case, like the
+// IDs of built-in rules.
+var policyIDPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// LoadPolicy reads and validates the allowed-tools policy file at path.
+func LoadPolicy(path string) (*policy.Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+	return ParsePolicy(path, data)
+}
+
+// ParsePolicy validates data against the policy file schema. path is used
+// in error positions only.
+func ParsePolicy(path string, data []byte) (*policy.Policy, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file %s: %w", path, err)
+	}
+
+	p := &policyParser{schemaParser: schemaParser{path: path}, policy: &policy.Policy{Path: path}}
+	if len(doc.Content) > 0 {
+		p.parseRoot(doc.Content[0])
+	}
+	if len(p.problems) > 0 {
+		return nil, &SchemaError{Path: path, Problems: p.problems, kind: "policy"}
+	}
+	return p.policy, nil
+}
+
+// policyParser walks a policy file with the helpers of the config schema.
+type policyParser struct {
+	schemaParser
+	policy *policy.Policy
+}
+
+func (p *policyParser) parseRoot(n *yaml.Node) {
+	p.mapping(n, "policy", func(key, value *yaml.Node) {
+		if key.Value != keyRules {
+			p.report(key, "unknown key %q (expected %s)", key.Value, keyRules)
+			return
+		}
+		if value.Kind != yaml.SequenceNode {
+			p.report(value, "rules must be a list")
+			return
+		}
+		seen := make(map[string]bool)
+		for _, item := range value.Content {
+			r := p.parseRule(item)
+			if r == nil {
+				continue
+			}
+			if seen[r.ID] {
+				p.report(item, "duplicate rule ID %q", r.ID)
+				continue
+			}
+			seen[r.ID] = true
+			p.policy.Rules = append(p.policy.Rules, r)
+		}
+	})
+}
+
+// parseRule reads one rule, returning nil if it has problems.
+func (p *policyParser) parseRule(n *yaml.Node) *policy.Rule {
+	before := len(p.problems)
+	r := &policy.Rule{}
+	p.mapping(n, "rule", func(key, value *yaml.Node) {
+		switch key.Value {
+		case keyID:
+			if s, ok := p.string(value, keyID); ok {
+				if !policyIDPattern.MatchString(s) {
+					p.report(value, "id %q must be lowercase kebab-case", s)
+				}
+				r.ID = s
+			}
+		case keyDescription:
+			r.Description, _ = p.string(value, keyDescription)
+		case keyDeny:
+			r.Deny = p.patterns(value, keyDeny)
+		case keyAllow:
+			r.Allow = p.patterns(value, keyAllow)
+		case keyTools:
+			for _, t := range p.patterns(value, keyTools) {
+				if t.Args != nil {
+					p.report(value, "tools must list tool names without arguments (got %q)", t.Raw)
+					continue
+				}
+				r.Tools = append(r.Tools, t.Name)
+			}
+		case keyMaxBreadth:
+			if s, ok := p.string(value, keyMaxBreadth); ok {
+				b, err := policy.ParseBreadth(s)
+				if err != nil {
+					p.report(value, "%v", err)
+				}
+				r.MaxBreadth = b
+			}
+		case keyMaxTools:
+			v, err := strconv.Atoi(value.Value)
+			if value.Kind != yaml.ScalarNode || value.Tag != "!!int" || err != nil || v <= 0 {
+				p.report(value, "%s must be a positive integer", keyMaxTools)
+				return
+			}
+			r.MaxTools = v
+		default:
+			p.report(key, "unknown key %q (expected %s, %s, %s, %s, %s, %s or %s)", key.Value,
+				keyID, keyDescription, keyDeny, keyAllow, keyTools, keyMaxBreadth, keyMaxTools)
+		}
+	})
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	switch {
+	case r.ID == "" && len(p.problems) == before:
+		p.report(n, "rule must have an id")
+	case len(r.Deny) == 0 && len(r.Allow) == 0 && r.MaxBreadth == 0 && r.MaxTools == 0 && len(p.problems) == before:
+		p.report(n, "rule %q must set at least one of %s, %s, %s or %s", r.ID, keyDeny, keyAllow, keyMaxBreadth, keyMaxTools)
+	case len(r.Tools) > 0 && len(r.Allow) == 0 && r.MaxBreadth == 0 && len(p.problems) == before:
+		p.report(n, "rule %q: tools only applies to %s and %s", r.ID, keyAllow, keyMaxBreadth)
+	}
+	if len(p.problems) > before {
+		return nil
+	}
+	return r
+}
+
+func (p *policyParser) string(n *yaml.Node, what string) (string, bool) {
+	if n.Kind != yaml.ScalarNode || n.Tag != "!!str" {
+		p.report(n, "%s must be a string", what)
+		return "", false
+	}
+	return n.Value, true
+}
+
+// patterns reads a list of allowed-tools entries, such as "Bash(rm:*)".
+func (p *policyParser) patterns(n *yaml.Node, what string) []*toolspec.Tool {
+	if n.Kind != yaml.SequenceNode {
+		p.report(n, "%s must be a list of tool patterns", what)
+		return nil
+	}
+	var tools []*toolspec.Tool
+	for _, item := range n.Content {
+		if item.Kind != yaml.ScalarNode || item.Tag != "!!str" {
+			p.report(item, "%s entries must be strings", what)
+			continue
+		}
+		list := toolspec.Parse(item.Value)
+		switch {
+		case len(list.Errors) > 0:
+			p.report(item, "invalid tool pattern %q: %v", item.Value, list.Errors[0])
+		case len(list.Tools) != 1:
+			p.report(item, "invalid tool pattern %q (expected one entry such as Bash(rm:*))", item.Value)
+		default:
+			tools = append(tools, list.Tools[0])
+		}
+	}
+	return tools
+}
@@ -0,0 +1,222 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/biwakonbu/aglx/internal/claude"
+	"github.com/biwakonbu/aglx/internal/skill"
+	"github.com/biwakonbu/aglx/internal/source"
+	"github.com/biwakonbu/aglx/internal/toolspec"
+)
+
+// Top-level keys of the config file.
+const (
+	keySpec       = "spec"
+	keyRules      = "rules"
+	keyThresholds = "thresholds"
+	keyTools      = "tools"
+	keyPolicy     = "policy"
+)
+
+// Keys of the thresholds section.
+const (
+	keyMaxBodyTokens           = "max-body-tokens"
+	keyMaxBodyLines            = "max-body-lines"
+	keyClaudeMdWarningBodySize = "claude-md-warning-body-size"
+	keyClaudeMdMaxBodySize     = "claude-md-max-body-size"
+)
+
+// schemaParser walks the YAML tree, filling in the config and collecting
+// every problem rather than stopping at the first one.
+type schemaParser struct {
+	path     string
+	cfg      *Config
+	problems []Problem
+}
+
+func (p *schemaParser) report(n *yaml.Node, format string, args ...interface{}) {
+	p.problems = append(p.problems, Problem{
+		Pos:     source.Position{File: p.path, Line: n.Line, Column: n.Column},
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// mapping iterates over the key/value pairs of a mapping node,
+// reporting duplicate keys and non-mapping nodes.
+func (p *schemaParser) mapping(n *yaml.Node, what string, fn func(key, value *yaml.Node)) {
+	if n.Kind != yaml.MappingNode {
+		if n.Tag == "!!null" {
+			return
+		}
+		p.report(n, "%s must be a mapping", what)
+		return
+	}
+	seen := make(map[string]bool)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		if seen[key.Value] {
+			p.report(key, "duplicate key %q", key.Value)
+			continue
+		}
+		seen[key.Value] = true
+		fn(key, value)
+	}
+}
+
+func (p *schemaParser) parseRoot(n *yaml.Node) {
+	p.mapping(n, "config", func(key, value *yaml.Node) {
+		switch key.Value {
+		case keySpec:
+			p.parseSpec(value)
+		case keyRules:
+			p.parseRules(value)
+		case keyThresholds:
+			p.parseThresholds(value)
+		case keyTools:
+			p.parseTools(value)
+		case keyPolicy:
+			p.parsePolicy(value)
+		default:
+			p.report(key, "unknown key %q (expected %s, %s, %s, %s or %s)", key.Value, keySpec, keyRules, keyThresholds, keyTools, keyPolicy)
+		}
+	})
+}
+
+func (p *schemaParser) parseSpec(n *yaml.Node) {
+	if n.Kind != yaml.ScalarNode || n.Tag != "!!str" {
+		p.report(n, "spec must be a string")
+		return
+	}
+	spec, err := skill.ParseSpec(n.Value)
+	if err != nil {
+		p.report(n, "%v", err)
+		return
+	}
+	p.cfg.Spec = spec
+}
+
+func (p *schemaParser) parseRules(n *yaml.Node) {
+	p.cfg.Rules = make(map[string]skill.Severity)
+	p.mapping(n, "rules", func(key, value *yaml.Node) {
+		_, isClaude := claude.RuleDescriptions[key.Value]
+		if _, ok := skill.LookupRule(key.Value); !ok && !isClaude {
+			p.report(key, "unknown rule %q (run 'aglx rules' for the list)", key.Value)
+			return
+		}
+		if value.Kind != yaml.ScalarNode {
+			p.report(value, "severity of %q must be off, warning or error", key.Value)
+			return
+		}
+		sev, err := skill.ParseSeverity(value.Value)
+		if err != nil {
+			p.report(value, "%v", err)
+			return
+		}
+		if isClaude && sev == skill.SeverityError {
+			p.report(value, "CLAUDE.md rule %q only supports off or warning", key.Value)
+			return
+		}
+		p.cfg.Rules[key.Value] = sev
+	})
+}
+
+func (p *schemaParser) parseThresholds(n *yaml.Node) {
+	t := &p.cfg.Thresholds
+	targets := map[string]*int{
+		keyMaxBodyTokens:           &t.MaxBodyTokens,
+		keyMaxBodyLines:            &t.MaxBodyLines,
+		keyClaudeMdWarningBodySize: &t.ClaudeMdWarningBodySize,
+		keyClaudeMdMaxBodySize:     &t.ClaudeMdMaxBodySize,
+	}
+	p.mapping(n, "thresholds", func(key, value *yaml.Node) {
+		target, ok := targets[key.Value]
+		if !ok {
+			p.report(key, "unknown threshold %q (expected %s, %s, %s or %s)", key.Value,
+				keyMaxBodyTokens, keyMaxBodyLines, keyClaudeMdWarningBodySize, keyClaudeMdMaxBodySize)
+			return
+		}
+		v, err := strconv.Atoi(value.Value)
+		if value.Kind != yaml.ScalarNode || value.Tag != "!!int" || err != nil || v <= 0 {
+			p.report(value, "%s must be a positive integer", key.Value)
+			return
+		}
+		*target = v
+	})
+}
+
+// parseTools reads the custom and MCP tool names that extend the built-in
+// tool catalogue. Each entry must be a single tool name without arguments.
+func (p *schemaParser) parseTools(n *yaml.Node) {
+	if n.Kind != yaml.SequenceNode {
+		if n.Tag != "!!null" {
+			p.report(n, "tools must be a list of tool names")
+		}
+		return
+	}
+	for _, item := range n.Content {
+		if item.Kind != yaml.ScalarNode || item.Tag != "!!str" {
+			p.report(item, "tool names must be strings")
+			continue
+		}
+		list := toolspec.Parse(item.Value)
+		switch {
+		case len(list.Errors) > 0:
+			p.report(item, "invalid tool name %q: %v", item.Value, list.Errors[0])
+		case len(list.Tools) != 1 || list.Tools[0].Args != nil:
+			p.report(item, "invalid tool name %q (expected one name such as DeployPreview, mcp__server or mcp__server__tool)", item.Value)
+		default:
+			p.cfg.Tools = append(p.cfg.Tools, item.Value)
+		}
+	}
+}
+
+// parsePolicy loads the policy file named by the config. A relative path is
+// relative to the directory of the config file.
+func (p *schemaParser) parsePolicy(n *yaml.Node) {
+	if n.Kind != yaml.ScalarNode || n.Tag != "!!str" || n.Value == "" {
+		p.report(n, "policy must be the path of a policy file")
+		return
+	}
+	path := n.Value
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(p.path), path)
+	}
+	pol, err := LoadPolicy(path)
+	var schemaErr *SchemaError
+	if errors.As(err, &schemaErr) {
+		// The problems carry positions in the policy file.
+		p.problems = append(p.problems, schemaErr.Problems...)
+		return
+	}
+	if err != nil {
+		p.report(n, "%v", err)
+		return
+	}
+	p.cfg.Policy = pol
+}
+
+// checkThresholds reports combinations that can never produce a sensible result.
+func (p *schemaParser) checkThresholds() {
+	t := p.cfg.Thresholds
+	if t.ClaudeMdWarningBodySize == 0 && t.ClaudeMdMaxBodySize == 0 {
+		return
+	}
+	warn, limit := t.ClaudeMdWarningBodySize, t.ClaudeMdMaxBodySize
+	if warn == 0 {
+		warn = claude.WarningBodySize
+	}
+	if limit == 0 {
+		limit = claude.RecommendedMaxBodySize
+	}
+	if warn > limit {
+		p.problems = append(p.problems, Problem{
+			Pos:     source.Position{File: p.path},
+			Message: fmt.Sprintf("%s (%d) must not exceed %s (%d)", keyClaudeMdWarningBodySize, warn, keyClaudeMdMaxBodySize, limit),
+		})
+	}
+}
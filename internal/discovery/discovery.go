@@ -0,0 +1,159 @@
+// Package discovery finds skill directories (directories containing SKILL.md) under a root.
+package discovery
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	skillFileName = "SKILL.md"
+	gitignoreName = ".gitignore"
+)
+
+// SymlinkPolicy controls how symbolic links to directories are handled.
+type SymlinkPolicy int
+
+const (
+	// SymlinkSkip ignores symbolic links to directories (default).
+	SymlinkSkip SymlinkPolicy = iota
+	// SymlinkFollow descends into symbolic links to directories.
+	// Each real directory is visited at most once to avoid cycles.
+	SymlinkFollow
+)
+
+// DefaultExcludes are always applied in addition to Options.Exclude.
+var DefaultExcludes = []string{".git/", "node_modules/"}
+
+// Options configures skill discovery.
+type Options struct {
+	// Exclude holds .gitignore-style patterns relative to each root.
+	// Discover returns an error for a pattern that is not a valid glob.
+	Exclude []string
+
+	// UseGitignore applies .gitignore files found during the walk.
+	UseGitignore bool
+
+	// Symlinks selects the symbolic link policy.
+	Symlinks SymlinkPolicy
+}
+
+// Discover walks root and returns every directory containing a SKILL.md file,
+// in lexical order. The root itself is returned if it contains SKILL.md.
+// A skill directory is treated as a leaf: its subdirectories are not searched.
+func Discover(root string, opts *Options) ([]string, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to access %s: %w", root, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", root)
+	}
+
+	matcher := &ignoreMatcher{}
+	for _, p := range DefaultExcludes {
+		_ = matcher.add("", p)
+	}
+	for _, p := range opts.Exclude {
+		if err := matcher.add("", p); err != nil {
+			return nil, fmt.Errorf("invalid exclude: %w", err)
+		}
+	}
+
+	w := &walker{opts: opts, visited: make(map[string]bool)}
+	if err := w.walk(root, "", matcher); err != nil {
+		return nil, err
+	}
+	return w.found, nil
+}
+
+// DiscoverMultiple runs Discover for each root and concatenates the results.
+// Duplicate directories are reported once. It continues past failing roots,
+// collecting errors.
+func DiscoverMultiple(roots []string, opts *Options) ([]string, []error) {
+	var dirs []string
+	var errs []error
+	seen := make(map[string]bool)
+
+	for _, root := range roots {
+		found, err := Discover(root, opts)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, dir := range found {
+			key := filepath.Clean(dir)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			dirs = append(dirs, dir)
+		}
+	}
+
+	return dirs, errs
+}
+
+type walker struct {
+	opts    *Options
+	visited map[string]bool
+	found   []string
+}
+
+// walk visits dir, whose slash-separated path relative to the root is rel.
+func (w *walker) walk(dir, rel string, matcher *ignoreMatcher) error {
+	if real, err := filepath.EvalSymlinks(dir); err == nil {
+		if w.visited[real] {
+			return nil
+		}
+		w.visited[real] = true
+	}
+
+	if info, err := os.Stat(filepath.Join(dir, skillFileName)); err == nil && !info.IsDir() {
+		w.found = append(w.found, dir)
+		return nil
+	}
+
+	if w.opts.UseGitignore {
+		matcher = matcher.clone()
+		if err := matcher.addFile(rel, filepath.Join(dir, gitignoreName)); err != nil {
+			return fmt.Errorf("failed to read %s: %w", filepath.Join(dir, gitignoreName), err)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	for _, entry := range entries {
+		childPath := filepath.Join(dir, entry.Name())
+		childRel := joinRel(rel, entry.Name())
+
+		isDir := entry.IsDir()
+		if entry.Type()&os.ModeSymlink != 0 {
+			if w.opts.Symlinks != SymlinkFollow {
+				continue
+			}
+			info, err := os.Stat(childPath)
+			if err != nil {
+				continue // dangling link
+			}
+			isDir = info.IsDir()
+		}
+		if !isDir || matcher.ignored(childRel, true) {
+			continue
+		}
+
+		if err := w.walk(childPath, childRel, matcher); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,252 @@
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// makeTree creates files (with parent directories) under root.
+func makeTree(t *testing.T, root string, files ...string) {
+	t.Helper()
+	for _, f := range files {
+		p := filepath.Join(root, filepath.FromSlash(f))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("---\nname: x\ndescription: x\n---\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func relPaths(t *testing.T, root string, dirs []string) []string {
+	t.Helper()
+	var out []string
+	for _, d := range dirs {
+		rel, err := filepath.Rel(root, d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, filepath.ToSlash(rel))
+	}
+	return out
+}
+
+func TestDiscover_NestedLayouts(t *testing.T) {
+	root := t.TempDir()
+	makeTree(t, root,
+		"skills/alpha/SKILL.md",
+		"skills/beta/SKILL.md",
+		"skills/beta/assets/template/SKILL.md", // inside a skill: not a separate skill
+		".claude/skills/gamma/SKILL.md",
+		"plugins/tools/skills/delta/SKILL.md",
+		".git/skills/ignored/SKILL.md",
+		"node_modules/pkg/SKILL.md",
+		"docs/README.md",
+	)
+
+	dirs, err := Discover(root, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		".claude/skills/gamma",
+		"plugins/tools/skills/delta",
+		"skills/alpha",
+		"skills/beta",
+	}
+	if got := relPaths(t, root, dirs); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDiscover_RootIsSkill(t *testing.T) {
+	root := t.TempDir()
+	makeTree(t, root, "SKILL.md")
+
+	dirs, err := Discover(root, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dirs) != 1 || dirs[0] != root {
+		t.Errorf("expected root to be discovered, got %v", dirs)
+	}
+}
+
+func TestDiscover_Excludes(t *testing.T) {
+	root := t.TempDir()
+	makeTree(t, root,
+		"skills/keep/SKILL.md",
+		"skills/drafts/wip/SKILL.md",
+		"vendor/skills/other/SKILL.md",
+		"a/b/tmp-skill/SKILL.md",
+	)
+
+	dirs, err := Discover(root, &Options{Exclude: []string{"drafts/", "/vendor", "**/tmp-*"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"skills/keep"}
+	if got := relPaths(t, root, dirs); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDiscover_Gitignore(t *testing.T) {
+	root := t.TempDir()
+	makeTree(t, root,
+		"skills/keep/SKILL.md",
+		"skills/build/SKILL.md",
+		"skills/generated-a/SKILL.md",
+		"skills/generated-b/SKILL.md",
+		"skills/[z-a]/SKILL.md",
+	)
+	// "[z-a]" is not a valid glob and is matched literally; "x[]" matches "x[]".
+	os.WriteFile(filepath.Join(root, ".gitignore"), []byte("# comment\nbuild/\n[z-a]\nx[]\n"), 0644)
+	os.WriteFile(filepath.Join(root, "skills", ".gitignore"), []byte("generated-*\n!generated-b\n"), 0644)
+
+	dirs, err := Discover(root, &Options{UseGitignore: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"skills/generated-b", "skills/keep"}
+	if got := relPaths(t, root, dirs); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Without UseGitignore everything is found
+	dirs, err = Discover(root, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dirs) != 5 {
+		t.Errorf("expected 5 skills without gitignore, got %v", dirs)
+	}
+}
+
+func TestDiscover_InvalidExclude(t *testing.T) {
+	root := t.TempDir()
+	makeTree(t, root, "skill/SKILL.md")
+
+	if _, err := Discover(root, &Options{Exclude: []string{"[z-a]"}}); err == nil {
+		t.Error("expected an error for an invalid exclude pattern")
+	}
+}
+
+func TestDiscover_Symlinks(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	makeTree(t, root, "skills/local/SKILL.md")
+	makeTree(t, outside, "linked/SKILL.md")
+
+	if err := os.Symlink(outside, filepath.Join(root, "skills", "external")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	// A cycle back to the root must not loop forever
+	os.Symlink(root, filepath.Join(root, "skills", "loop"))
+
+	dirs, err := Discover(root, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := relPaths(t, root, dirs); !reflect.DeepEqual(got, []string{"skills/local"}) {
+		t.Errorf("default policy should skip symlinks, got %v", got)
+	}
+
+	dirs, err = Discover(root, &Options{Symlinks: SymlinkFollow})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"skills/external/linked", "skills/local"}
+	if got := relPaths(t, root, dirs); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDiscover_Errors(t *testing.T) {
+	if _, err := Discover(filepath.Join(t.TempDir(), "missing"), nil); err == nil {
+		t.Error("expected error for missing root")
+	}
+
+	file := filepath.Join(t.TempDir(), "file")
+	os.WriteFile(file, nil, 0644)
+	if _, err := Discover(file, nil); err == nil {
+		t.Error("expected error for non-directory root")
+	}
+}
+
+func TestDiscoverMultiple(t *testing.T) {
+	root := t.TempDir()
+	makeTree(t, root, "a/one/SKILL.md", "b/two/SKILL.md")
+
+	dirs, errs := DiscoverMultiple([]string{
+		filepath.Join(root, "a"),
+		root, // overlaps with the first root
+		filepath.Join(root, "missing"),
+	}, nil)
+
+	if len(errs) != 1 {
+		t.Errorf("expected 1 error, got %v", errs)
+	}
+	want := []string{"a/one", "b/two"}
+	if got := relPaths(t, root, dirs); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestIgnoreMatcher(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		isDir   bool
+		want    bool
+	}{
+		{"build", "build", true, true},
+		{"build", "a/b/build", true, true},
+		{"build/", "build", false, false},
+		{"/build", "a/build", true, false},
+		{"a/*/c", "a/b/c", true, true},
+		{"a/*/c", "a/b/x/c", true, false},
+		{"a/**/c", "a/b/x/c", true, true},
+		{"a/**/c", "a/c", true, true},
+		{"**/tmp", "x/y/tmp", true, true},
+		{"tmp?", "tmp1", true, true},
+		{"tmp[0-9]", "tmpa", true, false},
+		{"*.bak", "dir/file.bak", false, true},
+		{"x[]]", "x]", false, true},
+		{"x[!]]", "xa", false, true},
+		{"x[!]]", "x]", false, false},
+		{`x[\d]`, `x\`, false, true},
+		{`x[\d]`, "x1", false, false},
+		{"x[", "x[", false, true},
+		{"x[]", "x[]", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"|"+tt.path, func(t *testing.T) {
+			m := &ignoreMatcher{}
+			if err := m.add("", tt.pattern); err != nil {
+				t.Fatal(err)
+			}
+			if got := m.ignored(tt.path, tt.isDir); got != tt.want {
+				t.Errorf("ignored(%q) with %q = %v, want %v", tt.path, tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePattern(t *testing.T) {
+	for _, pattern := range []string{"build/", "a/**/c", "x[]", "[!a-z]"} {
+		if err := ValidatePattern(pattern); err != nil {
+			t.Errorf("ValidatePattern(%q) = %v", pattern, err)
+		}
+	}
+	for _, pattern := range []string{"[z-a]", "x[b-a]/"} {
+		if err := ValidatePattern(pattern); err == nil {
+			t.Errorf("ValidatePattern(%q) = nil, want an error", pattern)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package discovery
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/biwakonbu/aglx/internal/glob"
+)
+
+// ignorePattern is a single compiled .gitignore-style pattern.
+type ignorePattern struct {
+	// base is the slash-separated directory (relative to the walk root)
+	// the pattern was declared in. Empty for root-level patterns.
+	base    string
+	negate  bool
+	dirOnly bool
+	re      *regexp.Regexp
+}
+
+// ignoreMatcher evaluates patterns in declaration order; the last match wins.
+type ignoreMatcher struct {
+	patterns []ignorePattern
+}
+
+// ValidatePattern reports whether pattern is a valid .gitignore-style
+// pattern, as accepted by Options.Exclude.
+func ValidatePattern(pattern string) error {
+	return (&ignoreMatcher{}).add("", pattern)
+}
+
+// add compiles a pattern line declared in the base directory.
+// Blank lines and comments are ignored.
+func (m *ignoreMatcher) add(base, line string) error {
+	return m.addPattern(base, line, glob.Expr)
+}
+
+// addPattern compiles a pattern line, translating the glob (without the
+// leading "!" or "/" and the trailing "/") with toRegexp.
+func (m *ignoreMatcher) addPattern(base, line string, toRegexp func(string) string) error {
+	original := line
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil
+	}
+
+	p := ignorePattern{base: base}
+	if strings.HasPrefix(line, "!") {
+		p.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\`) {
+		line = line[1:] // escaped leading '!' or '#'
+	}
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return nil
+	}
+
+	// A slash anywhere but the end anchors the pattern to its base directory;
+	// otherwise it matches a name at any depth.
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+	expr := toRegexp(line)
+	if !anchored {
+		expr = "(?:.*/)?" + expr
+	}
+	re, err := glob.CompileExpr(original, "^"+expr+"$")
+	if err != nil {
+		return err
+	}
+	p.re = re
+
+	m.patterns = append(m.patterns, p)
+	return nil
+}
+
+// addFile loads patterns from a .gitignore-style file. Missing files are ignored.
+func (m *ignoreMatcher) addFile(base, filePath string) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Like git, match a pattern that is not a valid glob literally.
+		if err := m.add(base, scanner.Text()); err != nil {
+			_ = m.addPattern(base, scanner.Text(), regexp.QuoteMeta)
+		}
+	}
+	return scanner.Err()
+}
+
+// clone returns a copy that can be extended without affecting m.
+func (m *ignoreMatcher) clone() *ignoreMatcher {
+	return &ignoreMatcher{patterns: append([]ignorePattern(nil), m.patterns...)}
+}
+
+// ignored reports whether the slash-separated relative path is excluded.
+func (m *ignoreMatcher) ignored(rel string, isDir bool) bool {
+	ignored := false
+	for _, p := range m.patterns {
+		if p.dirOnly && !isDir {
+			continue
+		}
+		target := rel
+		if p.base != "" {
+			if !strings.HasPrefix(rel, p.base+"/") {
+				continue
+			}
+			target = strings.TrimPrefix(rel, p.base+"/")
+		}
+		if p.re.MatchString(target) {
+			ignored = !p.negate
+		}
+	}
+	return ignored
+}
+
+// joinRel joins slash-separated relative path segments.
+func joinRel(base, name string) string {
+	if base == "" {
+		return name
+	}
+	return path.Join(base, name)
+}
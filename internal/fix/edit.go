@@ -0,0 +1,226 @@
+package fix
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/biwakonbu/aglx/internal/frontmatter"
+)
+
+// document is a SKILL.md file split into lines, with its frontmatter decoded
+// as a node tree so that values can be replaced in place. Rewriting only the
+// bytes of the changed scalars keeps key order, comments, quoting and
+// indentation of everything else intact.
+type document struct {
+	lines []string
+
+	// root is the frontmatter mapping (nil if the frontmatter is empty).
+	root *yaml.Node
+
+	edits   []edit
+	inserts []string
+
+	// values holds the new value of each edited key.
+	values map[string]string
+}
+
+// edit replaces bytes [start, end) of a line.
+type edit struct {
+	line, start, end int
+	text             string
+}
+
+// parseDocument splits content into lines and decodes the frontmatter between
+// the opening and closing delimiters, recognized as by skill.Parse. Line i of
+// the frontmatter is line i of the file. A byte order mark and CRLF line
+// endings are kept as they are.
+func parseDocument(content []byte) (*document, error) {
+	d := &document{lines: strings.Split(string(content), "\n")}
+	if !frontmatter.IsOpening(d.lines[0]) {
+		return nil, fmt.Errorf("missing opening frontmatter delimiter (---)")
+	}
+	end := -1
+	for i := 1; i < len(d.lines); i++ {
+		if frontmatter.IsClosing(d.lines[i]) {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return nil, fmt.Errorf("missing closing frontmatter delimiter (---)")
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.Join(d.lines[1:end], "\n")), &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML frontmatter: %w", err)
+	}
+	if len(doc.Content) > 0 {
+		if doc.Content[0].Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("frontmatter is not a mapping")
+		}
+		d.root = doc.Content[0]
+	}
+	return d, nil
+}
+
+// lookup returns the key and value nodes of a top-level frontmatter key.
+func (d *document) lookup(key string) (*yaml.Node, *yaml.Node) {
+	if d.root == nil {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(d.root.Content); i += 2 {
+		if d.root.Content[i].Value == key {
+			return d.root.Content[i], d.root.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// set changes the value of a top-level key, adding the key at the top of the
+// frontmatter if it is missing.
+func (d *document) set(key, value string) error {
+	text, err := render(value, yaml.Style(0))
+	if err != nil {
+		return err
+	}
+
+	if d.values == nil {
+		d.values = make(map[string]string)
+	}
+	d.values[key] = value
+
+	k, v := d.lookup(key)
+	if k == nil {
+		if d.root != nil && d.root.Style&yaml.FlowStyle != 0 {
+			return fmt.Errorf("cannot add %s to a flow-style frontmatter", key)
+		}
+		d.inserts = append(d.inserts, key+": "+text)
+		return nil
+	}
+
+	if v.Kind != yaml.ScalarNode || v.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		return fmt.Errorf("cannot rewrite %s: value is not a single-line scalar", key)
+	}
+
+	// An empty value ("key:") has no span of its own; insert after the colon.
+	if v.Tag == "!!null" && v.Value == "" {
+		line := d.lines[k.Line]
+		start := runeOffset(line, k.Column)
+		colon := strings.IndexByte(line[start:], ':')
+		if colon < 0 {
+			return fmt.Errorf("cannot rewrite %s: unexpected layout", key)
+		}
+		at := start + colon + 1
+		d.edits = append(d.edits, edit{line: k.Line, start: at, end: at, text: " " + text})
+		return nil
+	}
+
+	line := d.lines[v.Line]
+	start := runeOffset(line, v.Column)
+	end, ok := scalarEnd(line, start, v.Style)
+	if !ok {
+		return fmt.Errorf("cannot rewrite %s: value is not a single-line scalar", key)
+	}
+	if text, err = render(value, v.Style); err != nil {
+		return err
+	}
+	d.edits = append(d.edits, edit{line: v.Line, start: start, end: end, text: text})
+	return nil
+}
+
+// bytes returns the file content with all edits applied.
+func (d *document) bytes() []byte {
+	lines := append([]string(nil), d.lines...)
+
+	// Apply edits right to left so that earlier offsets stay valid.
+	edits := append([]edit(nil), d.edits...)
+	sort.Slice(edits, func(i, j int) bool {
+		if edits[i].line != edits[j].line {
+			return edits[i].line > edits[j].line
+		}
+		return edits[i].start > edits[j].start
+	})
+	for _, e := range edits {
+		line := lines[e.line]
+		lines[e.line] = line[:e.start] + e.text + line[e.end:]
+	}
+
+	if len(d.inserts) > 0 {
+		lines = append(lines[:1], append(append([]string(nil), d.inserts...), lines[1:]...)...)
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// runeOffset converts a 1-based column, counted in characters as reported by
+// yaml.v3, into a byte offset within line.
+func runeOffset(line string, column int) int {
+	n := 1
+	for i := range line {
+		if n == column {
+			return i
+		}
+		n++
+	}
+	return len(line)
+}
+
+// scalarEnd returns the byte offset just past a scalar starting at start.
+func scalarEnd(line string, start int, style yaml.Style) (int, bool) {
+	switch {
+	case style&yaml.DoubleQuotedStyle != 0:
+		for i := start + 1; i < len(line); i++ {
+			switch line[i] {
+			case '\\':
+				i++
+			case '"':
+				return i + 1, true
+			}
+		}
+		return 0, false
+	case style&yaml.SingleQuotedStyle != 0:
+		for i := start + 1; i < len(line); i++ {
+			if line[i] != '\'' {
+				continue
+			}
+			if i+1 < len(line) && line[i+1] == '\'' {
+				i++
+				continue
+			}
+			return i + 1, true
+		}
+		return 0, false
+	default:
+		// A plain scalar runs until a comment or the end of the line.
+		end := len(line)
+		if i := strings.Index(line[start:], " #"); i >= 0 {
+			end = start + i
+		}
+		return start + len(strings.TrimRight(line[start:end], " \t\r")), true
+	}
+}
+
+// render formats value as a scalar in the given style, falling back to a
+// double-quoted scalar when a plain one would not read back as the same string.
+func render(value string, style yaml.Style) (string, error) {
+	if strings.ContainsAny(value, "\n\r") {
+		return "", fmt.Errorf("cannot write multi-line value %q", value)
+	}
+	switch {
+	case style&yaml.DoubleQuotedStyle != 0:
+		return strconv.Quote(value), nil
+	case style&yaml.SingleQuotedStyle != 0:
+		return "'" + strings.ReplaceAll(value, "'", "''") + "'", nil
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(value), &node); err == nil && len(node.Content) == 1 {
+		if n := node.Content[0]; n.Kind == yaml.ScalarNode && n.Tag == "!!str" && n.Value == value {
+			return value, nil
+		}
+	}
+	return strconv.Quote(value), nil
+}
@@ -0,0 +1,336 @@
+// Package fix rewrites skill directories to resolve the violations that have a
+// single unambiguous correction (the target of `aglx fix`).
+package fix
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/biwakonbu/aglx/internal/skill"
+	"github.com/biwakonbu/aglx/internal/toolspec"
+)
+
+const skillFileName = "SKILL.md"
+
+// Options configures a fix run.
+type Options struct {
+	// Spec selects the allowed-tools separator to convert to.
+	// SpecAuto leaves allowed-tools unchanged.
+	Spec skill.Spec
+
+	// RenameDir renames the directory to match the name instead of
+	// rewriting the name to match the directory.
+	RenameDir bool
+
+	// DryRun computes the changes without touching the file system.
+	DryRun bool
+}
+
+// Change describes one correction.
+type Change struct {
+	// Rule is the ID of the rule the change resolves.
+	Rule string
+
+	// Field is the frontmatter field or path the change applies to.
+	Field string
+
+	// Message describes the change.
+	Message string
+}
+
+// Result is the outcome of fixing one skill directory.
+type Result struct {
+	// Path is the skill directory as given.
+	Path string
+
+	// NewPath is the directory after a rename (equal to Path otherwise).
+	NewPath string
+
+	// Original and Fixed are the SKILL.md contents before and after fixing.
+	Original []byte
+	Fixed    []byte
+
+	// Changes lists the corrections, in the order they are applied.
+	Changes []Change
+
+	// Skipped lists violations that were found but need a manual fix.
+	Skipped []Change
+
+	// removeDirs are the empty optional directories to delete.
+	removeDirs []string
+}
+
+// Changed reports whether the fix modifies anything.
+func (r *Result) Changed() bool {
+	return len(r.Changes) > 0
+}
+
+// Diff returns a unified diff of the SKILL.md rewrite ("" if unchanged).
+func (r *Result) Diff() string {
+	from := filepath.ToSlash(filepath.Join(r.Path, skillFileName))
+	to := filepath.ToSlash(filepath.Join(r.NewPath, skillFileName))
+	return UnifiedDiff(from, to, r.Original, r.Fixed)
+}
+
+// Fix computes the corrections for the skill in dirPath and, unless
+// opts.DryRun is set, applies them.
+func Fix(dirPath string, opts *Options) (*Result, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	parsed, err := skill.Parse(dirPath)
+	if err != nil {
+		return nil, err
+	}
+	skillPath := filepath.Join(dirPath, skillFileName)
+	content, err := os.ReadFile(skillPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SKILL.md: %w", err)
+	}
+	doc, err := parseDocument(content)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Path: dirPath, NewPath: dirPath, Original: content}
+	if err := fixName(doc, parsed, opts, result); err != nil {
+		return nil, err
+	}
+	if err := fixAllowedTools(doc, parsed, opts.Spec, result); err != nil {
+		return nil, err
+	}
+	result.Fixed = doc.bytes()
+	if err := verify(result.Fixed, doc); err != nil {
+		return nil, err
+	}
+	fixEmptyDirs(dirPath, result)
+
+	if opts.DryRun {
+		return result, nil
+	}
+	return result, apply(result, skillPath)
+}
+
+// fixName normalizes the name and makes it match the directory, renaming
+// one or the other.
+func fixName(doc *document, s *skill.Skill, opts *Options, result *Result) error {
+	abs, err := filepath.Abs(s.Path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", s.Path, err)
+	}
+	dirName := filepath.Base(abs)
+
+	name := skill.NormalizeName(s.Name)
+	switch {
+	case opts.RenameDir:
+		if name == "" {
+			name = skill.NormalizeName(dirName)
+		}
+	case skill.IsValidName(dirName):
+		name = dirName
+	}
+	if !skill.IsValidName(name) {
+		result.Skipped = append(result.Skipped, Change{
+			Rule:    skill.RuleNameFormat,
+			Field:   "name",
+			Message: fmt.Sprintf("cannot derive a valid name from %q or directory %q", s.Name, dirName),
+		})
+		return nil
+	}
+
+	if name != s.Name {
+		if err := doc.set("name", name); err != nil {
+			return err
+		}
+		result.Changes = append(result.Changes, Change{
+			Rule:    nameRule(s.Name),
+			Field:   "name",
+			Message: fmt.Sprintf("%q -> %q", s.Name, name),
+		})
+	}
+
+	if name == dirName {
+		return nil
+	}
+	if !opts.RenameDir {
+		result.Skipped = append(result.Skipped, Change{
+			Rule:    skill.RuleNameDirMatch,
+			Field:   "name",
+			Message: fmt.Sprintf("directory %q is not a valid name (use --rename-dir to rename it to %q)", dirName, name),
+		})
+		return nil
+	}
+
+	target := filepath.Join(filepath.Dir(filepath.Clean(s.Path)), name)
+	if _, err := os.Lstat(target); err == nil {
+		return fmt.Errorf("cannot rename %s: %s already exists", s.Path, target)
+	}
+	result.NewPath = target
+	result.Changes = append(result.Changes, Change{
+		Rule:    skill.RuleNameDirMatch,
+		Field:   "directory",
+		Message: fmt.Sprintf("rename %q -> %q", dirName, name),
+	})
+	return nil
+}
+
+// nameRule picks the rule a name rewrite resolves.
+func nameRule(from string) string {
+	switch {
+	case from == "":
+		return skill.RuleNameRequired
+	case skill.NormalizeName(from) != from:
+		return skill.RuleNameFormat
+	default:
+		return skill.RuleNameDirMatch
+	}
+}
+
+// fixAllowedTools converts allowed-tools to the separator of spec.
+func fixAllowedTools(doc *document, s *skill.Skill, spec skill.Spec, result *Result) error {
+	value, format := convertAllowedTools(s, spec)
+	if format == "" {
+		return nil
+	}
+	if value == s.AllowedTools {
+		result.Skipped = append(result.Skipped, Change{
+			Rule:    skill.RuleAllowedToolsSeparator,
+			Field:   "allowed-tools",
+			Message: fmt.Sprintf("cannot split %q into tools", s.AllowedTools),
+		})
+		return nil
+	}
+
+	if err := doc.set("allowed-tools", value); err != nil {
+		return err
+	}
+	result.Changes = append(result.Changes, Change{
+		Rule:    skill.RuleAllowedToolsSeparator,
+		Field:   "allowed-tools",
+		Message: fmt.Sprintf("converted to %s format", format),
+	})
+	return nil
+}
+
+// convertAllowedTools returns allowed-tools joined with the separator of
+// spec and the name of that format, or "" for the format if the
+// allowed-tools-separator rule accepts the current value.
+func convertAllowedTools(s *skill.Skill, spec skill.Spec) (value, format string) {
+	list := s.Tools()
+	tools := list.Names()
+	if len(tools) == 0 {
+		return "", ""
+	}
+
+	// Only rewrite values the allowed-tools-separator rule rejects.
+	switch {
+	case spec == skill.SpecAgentSkills && list.Separator == toolspec.SeparatorComma:
+		value, format = strings.Join(tools, " "), "space-separated"
+	case spec == skill.SpecClaudeCode && list.Separator == toolspec.SeparatorSpace:
+		value, format = strings.Join(tools, ", "), "comma-separated"
+	default:
+		return "", ""
+	}
+	if len(list.Errors) > 0 {
+		// The entries of a malformed value are guesses; leave it alone.
+		return s.AllowedTools, format
+	}
+	return value, format
+}
+
+// AllowedTools returns allowed-tools rewritten with the separator spec
+// requires. It reports false if the value is already accepted or cannot be
+// split into tools.
+func AllowedTools(s *skill.Skill, spec skill.Spec) (string, bool) {
+	value, format := convertAllowedTools(s, spec)
+	return value, format != "" && value != s.AllowedTools
+}
+
+// SetField returns SKILL.md content with a top-level frontmatter key set to
+// value. Only the bytes of that value change (the key is added at the top of
+// the frontmatter if missing), so the rest of the file is kept as written.
+func SetField(content []byte, key, value string) ([]byte, error) {
+	doc, err := parseDocument(content)
+	if err != nil {
+		return nil, err
+	}
+	if err := doc.set(key, value); err != nil {
+		return nil, err
+	}
+	fixed := doc.bytes()
+	if err := verify(fixed, doc); err != nil {
+		return nil, err
+	}
+	return fixed, nil
+}
+
+// fixEmptyDirs schedules the removal of empty optional directories.
+func fixEmptyDirs(dirPath string, result *Result) {
+	for _, dir := range skill.OptionalDirs {
+		path := filepath.Join(dirPath, dir)
+		info, err := os.Stat(path)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		_, err = f.Readdirnames(1)
+		f.Close()
+		if err != io.EOF {
+			continue
+		}
+
+		result.removeDirs = append(result.removeDirs, path)
+		result.Changes = append(result.Changes, Change{
+			Rule:    skill.RuleOptionalDirs,
+			Field:   dir,
+			Message: "removed empty directory",
+		})
+	}
+}
+
+// verify re-reads the rewritten frontmatter to make sure every edited key
+// now holds the intended value.
+func verify(fixed []byte, before *document) error {
+	after, err := parseDocument(fixed)
+	if err != nil {
+		return fmt.Errorf("rewritten frontmatter is invalid: %w", err)
+	}
+	for key, want := range before.values {
+		if _, v := after.lookup(key); v == nil || v.Value != want {
+			return fmt.Errorf("rewritten frontmatter does not hold the new %s", key)
+		}
+	}
+	return nil
+}
+
+// apply writes the fixed SKILL.md, removes empty directories and renames the
+// skill directory, in that order.
+func apply(result *Result, skillPath string) error {
+	if string(result.Fixed) != string(result.Original) {
+		info, err := os.Stat(skillPath)
+		if err != nil {
+			return fmt.Errorf("failed to stat SKILL.md: %w", err)
+		}
+		if err := os.WriteFile(skillPath, result.Fixed, info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to write SKILL.md: %w", err)
+		}
+	}
+	for _, dir := range result.removeDirs {
+		if err := os.Remove(dir); err != nil {
+			return fmt.Errorf("failed to remove empty directory: %w", err)
+		}
+	}
+	if result.NewPath != result.Path {
+		if err := os.Rename(result.Path, result.NewPath); err != nil {
+			return fmt.Errorf("failed to rename directory: %w", err)
+		}
+	}
+	return nil
+}
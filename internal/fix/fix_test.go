@@ -0,0 +1,292 @@
+package fix
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/biwakonbu/aglx/internal/skill"
+)
+
+// writeSkill creates a skill directory named dirName containing SKILL.md.
+func writeSkill(t *testing.T, dirName, content string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), dirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, skillFileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestFix_Frontmatter(t *testing.T) {
+	tests := []struct {
+		name    string
+		dir     string
+		opts    Options
+		content string
+		want    string
+		rules   []string
+	}{
+		{
+			name:    "lowercases and collapses hyphens, keeping comments and order",
+			dir:     "my-skill",
+			content: "---\n# The skill\ndescription: Does things.   # short\nname: My--Skill  # display name\n---\n\nBody\n",
+			want:    "---\n# The skill\ndescription: Does things.   # short\nname: my-skill  # display name\n---\n\nBody\n",
+			rules:   []string{skill.RuleNameFormat},
+		},
+		{
+			name:    "keeps the quoting style",
+			dir:     "quoted",
+			content: "---\nname: '-Quoted-'\ndescription: \"A \\\"quoted\\\" skill.\"\n---\n",
+			want:    "---\nname: 'quoted'\ndescription: \"A \\\"quoted\\\" skill.\"\n---\n",
+			rules:   []string{skill.RuleNameFormat},
+		},
+		{
+			name:    "keeps a byte order mark, CRLF line endings and a \"...\" terminator",
+			dir:     "irregular",
+			content: "\uFEFF---\r\nname: Irregular\r\ndescription: Irregular file.\r\n...\r\n",
+			want:    "\uFEFF---\r\nname: irregular\r\ndescription: Irregular file.\r\n...\r\n",
+			rules:   []string{skill.RuleNameFormat},
+		},
+		{
+			name:    "name follows the directory",
+			dir:     "pdf-tools",
+			content: "---\nname: pdf-processing\ndescription: PDFs.\n---\n",
+			want:    "---\nname: pdf-tools\ndescription: PDFs.\n---\n",
+			rules:   []string{skill.RuleNameDirMatch},
+		},
+		{
+			name:    "adds a missing name",
+			dir:     "unnamed",
+			content: "---\ndescription: No name.\n---\n",
+			want:    "---\nname: unnamed\ndescription: No name.\n---\n",
+			rules:   []string{skill.RuleNameRequired},
+		},
+		{
+			name:    "fills an empty name",
+			dir:     "empty-name",
+			content: "---\nname:\ndescription: Empty.\n---\n",
+			want:    "---\nname: empty-name\ndescription: Empty.\n---\n",
+			rules:   []string{skill.RuleNameRequired},
+		},
+		{
+			name:    "converts allowed-tools to commas for claude-code",
+			dir:     "tools",
+			opts:    Options{Spec: skill.SpecClaudeCode},
+			content: "---\nname: tools\ndescription: Tools.\nallowed-tools: Bash(git status) Read Grep\n---\n",
+			want:    "---\nname: tools\ndescription: Tools.\nallowed-tools: Bash(git status), Read, Grep\n---\n",
+			rules:   []string{skill.RuleAllowedToolsSeparator},
+		},
+		{
+			name:    "converts allowed-tools to spaces for agent-skills",
+			dir:     "tools",
+			opts:    Options{Spec: skill.SpecAgentSkills},
+			content: "---\nname: tools\ndescription: Tools.\nallowed-tools: \"Read, Grep, Glob\"\n---\n",
+			want:    "---\nname: tools\ndescription: Tools.\nallowed-tools: \"Read Grep Glob\"\n---\n",
+			rules:   []string{skill.RuleAllowedToolsSeparator},
+		},
+		{
+			name:    "leaves allowed-tools alone in auto mode",
+			dir:     "tools",
+			content: "---\nname: tools\ndescription: Tools.\nallowed-tools: Read Grep\n---\n",
+			want:    "---\nname: tools\ndescription: Tools.\nallowed-tools: Read Grep\n---\n",
+		},
+		{
+			name:    "leaves a valid skill unchanged",
+			dir:     "valid",
+			content: "---\nname: valid\ndescription: >\n  Folded\n  description.\n---\n",
+			want:    "---\nname: valid\ndescription: >\n  Folded\n  description.\n---\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeSkill(t, tt.dir, tt.content)
+			result, err := Fix(dir, &tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got, err := os.ReadFile(filepath.Join(dir, skillFileName))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("SKILL.md =\n%s\nwant:\n%s", got, tt.want)
+			}
+
+			var rules []string
+			for _, c := range result.Changes {
+				rules = append(rules, c.Rule)
+			}
+			if strings.Join(rules, ",") != strings.Join(tt.rules, ",") {
+				t.Errorf("change rules = %v, want %v", rules, tt.rules)
+			}
+		})
+	}
+}
+
+func TestFix_DryRun(t *testing.T) {
+	content := "---\nname: Dry-Run\ndescription: Dry run.\n---\n"
+	dir := writeSkill(t, "dry-run", content)
+	if err := os.Mkdir(filepath.Join(dir, "scripts"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Fix(dir, &Options{DryRun: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Changes) != 2 {
+		t.Errorf("expected name and scripts changes, got %+v", result.Changes)
+	}
+
+	got, _ := os.ReadFile(filepath.Join(dir, skillFileName))
+	if string(got) != content {
+		t.Error("dry run must not rewrite SKILL.md")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "scripts")); err != nil {
+		t.Error("dry run must not remove directories")
+	}
+	if diff := result.Diff(); !strings.Contains(diff, "-name: Dry-Run\n+name: dry-run\n") {
+		t.Errorf("unexpected diff:\n%s", diff)
+	}
+}
+
+func TestFix_EmptyDirectories(t *testing.T) {
+	dir := writeSkill(t, "dirs", "---\nname: dirs\ndescription: Dirs.\n---\n")
+	for _, sub := range []string{"scripts", "assets", "references"} {
+		if err := os.Mkdir(filepath.Join(dir, sub), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "references", "guide.md"), []byte("# Guide\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Fix(dir, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for sub, wantExists := range map[string]bool{"scripts": false, "assets": false, "references": true} {
+		_, err := os.Stat(filepath.Join(dir, sub))
+		if exists := err == nil; exists != wantExists {
+			t.Errorf("%s exists = %v, want %v", sub, exists, wantExists)
+		}
+	}
+}
+
+func TestFix_RenameDir(t *testing.T) {
+	dir := writeSkill(t, "Old_Name", "---\nname: New-Name\ndescription: Rename.\n---\n")
+
+	// Without --rename-dir an invalid directory name is left for a manual fix.
+	result, err := Fix(dir, &Options{DryRun: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Rule != skill.RuleNameDirMatch {
+		t.Errorf("expected a skipped name-dir-match, got %+v", result.Skipped)
+	}
+
+	result, err = Fix(dir, &Options{RenameDir: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := filepath.Join(filepath.Dir(dir), "new-name")
+	if result.NewPath != want {
+		t.Errorf("NewPath = %q, want %q", result.NewPath, want)
+	}
+	got, err := os.ReadFile(filepath.Join(want, skillFileName))
+	if err != nil {
+		t.Fatalf("renamed directory missing: %v", err)
+	}
+	if !strings.Contains(string(got), "name: new-name\n") {
+		t.Errorf("name not rewritten:\n%s", got)
+	}
+}
+
+func TestFix_RenameDirTargetExists(t *testing.T) {
+	dir := writeSkill(t, "source", "---\nname: target\ndescription: Rename.\n---\n")
+	if err := os.Mkdir(filepath.Join(filepath.Dir(dir), "target"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Fix(dir, &Options{RenameDir: true}); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected an already exists error, got %v", err)
+	}
+}
+
+func TestFix_ParseError(t *testing.T) {
+	dir := writeSkill(t, "broken", "# No frontmatter\n")
+	if _, err := Fix(dir, nil); err == nil {
+		t.Error("expected an error for a SKILL.md without frontmatter")
+	}
+}
+
+func TestSetField(t *testing.T) {
+	content := []byte("---\nname: My-Skill # shown\ndescription: 'Quoted.'\n---\n\nBody\n")
+	got, err := SetField(content, "name", "my-skill")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "---\nname: my-skill # shown\ndescription: 'Quoted.'\n---\n\nBody\n"; string(got) != want {
+		t.Errorf("SetField() = %q, want %q", got, want)
+	}
+
+	if _, err := SetField([]byte("no frontmatter\n"), "name", "x"); err == nil {
+		t.Error("expected an error without frontmatter")
+	}
+}
+
+func TestAllowedTools(t *testing.T) {
+	tests := []struct {
+		tools string
+		spec  skill.Spec
+		want  string
+		ok    bool
+	}{
+		{"Read, Grep", skill.SpecAgentSkills, "Read Grep", true},
+		{"Read Bash(git:*)", skill.SpecClaudeCode, "Read, Bash(git:*)", true},
+		{"Read Grep", skill.SpecAgentSkills, "", false},
+		{"Read", skill.SpecClaudeCode, "", false},
+		{"Read, Grep", skill.SpecAuto, "", false},
+		{"Read, Bash(git", skill.SpecAgentSkills, "Read, Bash(git", false},
+	}
+	for _, tt := range tests {
+		got, ok := AllowedTools(&skill.Skill{AllowedTools: tt.tools}, tt.spec)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("AllowedTools(%q, %q) = %q, %v; want %q, %v", tt.tools, tt.spec, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	a := []byte("1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n")
+	b := []byte("1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n")
+
+	want := `--- a
++++ b
+@@ -1,6 +1,6 @@
+ 1
+ 2
+-3
++three
+ 4
+ 5
+ 6
+@@ -10,3 +10,4 @@
+ 10
+ 11
+ 12
++13
+`
+	if got := UnifiedDiff("a", "b", a, b); got != want {
+		t.Errorf("UnifiedDiff =\n%s\nwant:\n%s", got, want)
+	}
+	if got := UnifiedDiff("a", "b", a, a); got != "" {
+		t.Errorf("expected no diff for equal input, got:\n%s", got)
+	}
+}
@@ -0,0 +1,195 @@
+// Package frontmatter splits Markdown files such as SKILL.md and CLAUDE.md
+// into their YAML frontmatter and body. It is shared by the skill and claude
+// packages so that both read files the same way.
+//
+// Reading is lenient: a UTF-8 byte order mark is skipped, CRLF line endings
+// are converted to LF, lines of any length are accepted and the frontmatter
+// may be closed by the YAML document end marker "..." instead of "---".
+// Each of these irregularities is recorded as an Issue, so that validators
+// can warn about files other tools may not read.
+package frontmatter
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	// Delimiter opens and closes the frontmatter.
+	Delimiter = "---"
+
+	// DocumentEnd is the YAML document end marker, accepted as a closing delimiter.
+	DocumentEnd = "..."
+
+	// MaxLineLength is the longest line, in bytes, that line-oriented readers
+	// commonly accept (the bufio.Scanner default). Longer lines are reported.
+	MaxLineLength = 64 * 1024
+)
+
+const byteOrderMark = "\uFEFF"
+
+// Issue is an irregularity in a file that was read anyway.
+type Issue struct {
+	// Line is the 1-based line the issue was found on.
+	Line int
+
+	Message string
+}
+
+// Document is a Markdown file split into frontmatter and body.
+type Document struct {
+	// HasFrontmatter reports whether the file starts with a closed
+	// frontmatter block.
+	HasFrontmatter bool
+
+	// Unclosed is set if the file opens a frontmatter block that is never
+	// closed. The whole file is then the body.
+	Unclosed bool
+
+	// Frontmatter is the YAML between the delimiters, with LF line endings.
+	// Its first line is line 2 of the file.
+	Frontmatter string
+
+	// Body is the Markdown after the frontmatter (without the blank lines
+	// that follow the closing delimiter), or the whole file if there is no
+	// frontmatter.
+	Body string
+
+	// BodyLine is the 1-based line on which the body starts (0 for an empty file).
+	BodyLine int
+
+	// Issues lists the irregularities found while reading, in line order.
+	Issues []Issue
+}
+
+// IsOpening reports whether line opens a frontmatter block. A leading byte
+// order mark and surrounding whitespace are ignored.
+func IsOpening(line string) bool {
+	return strings.TrimSpace(strings.TrimPrefix(line, byteOrderMark)) == Delimiter
+}
+
+// IsClosing reports whether line closes a frontmatter block ("---" or "...").
+func IsClosing(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == Delimiter || trimmed == DocumentEnd
+}
+
+// FenceMarker returns the fence ("```" or "~~~") that opens a fenced code
+// block on line, or "" if the line does not open one. Leading and trailing
+// whitespace is ignored.
+func FenceMarker(line string) string {
+	trimmed := strings.TrimSpace(line)
+	for _, marker := range []string{"```", "~~~"} {
+		if strings.HasPrefix(trimmed, marker) {
+			return marker
+		}
+	}
+	return ""
+}
+
+// Read reads a Markdown file from r and splits it. Only read errors are
+// returned; whether a frontmatter is required is up to the caller.
+func Read(r io.Reader) (*Document, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+	return Split(content), nil
+}
+
+// Split splits the content of a Markdown file.
+func Split(content []byte) *Document {
+	doc := &Document{}
+	if len(content) == 0 {
+		return doc
+	}
+
+	text := string(content)
+	if strings.HasPrefix(text, byteOrderMark) {
+		text = strings.TrimPrefix(text, byteOrderMark)
+		doc.issue(1, "file starts with a UTF-8 byte order mark (BOM); save it as UTF-8 without BOM")
+	}
+	lines := doc.splitLines(text)
+
+	end := -1
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == Delimiter {
+		for i := 1; i < len(lines); i++ {
+			if IsClosing(lines[i]) {
+				end = i
+				break
+			}
+		}
+		if end < 0 {
+			doc.Unclosed = true
+			doc.issue(1, "frontmatter opened here is never closed with \"---\"; the whole file is read as Markdown")
+		}
+	}
+
+	if end < 0 {
+		doc.Body = strings.Join(lines, "\n")
+		doc.BodyLine = 1
+		doc.sortIssues()
+		return doc
+	}
+
+	doc.checkDelimiter(lines[0], 1)
+	doc.checkDelimiter(lines[end], end+1)
+	doc.HasFrontmatter = true
+	doc.Frontmatter = strings.Join(lines[1:end], "\n")
+
+	// Skip the blank lines after the closing delimiter.
+	body := strings.Join(lines[end+1:], "\n")
+	doc.Body = strings.TrimLeft(body, "\n")
+	doc.BodyLine = end + 2 + len(body) - len(doc.Body)
+	doc.sortIssues()
+	return doc
+}
+
+func (d *Document) issue(line int, format string, args ...interface{}) {
+	d.Issues = append(d.Issues, Issue{Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+// splitLines splits text into lines without their line endings, recording
+// CRLF line endings and overlong lines. A final line ending does not start
+// another line.
+func (d *Document) splitLines(text string) []string {
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	firstCRLF, crlf := 0, 0
+	for i, line := range lines {
+		if strings.HasSuffix(line, "\r") {
+			lines[i] = strings.TrimSuffix(line, "\r")
+			if crlf++; firstCRLF == 0 {
+				firstCRLF = i + 1
+			}
+		}
+		if n := len(lines[i]); n > MaxLineLength {
+			d.issue(i+1, "line is %d bytes long; tools that read files line by line may fail on lines over %dKB", n, MaxLineLength/1024)
+		}
+	}
+	switch {
+	case crlf == 0:
+	case crlf == len(lines) || (crlf == len(lines)-1 && !strings.HasSuffix(text, "\n")):
+		d.issue(firstCRLF, "file uses Windows (CRLF) line endings; use LF")
+	default:
+		d.issue(firstCRLF, "file mixes CRLF and LF line endings; use LF throughout")
+	}
+	return lines
+}
+
+// checkDelimiter records delimiters that other tools may not recognize.
+func (d *Document) checkDelimiter(line string, lineNumber int) {
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case trimmed == DocumentEnd:
+		d.issue(lineNumber, "frontmatter is closed with %q instead of %q; most tools only recognize %q", DocumentEnd, Delimiter, Delimiter)
+	case trimmed != line:
+		d.issue(lineNumber, "frontmatter delimiter %q has surrounding whitespace; most tools only recognize %q on its own", line, Delimiter)
+	}
+}
+
+// sortIssues orders issues by line, keeping the order of issues on the same line.
+func (d *Document) sortIssues() {
+	sort.SliceStable(d.Issues, func(i, j int) bool { return d.Issues[i].Line < d.Issues[j].Line })
+}
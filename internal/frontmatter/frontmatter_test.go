@@ -0,0 +1,156 @@
+package frontmatter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    Document
+	}{
+		{
+			name:    "empty file",
+			content: "",
+			want:    Document{},
+		},
+		{
+			name:    "frontmatter and body",
+			content: "---\nname: demo\n---\n\n# Title\n",
+			want:    Document{HasFrontmatter: true, Frontmatter: "name: demo", Body: "# Title", BodyLine: 5},
+		},
+		{
+			name:    "no frontmatter",
+			content: "# Title\n\nText\n",
+			want:    Document{Body: "# Title\n\nText", BodyLine: 1},
+		},
+		{
+			name:    "empty frontmatter without body",
+			content: "---\n---\n",
+			want:    Document{HasFrontmatter: true, BodyLine: 3},
+		},
+		{
+			name:    "unclosed frontmatter",
+			content: "---\nname: demo\n",
+			want: Document{Unclosed: true, Body: "---\nname: demo", BodyLine: 1, Issues: []Issue{
+				{Line: 1, Message: `frontmatter opened here is never closed with "---"; the whole file is read as Markdown`},
+			}},
+		},
+		{
+			name:    "byte order mark",
+			content: "\uFEFF---\nname: demo\n---\nBody\n",
+			want: Document{HasFrontmatter: true, Frontmatter: "name: demo", Body: "Body", BodyLine: 4, Issues: []Issue{
+				{Line: 1, Message: "file starts with a UTF-8 byte order mark (BOM); save it as UTF-8 without BOM"},
+			}},
+		},
+		{
+			name:    "CRLF line endings",
+			content: "---\r\nname: demo\r\n---\r\n\r\nBody\r\n",
+			want: Document{HasFrontmatter: true, Frontmatter: "name: demo", Body: "Body", BodyLine: 5, Issues: []Issue{
+				{Line: 1, Message: "file uses Windows (CRLF) line endings; use LF"},
+			}},
+		},
+		{
+			name:    "CRLF without final newline",
+			content: "---\r\nname: demo\r\n---\r\nBody",
+			want: Document{HasFrontmatter: true, Frontmatter: "name: demo", Body: "Body", BodyLine: 4, Issues: []Issue{
+				{Line: 1, Message: "file uses Windows (CRLF) line endings; use LF"},
+			}},
+		},
+		{
+			name:    "mixed line endings",
+			content: "---\nname: demo\r\n---\nBody\n",
+			want: Document{HasFrontmatter: true, Frontmatter: "name: demo", Body: "Body", BodyLine: 4, Issues: []Issue{
+				{Line: 2, Message: "file mixes CRLF and LF line endings; use LF throughout"},
+			}},
+		},
+		{
+			name:    "document end marker",
+			content: "---\nname: demo\n...\nBody\n",
+			want: Document{HasFrontmatter: true, Frontmatter: "name: demo", Body: "Body", BodyLine: 4, Issues: []Issue{
+				{Line: 3, Message: `frontmatter is closed with "..." instead of "---"; most tools only recognize "---"`},
+			}},
+		},
+		{
+			name:    "delimiter with trailing whitespace",
+			content: "--- \nname: demo\n---\n",
+			want: Document{HasFrontmatter: true, Frontmatter: "name: demo", BodyLine: 4, Issues: []Issue{
+				{Line: 1, Message: `frontmatter delimiter "--- " has surrounding whitespace; most tools only recognize "---" on its own`},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Split([]byte(tt.content))
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("Split() =\n%+v\nwant\n%+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplit_LongLines(t *testing.T) {
+	long := strings.Repeat("x", MaxLineLength+1)
+	content := "---\ndescription: " + long + "\n---\n\n" + long + "\n"
+
+	doc := Split([]byte(content))
+	if !doc.HasFrontmatter || doc.Frontmatter != "description: "+long || doc.Body != long {
+		t.Fatalf("long lines were not read: HasFrontmatter=%v, frontmatter %d bytes, body %d bytes", doc.HasFrontmatter, len(doc.Frontmatter), len(doc.Body))
+	}
+	var lines []int
+	for _, issue := range doc.Issues {
+		if strings.Contains(issue.Message, "over 64KB") {
+			lines = append(lines, issue.Line)
+		}
+	}
+	if !reflect.DeepEqual(lines, []int{2, 5}) {
+		t.Errorf("long line issues on lines %v, want [2 5]", lines)
+	}
+}
+
+func TestRead_Error(t *testing.T) {
+	if _, err := Read(iotest.ErrReader(iotest.ErrTimeout)); err == nil || !strings.Contains(err.Error(), "error reading file") {
+		t.Errorf("Read() error = %v", err)
+	}
+}
+
+func TestDelimiters(t *testing.T) {
+	for _, line := range []string{"---", "--- ", "---\r", "\uFEFF---"} {
+		if !IsOpening(line) {
+			t.Errorf("IsOpening(%q) = false", line)
+		}
+	}
+	for _, line := range []string{"", "----", "- --", "..."} {
+		if IsOpening(line) {
+			t.Errorf("IsOpening(%q) = true", line)
+		}
+	}
+	for _, line := range []string{"---", "...", " ...\r"} {
+		if !IsClosing(line) {
+			t.Errorf("IsClosing(%q) = false", line)
+		}
+	}
+	if IsClosing("....") {
+		t.Error(`IsClosing("....") = true`)
+	}
+}
+
+func TestFenceMarker(t *testing.T) {
+	tests := map[string]string{
+		"```":       "```",
+		"```bash":   "```",
+		"  ~~~~ ":   "~~~",
+		"``":        "",
+		"text ```":  "",
+		"- ```item": "",
+	}
+	for line, want := range tests {
+		if got := FenceMarker(line); got != want {
+			t.Errorf("FenceMarker(%q) = %q, want %q", line, got, want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Package glob translates gitignore-style globs into regular expressions.
+// It is shared by discovery (exclude patterns and .gitignore files) and
+// policy (path globs of allowed-tools entries) so both match alike.
+package glob
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+)
+
+// Expr returns the unanchored regular expression of a slash-separated
+// glob:
+//   - "*" and "?" match within one path element;
+//   - "**/" matches zero or more directories, any other "**" everything;
+//   - "[...]" is a character class, negated by "[!...]". A "]" right after
+//     "[" or "[!" is part of the class and "\" in a class is literal. An
+//     unclosed "[" is literal.
+//
+// The expression may still fail to compile (e.g. the range "[z-a]").
+func Expr(glob string) string {
+	var sb strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				if i+2 < len(glob) && glob[i+2] == '/' {
+					sb.WriteString("(?:.*/)?")
+					i += 2
+				} else {
+					sb.WriteString(".*")
+					i++
+				}
+				continue
+			}
+			sb.WriteString("[^/]*")
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			start := i + 1
+			if start < len(glob) && glob[start] == '!' {
+				start++
+			}
+			if start < len(glob) && glob[start] == ']' {
+				start++
+			}
+			end := strings.IndexByte(glob[start:], ']')
+			if end < 0 {
+				sb.WriteString(`\[`)
+				continue
+			}
+			end += start
+			class := glob[i+1 : end]
+			negate := strings.HasPrefix(class, "!")
+			if negate {
+				class = class[1:]
+			}
+			class = strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`).Replace(class)
+			if negate {
+				class = "^" + class
+			}
+			sb.WriteString("[" + class + "]")
+			i = end
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return sb.String()
+}
+
+// Compile returns a regular expression matching the whole paths matched by
+// glob, or an error describing why the glob is invalid.
+func Compile(glob string) (*regexp.Regexp, error) {
+	return CompileExpr(glob, "^"+Expr(glob)+"$")
+}
+
+// CompileExpr compiles expr, the regular expression built for glob,
+// reporting errors in terms of the glob.
+func CompileExpr(glob, expr string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		var serr *syntax.Error
+		if errors.As(err, &serr) {
+			return nil, fmt.Errorf("invalid pattern %q: %s %q", glob, serr.Code, serr.Expr)
+		}
+		return nil, fmt.Errorf("invalid pattern %q: %w", glob, err)
+	}
+	return re, nil
+}
@@ -0,0 +1,54 @@
+package glob
+
+import "testing"
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		glob string
+		path string
+		want bool
+	}{
+		{"a/*/c", "a/b/c", true},
+		{"a/*/c", "a/b/x/c", false},
+		{"a/**/c", "a/b/x/c", true},
+		{"a/**/c", "a/c", true},
+		{"**/tmp", "tmp", true},
+		{"**/tmp", "x/y/tmp", true},
+		{"src/**", "src/pkg/main.go", true},
+		{"src/**", "docs/main.go", false},
+		{"tmp?", "tmp1", true},
+		{"tmp?", "tmp/", false},
+		{"tmp[0-9]", "tmp5", true},
+		{"tmp[!0-9]", "tmp5", false},
+		{"x[]]", "x]", true},
+		{"x[!]]", "xa", true},
+		{"x[!]]", "x]", false},
+		{`x[\d]`, `x\`, true},
+		{`x[\d]`, "x1", false},
+		{"x[", "x[", true},
+		{"x[]", "x[]", true},
+		{"a.b", "axb", false},
+		{"a+(b)", "a+(b)", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.glob+"|"+tt.path, func(t *testing.T) {
+			re, err := Compile(tt.glob)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := re.MatchString(tt.path); got != tt.want {
+				t.Errorf("Compile(%q).MatchString(%q) = %v, want %v", tt.glob, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompile_Invalid(t *testing.T) {
+	_, err := Compile("[z-a]")
+	if err == nil {
+		t.Fatal("expected an error for an invalid range")
+	}
+	if want := `invalid pattern "[z-a]": invalid character class range "z-a"`; err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+}
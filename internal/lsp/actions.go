@@ -0,0 +1,82 @@
+package lsp
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/biwakonbu/aglx/internal/fix"
+	"github.com/biwakonbu/aglx/internal/skill"
+)
+
+// codeActions returns quick fixes for the diagnostics the client sent:
+// normalizing the name, matching the name to the directory and converting
+// allowed-tools to the separator of the specification that reported it.
+// Each fix rewrites one frontmatter value in place, like aglx fix.
+func (s *Server) codeActions(doc *document, diagnostics []Diagnostic) []codeAction {
+	actions := []codeAction{}
+	parsed, err := skill.ParseReader(strings.NewReader(doc.text), doc.dir())
+	if err != nil {
+		return actions
+	}
+
+	// Diagnostics with the same fix share one action.
+	index := make(map[string]int)
+	for _, d := range diagnostics {
+		if d.Source != diagnosticSource {
+			continue
+		}
+		key, value, title, ok := quickFix(doc, parsed, d)
+		if !ok {
+			continue
+		}
+		if i, ok := index[key+"\x00"+value]; ok {
+			actions[i].Diagnostics = append(actions[i].Diagnostics, d)
+			continue
+		}
+		fixed, err := fix.SetField([]byte(doc.text), key, value)
+		if err != nil {
+			continue // e.g. a multi-line value
+		}
+		index[key+"\x00"+value] = len(actions)
+		actions = append(actions, codeAction{
+			Title:       title,
+			Kind:        codeActionKindQuickFix,
+			Diagnostics: []Diagnostic{d},
+			IsPreferred: true,
+			Edit: workspaceEdit{Changes: map[string][]TextEdit{
+				doc.uri: {lineEdit(doc.text, string(fixed))},
+			}},
+		})
+	}
+	return actions
+}
+
+// quickFix returns the frontmatter key and value that resolve a diagnostic.
+func quickFix(doc *document, parsed *skill.Skill, d Diagnostic) (key, value, title string, ok bool) {
+	switch d.Code {
+	case skill.RuleNameFormat:
+		value = skill.NormalizeName(parsed.Name)
+		if !skill.IsValidName(value) || value == parsed.Name {
+			return "", "", "", false
+		}
+		return "name", value, fmt.Sprintf("Change name to %q", value), true
+	case skill.RuleNameDirMatch:
+		value = filepath.Base(doc.dir())
+		if !skill.IsValidName(value) || value == parsed.Name {
+			return "", "", "", false
+		}
+		return "name", value, fmt.Sprintf("Change name to %q to match the directory", value), true
+	case skill.RuleAllowedToolsSeparator:
+		if d.Data == nil {
+			return "", "", "", false
+		}
+		value, ok = fix.AllowedTools(parsed, skill.Spec(d.Data.Spec))
+		if !ok {
+			return "", "", "", false
+		}
+		return "allowed-tools", value, fmt.Sprintf("Change allowed-tools to %q", value), true
+	default:
+		return "", "", "", false
+	}
+}
@@ -0,0 +1,154 @@
+package lsp
+
+import (
+	"strings"
+
+	"github.com/biwakonbu/aglx/internal/frontmatter"
+)
+
+// field documents a SKILL.md frontmatter key for completion and hover.
+type field struct {
+	key    string
+	detail string
+	doc    string
+}
+
+// fields are the frontmatter keys defined by the specification, in the
+// order they usually appear.
+var fields = []field{
+	{
+		key:    "name",
+		detail: "Skill identifier (required)",
+		doc: "1-64 characters: lowercase letters, digits and hyphens, with no leading, trailing or consecutive hyphens. " +
+			"Must match the name of the skill's directory.\n\n" +
+			"The Claude Code specification also forbids XML tags and the reserved words `anthropic` and `claude`.",
+	},
+	{
+		key:    "description",
+		detail: "What the skill does and when to use it (required)",
+		doc: "1-1024 characters. Agents read the description to decide when to load the skill, " +
+			"so say both what it does and when it should be used.\n\n" +
+			"The Claude Code specification forbids XML tags.",
+	},
+	{
+		key:    "license",
+		detail: "License of the skill (optional)",
+		doc:    "A license name (e.g. `Apache-2.0`) or the name of a license file bundled with the skill.",
+	},
+	{
+		key:    "compatibility",
+		detail: "Environment requirements (optional)",
+		doc:    "1-500 characters describing what the skill needs to run, e.g. the intended product, system packages or network access.",
+	},
+	{
+		key:    "allowed-tools",
+		detail: "Pre-approved tools (optional, experimental)",
+		doc: "Tools the skill may use without asking, each written as `ToolName` or `ToolName(args)`.\n\n" +
+			"The Agent Skills specification separates them with spaces (`Read Grep`), Claude Code with commas (`Read, Grep`).",
+	},
+	{
+		key:    "metadata",
+		detail: "Additional properties (optional)",
+		doc: "A map from string keys to string values for properties the specification does not define, e.g. `author` or `version`.\n\n" +
+			"`aglx-ignore` lists aglx rules to suppress for the whole skill.",
+	},
+}
+
+func lookupField(key string) (field, bool) {
+	for _, f := range fields {
+		if f.key == key {
+			return f, true
+		}
+	}
+	return field{}, false
+}
+
+func (f field) markdown() string {
+	return "**" + f.key + "**: " + f.detail + "\n\n" + f.doc
+}
+
+// frontmatterEnd returns the index of the closing "---" (or "...") line,
+// len(lines) if the frontmatter is not closed yet, or -1 if there is no
+// frontmatter.
+func frontmatterEnd(lines []string) int {
+	if len(lines) == 0 || !frontmatter.IsOpening(lines[0]) {
+		return -1
+	}
+	for i := 1; i < len(lines); i++ {
+		if frontmatter.IsClosing(lines[i]) {
+			return i
+		}
+	}
+	return len(lines)
+}
+
+// inFrontmatter reports whether line is between the frontmatter delimiters.
+func inFrontmatter(lines []string, line int) bool {
+	return line > 0 && line < frontmatterEnd(lines)
+}
+
+// frontmatterKey returns the top-level key a frontmatter line defines, or ""
+// if the line is indented, a comment or has no key.
+func frontmatterKey(line string) string {
+	if line == "" || strings.ContainsRune(" \t#-", rune(line[0])) {
+		return ""
+	}
+	key, _, ok := strings.Cut(line, ":")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(key)
+}
+
+// completeKeys offers the frontmatter keys that are not used yet when the
+// cursor is where a top-level key starts.
+func completeKeys(lines []string, pos Position) []completionItem {
+	items := []completionItem{}
+	if !inFrontmatter(lines, pos.Line) {
+		return items
+	}
+	line := lines[pos.Line]
+	typed := line[:byteOffset(line, pos.Character)]
+	if strings.ContainsAny(typed, ": \t#") || strings.HasPrefix(typed, "-") {
+		return items
+	}
+
+	used := map[string]bool{}
+	for i := 1; i < frontmatterEnd(lines); i++ {
+		if i != pos.Line {
+			used[frontmatterKey(lines[i])] = true
+		}
+	}
+	for _, f := range fields {
+		if used[f.key] || !strings.HasPrefix(f.key, typed) {
+			continue
+		}
+		items = append(items, completionItem{
+			Label:         f.key,
+			Kind:          completionItemKindProperty,
+			Detail:        f.detail,
+			Documentation: markupContent{Kind: "markdown", Value: f.doc},
+			TextEdit: &TextEdit{
+				Range:   Range{Start: Position{Line: pos.Line}, End: pos},
+				NewText: f.key + ": ",
+			},
+		})
+	}
+	return items
+}
+
+// hoverKey documents the frontmatter key under the cursor.
+func hoverKey(lines []string, pos Position) *hover {
+	if !inFrontmatter(lines, pos.Line) {
+		return nil
+	}
+	key := frontmatterKey(lines[pos.Line])
+	f, ok := lookupField(key)
+	if !ok || pos.Character > utf16Len(key) {
+		return nil
+	}
+	return &hover{
+		Contents: markupContent{Kind: "markdown", Value: f.markdown()},
+		Range:    &Range{Start: Position{Line: pos.Line}, End: Position{Line: pos.Line, Character: utf16Len(key)}},
+	}
+}
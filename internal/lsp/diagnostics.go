@@ -0,0 +1,109 @@
+package lsp
+
+import (
+	"strings"
+
+	"github.com/biwakonbu/aglx/internal/checker"
+	"github.com/biwakonbu/aglx/internal/claude"
+	"github.com/biwakonbu/aglx/internal/skill"
+	"github.com/biwakonbu/aglx/internal/source"
+)
+
+// diagnosticSource is the source shown next to every diagnostic.
+const diagnosticSource = "aglx"
+
+// skillDiagnostics validates the text of a SKILL.md document against the
+// specifications selected by opts. The optional directories are read from
+// disk. Under SpecAuto a finding reported for only one specification is
+// prefixed with its name, as in quiet output.
+func skillDiagnostics(doc *document, opts *checker.CheckOptions) []Diagnostic {
+	lines := splitLines(doc.text)
+	parsed, err := skill.ParseReader(strings.NewReader(doc.text), doc.dir())
+	if err != nil {
+		return []Diagnostic{{
+			Range:    findingRange(lines, source.Position{}),
+			Severity: SeverityError,
+			Source:   diagnosticSource,
+			Message:  err.Error(),
+		}}
+	}
+
+	type entry struct {
+		diagnostic Diagnostic
+		specs      []skill.Spec
+	}
+	var entries []*entry
+	seen := make(map[Diagnostic]*entry)
+	add := func(spec skill.Spec, e skill.ValidationError, severity int) {
+		pos := e.Pos
+		if pos.File != doc.path {
+			// Directory findings (e.g. scripts/) are shown at the top.
+			pos = source.Position{}
+		}
+		d := Diagnostic{
+			Range:    findingRange(lines, pos),
+			Severity: severity,
+			Code:     e.Rule,
+			Source:   diagnosticSource,
+			Message:  e.Error(),
+		}
+		if en, ok := seen[d]; ok {
+			en.specs = append(en.specs, spec)
+			return
+		}
+		en := &entry{diagnostic: d, specs: []skill.Spec{spec}}
+		seen[d] = en
+		entries = append(entries, en)
+	}
+
+	specs := opts.Specs()
+	for _, spec := range specs {
+		result := skill.ValidateWithOptions(parsed, opts.ValidationOptions(spec))
+		for _, e := range result.Errors {
+			add(spec, e, SeverityError)
+		}
+		for _, e := range result.Warnings {
+			add(spec, e, SeverityWarning)
+		}
+	}
+
+	diagnostics := make([]Diagnostic, 0, len(entries))
+	for _, en := range entries {
+		d := en.diagnostic
+		if len(en.specs) < len(specs) || len(specs) == 1 {
+			d.Data = &diagnosticData{Spec: string(en.specs[0])}
+		}
+		if len(en.specs) < len(specs) {
+			d.Message = string(en.specs[0]) + ": " + d.Message
+		}
+		diagnostics = append(diagnostics, d)
+	}
+	return diagnostics
+}
+
+// claudeDiagnostics validates the text of a CLAUDE.md document.
+func claudeDiagnostics(doc *document, opts *claude.ValidationOptions) []Diagnostic {
+	lines := splitLines(doc.text)
+	parsed, err := claude.ParseReader(strings.NewReader(doc.text), doc.path)
+	if err != nil {
+		return []Diagnostic{{
+			Range:    findingRange(lines, source.Position{}),
+			Severity: SeverityError,
+			Source:   diagnosticSource,
+			Message:  err.Error(),
+		}}
+	}
+
+	result := claude.ValidateWithOptions(parsed, opts)
+	diagnostics := make([]Diagnostic, 0, len(result.Warnings))
+	for _, w := range result.Warnings {
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    findingRange(lines, w.Pos),
+			Severity: SeverityWarning,
+			Code:     w.Rule,
+			Source:   diagnosticSource,
+			Message:  w.Field + ": " + w.Message,
+		})
+	}
+	return diagnostics
+}
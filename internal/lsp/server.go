@@ -0,0 +1,333 @@
+// Package lsp implements a Language Server Protocol server (`aglx lsp`) that
+// validates SKILL.md and CLAUDE.md files as they are edited.
+//
+// The server speaks JSON-RPC over a byte stream (stdio), keeps the text of
+// open documents in memory and publishes diagnostics on every change. It
+// also offers completion and hover documentation for SKILL.md frontmatter
+// keys and quick fixes for the violations aglx fix can correct.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/biwakonbu/aglx/internal/checker"
+	"github.com/biwakonbu/aglx/internal/claude"
+	"github.com/biwakonbu/aglx/internal/config"
+)
+
+// ErrNoShutdown is returned by Serve when the client sends exit without a
+// preceding shutdown request.
+var ErrNoShutdown = errors.New("exit received before shutdown")
+
+// Options configures a Server.
+type Options struct {
+	// Config returns the configuration for documents in dir. If nil, the
+	// nearest .aglx.yaml searched upward from dir is used.
+	Config func(dir string) (*config.Config, error)
+
+	// Version is reported to the client as the server version.
+	Version string
+}
+
+// documentKind tells which validator a document belongs to.
+type documentKind int
+
+const (
+	skillDocument documentKind = iota
+	claudeDocument
+)
+
+// document is an open SKILL.md or CLAUDE.md file.
+type document struct {
+	uri  string
+	path string
+	kind documentKind
+	text string
+}
+
+// Server is a language server for skill files. A Server handles a single
+// connection; it is not safe for concurrent use.
+type Server struct {
+	opts Options
+	out  *writer
+	docs map[string]*document
+
+	initialized bool
+	shutdown    bool
+
+	// logged holds the errors already sent to the client, so that a broken
+	// config file is reported once rather than on every keystroke.
+	logged map[string]bool
+}
+
+// NewServer returns a server configured by opts (nil uses the defaults).
+func NewServer(opts *Options) *Server {
+	s := &Server{docs: make(map[string]*document), logged: make(map[string]bool)}
+	if opts != nil {
+		s.opts = *opts
+	}
+	if s.opts.Config == nil {
+		s.opts.Config = config.LoadNearest
+	}
+	return s
+}
+
+// Serve reads requests from r and writes responses and notifications to w
+// until the client sends exit or closes r.
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	s.out = &writer{w: w}
+	in := bufio.NewReader(r)
+	for {
+		body, err := readMessage(in)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var req request
+		if err := json.Unmarshal(body, &req); err != nil {
+			if err := s.replyError(json.RawMessage("null"), &responseError{codeParseError, err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+		if req.Method == "exit" {
+			if !s.shutdown {
+				return ErrNoShutdown
+			}
+			return nil
+		}
+		if err := s.handle(&req); err != nil {
+			return err
+		}
+	}
+}
+
+func (e *responseError) Error() string { return e.Message }
+
+// handle runs one request or notification. Only failures to write to the
+// client are returned; everything else is reported as a JSON-RPC error.
+func (s *Server) handle(req *request) error {
+	isRequest := len(req.ID) > 0 && string(req.ID) != "null"
+
+	var result any
+	var err error
+	switch {
+	case !s.initialized && req.Method != "initialize":
+		err = &responseError{codeServerNotInitialized, "server not initialized"}
+	case s.shutdown:
+		err = &responseError{codeInvalidRequest, "server is shutting down"}
+	default:
+		result, err = s.dispatch(req)
+	}
+
+	var rerr *responseError
+	if errors.As(err, &rerr) {
+		if !isRequest {
+			return nil // Notifications have no response
+		}
+		return s.replyError(req.ID, rerr)
+	}
+	if err != nil || !isRequest {
+		return err
+	}
+	return s.out.write(response{JSONRPC: "2.0", ID: req.ID, Result: result})
+}
+
+func (s *Server) dispatch(req *request) (any, error) {
+	switch req.Method {
+	case "initialize":
+		s.initialized = true
+		return s.initialize(), nil
+	case "initialized":
+		return nil, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := decode(req.Params, &params); err != nil {
+			return nil, err
+		}
+		doc := newDocument(params.TextDocument.URI, params.TextDocument.Text)
+		if doc == nil {
+			return nil, nil // Not a skill file
+		}
+		s.docs[doc.uri] = doc
+		return nil, s.publish(doc)
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := decode(req.Params, &params); err != nil {
+			return nil, err
+		}
+		doc := s.docs[params.TextDocument.URI]
+		if doc == nil || len(params.ContentChanges) == 0 {
+			return nil, nil
+		}
+		// Full synchronization: the last change holds the whole document.
+		doc.text = params.ContentChanges[len(params.ContentChanges)-1].Text
+		return nil, s.publish(doc)
+	case "textDocument/didSave":
+		var params didSaveParams
+		if err := decode(req.Params, &params); err != nil {
+			return nil, err
+		}
+		// Files next to the document (e.g. scripts/) may have changed too.
+		if doc := s.docs[params.TextDocument.URI]; doc != nil {
+			return nil, s.publish(doc)
+		}
+		return nil, nil
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err := decode(req.Params, &params); err != nil {
+			return nil, err
+		}
+		if _, ok := s.docs[params.TextDocument.URI]; !ok {
+			return nil, nil
+		}
+		delete(s.docs, params.TextDocument.URI)
+		return nil, s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: params.TextDocument.URI, Diagnostics: []Diagnostic{}})
+
+	case "textDocument/completion":
+		var params textDocumentPositionParams
+		if err := decode(req.Params, &params); err != nil {
+			return nil, err
+		}
+		doc := s.docs[params.TextDocument.URI]
+		if doc == nil || doc.kind != skillDocument {
+			return []completionItem{}, nil
+		}
+		return completeKeys(splitLines(doc.text), params.Position), nil
+	case "textDocument/hover":
+		var params textDocumentPositionParams
+		if err := decode(req.Params, &params); err != nil {
+			return nil, err
+		}
+		doc := s.docs[params.TextDocument.URI]
+		if doc == nil || doc.kind != skillDocument {
+			return nil, nil
+		}
+		if h := hoverKey(splitLines(doc.text), params.Position); h != nil {
+			return h, nil
+		}
+		return nil, nil
+	case "textDocument/codeAction":
+		var params codeActionParams
+		if err := decode(req.Params, &params); err != nil {
+			return nil, err
+		}
+		doc := s.docs[params.TextDocument.URI]
+		if doc == nil || doc.kind != skillDocument {
+			return []codeAction{}, nil
+		}
+		return s.codeActions(doc, params.Context.Diagnostics), nil
+
+	default:
+		if strings.HasPrefix(req.Method, "$/") {
+			return nil, nil // Optional protocol notifications
+		}
+		return nil, &responseError{codeMethodNotFound, fmt.Sprintf("method %q is not supported", req.Method)}
+	}
+}
+
+func (s *Server) initialize() initializeResult {
+	return initializeResult{
+		Capabilities: serverCapabilities{
+			TextDocumentSync: textDocumentSyncOptions{
+				OpenClose: true,
+				Change:    textDocumentSyncFull,
+				Save:      true,
+			},
+			HoverProvider:      true,
+			CodeActionProvider: codeActionOptions{CodeActionKinds: []string{codeActionKindQuickFix}},
+		},
+		ServerInfo: serverInfo{Name: "aglx", Version: s.opts.Version},
+	}
+}
+
+// dir returns the directory holding the document.
+func (d *document) dir() string {
+	return filepath.Dir(d.path)
+}
+
+// newDocument returns the document for uri, or nil if it is not a SKILL.md
+// or CLAUDE.md file on disk.
+func newDocument(uri, text string) *document {
+	path, err := uriToPath(uri)
+	if err != nil {
+		return nil
+	}
+	doc := &document{uri: uri, path: filepath.Clean(path), text: text}
+	switch filepath.Base(path) {
+	case "SKILL.md":
+		doc.kind = skillDocument
+	case claude.ClaudeFileName:
+		doc.kind = claudeDocument
+	default:
+		return nil
+	}
+	return doc
+}
+
+// options returns the check options for a document, falling back to the
+// defaults if its config file cannot be used.
+func (s *Server) options(doc *document) *checker.CheckOptions {
+	dir := doc.dir()
+	if doc.kind == claudeDocument && filepath.Base(dir) == claude.ClaudeDir {
+		dir = filepath.Dir(dir) // .claude/CLAUDE.md belongs to the skill one level up
+	}
+	cfg, err := s.opts.Config(dir)
+	if err != nil {
+		s.logError(err)
+		return &checker.CheckOptions{}
+	}
+	return cfg.CheckOptions()
+}
+
+// logError sends an error to the client's log, once per distinct message.
+func (s *Server) logError(err error) {
+	msg := "aglx: " + err.Error()
+	if s.logged[msg] {
+		return
+	}
+	s.logged[msg] = true
+	// A failed write surfaces on the next response.
+	_ = s.notify("window/logMessage", logMessageParams{Type: messageTypeError, Message: msg})
+}
+
+func (s *Server) publish(doc *document) error {
+	opts := s.options(doc)
+	var diagnostics []Diagnostic
+	switch doc.kind {
+	case skillDocument:
+		diagnostics = skillDiagnostics(doc, opts)
+	case claudeDocument:
+		diagnostics = claudeDiagnostics(doc, &opts.ClaudeMd)
+	}
+	return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: doc.uri, Diagnostics: diagnostics})
+}
+
+func (s *Server) notify(method string, params any) error {
+	return s.out.write(notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+func (s *Server) replyError(id json.RawMessage, rerr *responseError) error {
+	return s.out.write(errorResponse{JSONRPC: "2.0", ID: id, Error: *rerr})
+}
+
+// decode unmarshals request parameters, reporting failures as invalid params.
+func decode(params json.RawMessage, v any) error {
+	if err := json.Unmarshal(params, v); err != nil {
+		return &responseError{codeInvalidParams, err.Error()}
+	}
+	return nil
+}
@@ -0,0 +1,364 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/biwakonbu/aglx/internal/config"
+	"github.com/biwakonbu/aglx/internal/skill"
+)
+
+// message is any message the server writes.
+type message struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *responseError  `json:"error"`
+}
+
+// script frames client messages. Values with an "id" are requests.
+func script(t *testing.T, msgs ...map[string]any) io.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	for _, m := range msgs {
+		m["jsonrpc"] = "2.0"
+		body, err := json.Marshal(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprintf(&buf, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	}
+	return &buf
+}
+
+func req(id int, method string, params any) map[string]any {
+	return map[string]any{"id": id, "method": method, "params": params}
+}
+
+func notif(method string, params any) map[string]any {
+	return map[string]any{"method": method, "params": params}
+}
+
+// run serves a scripted session with the given config and returns the
+// server's messages.
+func run(t *testing.T, cfg *config.Config, msgs ...map[string]any) ([]message, error) {
+	t.Helper()
+	s := NewServer(&Options{
+		Config:  func(string) (*config.Config, error) { return cfg, nil },
+		Version: "test",
+	})
+	var out bytes.Buffer
+	err := s.Serve(script(t, msgs...), &out)
+
+	var got []message
+	r := bufio.NewReader(&out)
+	for {
+		body, rerr := readMessage(r)
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			t.Fatal(rerr)
+		}
+		var m message
+		if err := json.Unmarshal(body, &m); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, m)
+	}
+	return got, err
+}
+
+// responseTo returns the response to request id.
+func responseTo(t *testing.T, msgs []message, id int) message {
+	t.Helper()
+	for _, m := range msgs {
+		if m.Method == "" && string(m.ID) == fmt.Sprint(id) {
+			return m
+		}
+	}
+	t.Fatalf("no response to request %d", id)
+	return message{}
+}
+
+// diagnostics returns the published diagnostics, in order.
+func diagnostics(t *testing.T, msgs []message) [][]Diagnostic {
+	t.Helper()
+	var out [][]Diagnostic
+	for _, m := range msgs {
+		if m.Method != "textDocument/publishDiagnostics" {
+			continue
+		}
+		var p publishDiagnosticsParams
+		if err := json.Unmarshal(m.Params, &p); err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, p.Diagnostics)
+	}
+	return out
+}
+
+func fileURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+// skillFile creates a skill directory and returns the URI of its SKILL.md.
+func skillFile(t *testing.T, dirName string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), dirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "SKILL.md")
+	if err := os.WriteFile(path, []byte("---\nname: "+dirName+"\ndescription: On disk.\n---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return fileURI(path)
+}
+
+var (
+	initialize = req(1, "initialize", map[string]any{"capabilities": map[string]any{}})
+	shutdown   = req(99, "shutdown", nil)
+	exit       = notif("exit", nil)
+)
+
+func TestServer_Lifecycle(t *testing.T) {
+	msgs, err := run(t, &config.Config{},
+		req(0, "textDocument/hover", map[string]any{}),
+		initialize,
+		notif("initialized", map[string]any{}),
+		notif("$/setTrace", map[string]any{"value": "off"}),
+		req(2, "workspace/symbol", map[string]any{}),
+		shutdown,
+		req(3, "textDocument/hover", map[string]any{}),
+		exit,
+	)
+	if err != nil {
+		t.Fatalf("Serve() = %v", err)
+	}
+
+	if e := responseTo(t, msgs, 0).Error; e == nil || e.Code != codeServerNotInitialized {
+		t.Errorf("request before initialize: error = %v", e)
+	}
+	var init initializeResult
+	if err := json.Unmarshal(responseTo(t, msgs, 1).Result, &init); err != nil {
+		t.Fatal(err)
+	}
+	if init.ServerInfo.Name != "aglx" || init.ServerInfo.Version != "test" || init.Capabilities.TextDocumentSync.Change != textDocumentSyncFull || !init.Capabilities.HoverProvider {
+		t.Errorf("unexpected initialize result: %+v", init)
+	}
+	if e := responseTo(t, msgs, 2).Error; e == nil || e.Code != codeMethodNotFound {
+		t.Errorf("unknown method: error = %v", e)
+	}
+	if m := responseTo(t, msgs, 99); m.Error != nil || string(m.Result) != "null" {
+		t.Errorf("shutdown response = %+v", m)
+	}
+	if e := responseTo(t, msgs, 3).Error; e == nil || e.Code != codeInvalidRequest {
+		t.Errorf("request after shutdown: error = %v", e)
+	}
+
+	if _, err := run(t, &config.Config{}, initialize, exit); !errors.Is(err, ErrNoShutdown) {
+		t.Errorf("exit without shutdown: Serve() = %v, want ErrNoShutdown", err)
+	}
+	if _, err := run(t, &config.Config{}, initialize); err != nil {
+		t.Errorf("closed input: Serve() = %v, want nil", err)
+	}
+}
+
+func TestServer_SkillDiagnostics(t *testing.T) {
+	uri := skillFile(t, "my-skill")
+	open := notif("textDocument/didOpen", map[string]any{"textDocument": map[string]any{
+		"uri": uri, "languageId": "markdown", "version": 1,
+		"text": "---\nname: My-Skill\ndescription: Edited.\nallowed-tools: Read Grep\n---\n\n# Skill\n",
+	}})
+	change := notif("textDocument/didChange", map[string]any{
+		"textDocument":   map[string]any{"uri": uri, "version": 2},
+		"contentChanges": []map[string]any{{"text": "---\nname: my-skill\ndescription: Fixed.\n---\n"}},
+	})
+	closeDoc := notif("textDocument/didClose", map[string]any{"textDocument": map[string]any{"uri": uri}})
+
+	msgs, err := run(t, &config.Config{}, initialize, open, change, closeDoc, shutdown, exit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	published := diagnostics(t, msgs)
+	if len(published) != 3 {
+		t.Fatalf("expected 3 publishDiagnostics notifications, got %d", len(published))
+	}
+
+	var got []string
+	for _, d := range published[0] {
+		got = append(got, fmt.Sprintf("%d:%d-%d:%d %d %s %s", d.Range.Start.Line, d.Range.Start.Character, d.Range.End.Line, d.Range.End.Character, d.Severity, d.Code, d.Message))
+	}
+	want := []string{
+		// Reported by both specifications: no prefix.
+		"1:6-1:14 1 name-format name: must be lowercase (uppercase characters not allowed)",
+		"1:6-1:14 1 name-format name: may only contain lowercase alphanumeric characters (a-z, 0-9) and hyphens (-)",
+		"1:6-1:14 1 name-dir-match name: must match parent directory name (expected \"my-skill\", got \"My-Skill\")",
+		// Only Claude Code requires commas.
+		"3:15-3:24 1 allowed-tools-separator claude-code: allowed-tools: must use comma-separated format for Claude Code specification (e.g., 'Read, Grep, Glob')",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("diagnostics:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if d := published[0][3]; d.Data == nil || d.Data.Spec != string(skill.SpecClaudeCode) {
+		t.Errorf("expected the spec in the diagnostic data, got %+v", d.Data)
+	}
+	if len(published[1]) != 0 || len(published[2]) != 0 {
+		t.Errorf("expected no diagnostics after the fix and on close, got %v and %v", published[1], published[2])
+	}
+}
+
+func TestServer_SpecFromConfig(t *testing.T) {
+	uri := skillFile(t, "my-skill")
+	open := notif("textDocument/didOpen", map[string]any{"textDocument": map[string]any{
+		"uri": uri, "version": 1,
+		"text": "---\nname: my-skill\ndescription: Edited.\nallowed-tools: Read Grep\n---\n",
+	}})
+	msgs, err := run(t, &config.Config{Spec: skill.SpecAgentSkills}, initialize, open, shutdown, exit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if published := diagnostics(t, msgs); len(published) != 1 || len(published[0]) != 0 {
+		t.Errorf("expected no diagnostics for agent-skills, got %v", published)
+	}
+}
+
+func TestServer_ClaudeMdDiagnostics(t *testing.T) {
+	dir := t.TempDir()
+	uri := fileURI(filepath.Join(dir, ".claude", "CLAUDE.md"))
+	other := fileURI(filepath.Join(dir, "README.md"))
+	open := func(uri, text string) map[string]any {
+		return notif("textDocument/didOpen", map[string]any{"textDocument": map[string]any{"uri": uri, "version": 1, "text": text}})
+	}
+
+	msgs, err := run(t, &config.Config{}, initialize, open(uri, "\n"), open(other, ""), shutdown, exit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	published := diagnostics(t, msgs)
+	if len(published) != 1 {
+		t.Fatalf("expected diagnostics for CLAUDE.md only, got %v", published)
+	}
+	if len(published[0]) != 1 || published[0][0].Code != "claude-md-empty" || published[0][0].Severity != SeverityWarning {
+		t.Errorf("unexpected CLAUDE.md diagnostics: %+v", published[0])
+	}
+}
+
+func TestServer_ConfigErrorIsLoggedOnce(t *testing.T) {
+	uri := skillFile(t, "my-skill")
+	s := NewServer(&Options{Config: func(string) (*config.Config, error) { return nil, errors.New("bad config") }})
+	text := "---\nname: my-skill\ndescription: Fine.\n---\n"
+	in := script(t, initialize,
+		notif("textDocument/didOpen", map[string]any{"textDocument": map[string]any{"uri": uri, "version": 1, "text": text}}),
+		notif("textDocument/didSave", map[string]any{"textDocument": map[string]any{"uri": uri}}),
+	)
+	var out bytes.Buffer
+	if err := s.Serve(in, &out); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(out.String(), "window/logMessage"); n != 1 {
+		t.Errorf("expected the config error to be logged once, got %d times:\n%s", n, out.String())
+	}
+	if n := strings.Count(out.String(), "textDocument/publishDiagnostics"); n != 2 {
+		t.Errorf("expected diagnostics with the default options on open and save, got %d", n)
+	}
+}
+
+func TestServer_CompletionAndHover(t *testing.T) {
+	uri := skillFile(t, "my-skill")
+	text := "---\nname: my-skill\nde\n---\n"
+	pos := func(id int, method string, line, char int) map[string]any {
+		return req(id, method, map[string]any{"textDocument": map[string]any{"uri": uri}, "position": map[string]any{"line": line, "character": char}})
+	}
+	msgs, err := run(t, &config.Config{}, initialize,
+		notif("textDocument/didOpen", map[string]any{"textDocument": map[string]any{"uri": uri, "version": 1, "text": text}}),
+		pos(2, "textDocument/completion", 2, 2),
+		pos(3, "textDocument/hover", 1, 2),
+		pos(4, "textDocument/hover", 1, 8),
+		shutdown, exit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var items []completionItem
+	if err := json.Unmarshal(responseTo(t, msgs, 2).Result, &items); err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].Label != "description" || items[0].TextEdit.NewText != "description: " || items[0].TextEdit.Range.End != (Position{2, 2}) {
+		t.Errorf("unexpected completion: %+v", items)
+	}
+
+	var h hover
+	if err := json.Unmarshal(responseTo(t, msgs, 3).Result, &h); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(h.Contents.Value, "1-64 characters") || h.Range.End.Character != 4 {
+		t.Errorf("unexpected hover: %+v", h)
+	}
+	if r := responseTo(t, msgs, 4).Result; string(r) != "null" {
+		t.Errorf("expected no hover over the value, got %s", r)
+	}
+}
+
+func TestServer_CodeActions(t *testing.T) {
+	uri := skillFile(t, "my-skill")
+	text := "---\nname: My_Skill  # display\ndescription: Edited.\nallowed-tools: Read Grep\n---\n\n# Skill\n"
+	diag := func(code, spec string) Diagnostic {
+		d := Diagnostic{Range: Range{Start: Position{1, 6}, End: Position{1, 14}}, Severity: SeverityError, Code: code, Source: diagnosticSource, Message: code}
+		if spec != "" {
+			d.Data = &diagnosticData{Spec: spec}
+		}
+		return d
+	}
+	msgs, err := run(t, &config.Config{}, initialize,
+		notif("textDocument/didOpen", map[string]any{"textDocument": map[string]any{"uri": uri, "version": 1, "text": text}}),
+		req(2, "textDocument/codeAction", map[string]any{
+			"textDocument": map[string]any{"uri": uri},
+			"range":        Range{},
+			"context": map[string]any{"diagnostics": []Diagnostic{
+				diag("name-format", ""),
+				diag("name-dir-match", ""),
+				diag("allowed-tools-separator", "claude-code"),
+				diag("description-required", ""),
+			}},
+		}),
+		shutdown, exit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var actions []codeAction
+	if err := json.Unmarshal(responseTo(t, msgs, 2).Result, &actions); err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 2 {
+		t.Fatalf("expected 2 actions, got %+v", actions)
+	}
+
+	// Both name diagnostics are resolved by the same edit.
+	name := actions[0]
+	if name.Title != `Change name to "my-skill"` || len(name.Diagnostics) != 2 || name.Kind != codeActionKindQuickFix {
+		t.Errorf("unexpected name action: %+v", name)
+	}
+	want := TextEdit{Range: Range{Start: Position{1, 0}, End: Position{2, 0}}, NewText: "name: my-skill  # display\n"}
+	if edits := name.Edit.Changes[uri]; len(edits) != 1 || edits[0] != want {
+		t.Errorf("name edit = %+v, want %+v", edits, want)
+	}
+
+	tools := actions[1]
+	want = TextEdit{Range: Range{Start: Position{3, 0}, End: Position{4, 0}}, NewText: "allowed-tools: Read, Grep\n"}
+	if edits := tools.Edit.Changes[uri]; tools.Title != `Change allowed-tools to "Read, Grep"` || len(edits) != 1 || edits[0] != want {
+		t.Errorf("unexpected allowed-tools action: %+v", tools)
+	}
+}
@@ -0,0 +1,124 @@
+package lsp
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/biwakonbu/aglx/internal/source"
+)
+
+// uriToPath converts a file:// URI into a file path.
+func uriToPath(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "file" {
+		return "", fmt.Errorf("unsupported URI scheme %q", u.Scheme)
+	}
+	path := u.Path
+	// file:///C:/dir -> C:/dir
+	if runtime.GOOS == "windows" && len(path) > 2 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	return filepath.FromSlash(path), nil
+}
+
+// splitLines splits text into lines without their line terminators.
+func splitLines(text string) []string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return lines
+}
+
+// utf16Len returns the length of s in UTF-16 code units, the unit of LSP
+// character offsets.
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
+
+// byteOffset converts a UTF-16 character offset into a byte offset within
+// line, clamped to the line length.
+func byteOffset(line string, character int) int {
+	n := 0
+	for i, r := range line {
+		if n >= character {
+			return i
+		}
+		n += utf16.RuneLen(r)
+	}
+	return len(line)
+}
+
+// findingRange converts a finding position into the range from that
+// position to the end of its line. Positions without a line (or in another
+// file) cover the first line of the document.
+func findingRange(lines []string, pos source.Position) Range {
+	line := pos.Line - 1
+	if line < 0 || line >= len(lines) {
+		line = 0
+	}
+	text := lines[line]
+	start := 0
+	if pos.Line > 0 && pos.Column > 0 {
+		// Columns count characters from 1.
+		start = utf16Len(prefixRunes(text, pos.Column-1))
+	}
+	end := utf16Len(text)
+	if start > end {
+		start = end
+	}
+	return Range{Start: Position{line, start}, End: Position{line, end}}
+}
+
+// prefixRunes returns the first n characters of s.
+func prefixRunes(s string, n int) string {
+	i := 0
+	for ; n > 0 && i < len(s); n-- {
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+	return s[:i]
+}
+
+// lineEdit returns a single edit turning old into new, replacing only the
+// lines between their common prefix and suffix.
+func lineEdit(old, new string) TextEdit {
+	a := strings.SplitAfter(old, "\n")
+	b := strings.SplitAfter(new, "\n")
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	end := Position{Line: len(a) - suffix}
+	if suffix == 0 {
+		// The last line changed: end at its last character instead of
+		// after it, which would be past the end of the document.
+		last := a[len(a)-1]
+		end = Position{Line: len(a) - 1, Character: utf16Len(last)}
+		if prefix == len(a) {
+			prefix = len(a) - 1
+		}
+	}
+	return TextEdit{
+		Range:   Range{Start: Position{Line: prefix}, End: end},
+		NewText: strings.Join(b[prefix:len(b)-suffix], ""),
+	}
+}
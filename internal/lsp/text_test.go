@@ -0,0 +1,63 @@
+package lsp
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/biwakonbu/aglx/internal/source"
+)
+
+func TestFindingRange(t *testing.T) {
+	lines := splitLines("---\r\nname: 😀x\r\n---\r\n")
+	tests := []struct {
+		pos  source.Position
+		want Range
+	}{
+		// The emoji is two UTF-16 code units; \r is not part of the line.
+		{source.Position{File: "f", Line: 2, Column: 8}, Range{Position{1, 8}, Position{1, 9}}},
+		{source.Position{File: "f", Line: 2}, Range{Position{1, 0}, Position{1, 9}}},
+		{source.Position{File: "f"}, Range{Position{0, 0}, Position{0, 3}}},
+		{source.Position{File: "f", Line: 9, Column: 1}, Range{Position{0, 0}, Position{0, 3}}},
+	}
+	for _, tt := range tests {
+		if got := findingRange(lines, tt.pos); got != tt.want {
+			t.Errorf("findingRange(%v) = %v, want %v", tt.pos, got, tt.want)
+		}
+	}
+}
+
+func TestByteOffset(t *testing.T) {
+	line := "a😀b"
+	for character, want := range map[int]int{0: 0, 1: 1, 3: 5, 4: 6, 10: 6} {
+		if got := byteOffset(line, character); got != want {
+			t.Errorf("byteOffset(%d) = %d, want %d", character, got, want)
+		}
+	}
+}
+
+func TestLineEdit(t *testing.T) {
+	tests := []struct {
+		old, new string
+		want     TextEdit
+	}{
+		{"a\nb\nc\n", "a\nB\nc\n", TextEdit{Range{Position{1, 0}, Position{2, 0}}, "B\n"}},
+		{"a\nb\n", "a\nx\nb\n", TextEdit{Range{Position{1, 0}, Position{1, 0}}, "x\n"}},
+		{"a\nb", "a\nc", TextEdit{Range{Position{1, 0}, Position{1, 1}}, "c"}},
+		{"a\n", "a\nb", TextEdit{Range{Position{1, 0}, Position{1, 0}}, "b"}},
+	}
+	for _, tt := range tests {
+		if got := lineEdit(tt.old, tt.new); got != tt.want {
+			t.Errorf("lineEdit(%q, %q) = %+v, want %+v", tt.old, tt.new, got, tt.want)
+		}
+	}
+}
+
+func TestURIToPath(t *testing.T) {
+	path, err := uriToPath("file:///tmp/my%20skill/SKILL.md")
+	if err != nil || path != filepath.FromSlash("/tmp/my skill/SKILL.md") {
+		t.Errorf("uriToPath() = %q, %v", path, err)
+	}
+	if _, err := uriToPath("untitled:Untitled-1"); err == nil {
+		t.Error("expected an error for a non-file URI")
+	}
+}
@@ -0,0 +1,177 @@
+// Package mdref finds the local file references of a Markdown document:
+// the destinations of links, images and link reference definitions, and
+// bare paths into known directories such as scripts/extract.py.
+package mdref
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/biwakonbu/aglx/internal/frontmatter"
+)
+
+// Kind is how a reference is written.
+type Kind int
+
+const (
+	// KindLink is a link or image destination: [text](target), ![alt](target)
+	// or a definition [id]: target. It is relative to the document.
+	KindLink Kind = iota + 1
+	// KindPath is a path mentioned in text or code, such as
+	// `scripts/extract.py`. It is relative to the skill directory.
+	KindPath
+)
+
+// Ref is a reference to a local file.
+type Ref struct {
+	Kind Kind
+
+	// Raw is the reference as written, e.g. "references/api.md#errors".
+	Raw string
+
+	// Path is the referenced file: Raw without fragment and query, with
+	// percent-encoding decoded, e.g. "references/api.md".
+	Path string
+
+	// Line and Column locate Raw in the document (1-based, Column in
+	// characters).
+	Line   int
+	Column int
+}
+
+var (
+	// inlineLinkPattern matches [text](destination "title") and images,
+	// capturing the destination, which may be written as <destination>.
+	inlineLinkPattern = regexp.MustCompile(`!?\[[^\]]*\]\(\s*(<[^<>\n]*>|[^\s()<>]*(?:\([^\s()]*\)[^\s()<>]*)*)(?:\s+(?:"[^"]*"|'[^']*'|\([^()]*\)))?\s*\)`)
+
+	// definitionPattern matches a link reference definition, [id]: destination.
+	definitionPattern = regexp.MustCompile(`^ {0,3}\[[^\]]+\]:\s*(<[^<>\n]*>|\S+)`)
+
+	// schemePattern matches the scheme of an absolute URL such as https: or mailto:.
+	schemePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*:`)
+
+	// codeSpanPattern matches an inline code span.
+	codeSpanPattern = regexp.MustCompile("(`+)[^`]+?(`+)")
+)
+
+// Find returns the local references of a Markdown document in order of
+// appearance. dirs are the directories whose bare paths are references
+// ("scripts" finds scripts/, scripts/extract.py and ./scripts/extract.py). Links are
+// not looked for in code, but paths are: a code block that runs
+// scripts/extract.py references it.
+func Find(markdown string, dirs []string) []Ref {
+	paths := pathPattern(dirs)
+	var refs []Ref
+	var fence string
+
+	for i, line := range strings.Split(markdown, "\n") {
+		lineNo := i + 1
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			} else {
+				refs = append(refs, findPaths(paths, line, lineNo, nil)...)
+			}
+			continue
+		}
+		if marker := frontmatter.FenceMarker(trimmed); marker != "" {
+			fence = marker
+			continue
+		}
+
+		// Links outside code spans; paths anywhere but in link syntax, whose
+		// destinations are already references.
+		code := codeSpanPattern.FindAllStringIndex(line, -1)
+		var links [][]int
+		if m := definitionPattern.FindStringSubmatchIndex(line); m != nil {
+			links = append(links, m)
+		}
+		for _, m := range inlineLinkPattern.FindAllStringSubmatchIndex(line, -1) {
+			if !inside(code, m[0]) {
+				links = append(links, m)
+			}
+		}
+		for _, m := range links {
+			if ref, ok := link(line, lineNo, m[2], m[3]); ok {
+				refs = append(refs, ref)
+			}
+		}
+		refs = append(refs, findPaths(paths, line, lineNo, links)...)
+	}
+	return refs
+}
+
+// link returns the reference for the destination line[start:end], if it is
+// local.
+func link(line string, lineNo, start, end int) (Ref, bool) {
+	raw := line[start:end]
+	if strings.HasPrefix(raw, "<") {
+		raw = raw[1 : len(raw)-1]
+		start++
+	}
+	if raw == "" || strings.HasPrefix(raw, "#") || strings.HasPrefix(raw, "//") || schemePattern.MatchString(raw) {
+		return Ref{}, false
+	}
+	p := raw
+	if i := strings.IndexAny(p, "#?"); i >= 0 {
+		p = p[:i]
+	}
+	if decoded, err := url.PathUnescape(p); err == nil {
+		p = decoded
+	}
+	if p == "" {
+		return Ref{}, false
+	}
+	return Ref{Kind: KindLink, Raw: raw, Path: p, Line: lineNo, Column: column(line, start)}, true
+}
+
+// pathPattern matches paths starting with one of dirs, capturing the path.
+// The character before the path must not continue a longer path or URL.
+func pathPattern(dirs []string) *regexp.Regexp {
+	if len(dirs) == 0 {
+		return nil
+	}
+	quoted := make([]string, len(dirs))
+	for i, dir := range dirs {
+		quoted[i] = regexp.QuoteMeta(dir)
+	}
+	return regexp.MustCompile(`(?:^|[^\w./\\~-])((?:\./)?(?:` + strings.Join(quoted, "|") + `)/[\w./-]*)`)
+}
+
+// findPaths returns the bare paths of a line outside the byte ranges of
+// skip.
+func findPaths(paths *regexp.Regexp, line string, lineNo int, skip [][]int) []Ref {
+	if paths == nil {
+		return nil
+	}
+	var refs []Ref
+	for _, m := range paths.FindAllStringSubmatchIndex(line, -1) {
+		start, raw := m[2], line[m[2]:m[3]]
+		if inside(skip, start) {
+			continue
+		}
+		// A sentence may end right after a path.
+		raw = strings.TrimRight(raw, ".")
+		refs = append(refs, Ref{Kind: KindPath, Raw: raw, Path: strings.TrimPrefix(raw, "./"), Line: lineNo, Column: column(line, start)})
+	}
+	return refs
+}
+
+// inside reports whether the byte offset is inside one of the ranges, given
+// as match indexes whose first two elements are the start and end.
+func inside(ranges [][]int, offset int) bool {
+	for _, r := range ranges {
+		if offset >= r[0] && offset < r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// column converts a byte offset in line into a 1-based character column.
+func column(line string, offset int) int {
+	return utf8.RuneCountInString(line[:offset]) + 1
+}
@@ -0,0 +1,66 @@
+package mdref
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var dirs = []string{"scripts", "references", "assets"}
+
+func format(refs []Ref) string {
+	var lines []string
+	for _, r := range refs {
+		kind := "link"
+		if r.Kind == KindPath {
+			kind = "path"
+		}
+		lines = append(lines, fmt.Sprintf("%d:%d %s %s %s", r.Line, r.Column, kind, r.Raw, r.Path))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     []string
+	}{
+		{"inline link", "See [the guide](references/REFERENCE.md) first.", []string{"1:17 link references/REFERENCE.md references/REFERENCE.md"}},
+		{"image and title", `![chart](assets/chart.png "Chart")`, []string{"1:10 link assets/chart.png assets/chart.png"}},
+		{"angle brackets and escapes", "[a](<docs/my file.md>) [b](docs/my%20file.md#usage)", []string{
+			"1:6 link docs/my file.md docs/my file.md",
+			"1:28 link docs/my%20file.md#usage docs/my file.md",
+		}},
+		{"definition", "[guide]: ./references/guide.md", []string{"1:10 link ./references/guide.md ./references/guide.md"}},
+		{"not local", "[a](https://example.com/scripts/x.sh) [b](#usage) [c](mailto:a@example.com) <https://example.com>", nil},
+		{"bare paths", "Run `python scripts/extract.py` on references/api.md.", []string{
+			"1:13 path scripts/extract.py scripts/extract.py",
+			"1:36 path references/api.md references/api.md",
+		}},
+		{"relative bare path", "Use ./assets/template.docx.", []string{"1:5 path ./assets/template.docx assets/template.docx"}},
+		{"directory", "Put scripts in scripts/.", []string{"1:16 path scripts/ scripts/"}},
+		{"not a bare path", "See docs/scripts/a.sh, my-assets/x.png or assets.", nil},
+		{"link text path", "[scripts/run.sh](scripts/run.sh)", []string{"1:18 link scripts/run.sh scripts/run.sh"}},
+		{"code span link", "Write `[x](missing.md)` to link.", nil},
+		{"fenced code", "```bash\n[x](missing.md)\npython scripts/fill.py\n```\n[y](y.md)", []string{
+			"3:8 path scripts/fill.py scripts/fill.py",
+			"5:5 link y.md y.md",
+		}},
+		{"characters", "日本語 [説明](references/説明.md)", []string{"1:10 link references/説明.md references/説明.md"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := format(Find(tt.markdown, dirs))
+			if want := strings.Join(tt.want, "\n"); got != want {
+				t.Errorf("Find(%q) =\n%s\nwant\n%s", tt.markdown, got, want)
+			}
+		})
+	}
+}
+
+func TestFind_NoDirs(t *testing.T) {
+	if refs := Find("Run scripts/a.sh, see [b](b.md).", nil); len(refs) != 1 || refs[0].Path != "b.md" {
+		t.Errorf("expected only the link without dirs, got %v", refs)
+	}
+}
@@ -0,0 +1,71 @@
+// Package parallel runs independent work items on a bounded pool of goroutines.
+package parallel
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// Workers returns n if it is positive, otherwise the number of usable CPUs.
+func Workers(n int) int {
+	if n > 0 {
+		return n
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// Map calls fn for every item on at most Workers(workers) goroutines and
+// returns the results in item order, regardless of completion order.
+//
+// If ctx is cancelled, Map stops starting new calls, waits for the running
+// ones and returns ctx.Err() with no results.
+func Map[T, R any](ctx context.Context, workers int, items []T, fn func(T) R) ([]R, error) {
+	results := make([]R, len(items))
+	workers = min(Workers(workers), len(items))
+
+	if workers <= 1 {
+		for i, item := range items {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			results[i] = fn(item)
+		}
+		return results, nil
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				// Each index is handed out once, so workers never share a slot.
+				results[i] = fn(items[i])
+			}
+		}()
+	}
+
+	var err error
+feed:
+	for i := range items {
+		// Check first: select picks randomly when a worker is also ready.
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			err = ctx.Err()
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
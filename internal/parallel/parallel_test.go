@@ -0,0 +1,81 @@
+package parallel
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMap_PreservesOrder(t *testing.T) {
+	items := make([]int, 200)
+	for i := range items {
+		items[i] = i
+	}
+
+	for _, workers := range []int{0, 1, 3, 500} {
+		// Later items finish first, so completion order is the reverse of item order.
+		results, err := Map(context.Background(), workers, items, func(n int) int {
+			time.Sleep(time.Duration(len(items)-n) * time.Microsecond)
+			return n * n
+		})
+		if err != nil {
+			t.Fatalf("workers=%d: unexpected error: %v", workers, err)
+		}
+		for i, r := range results {
+			if r != i*i {
+				t.Fatalf("workers=%d: results[%d] = %d, want %d", workers, i, r, i*i)
+			}
+		}
+	}
+}
+
+func TestMap_BoundsConcurrency(t *testing.T) {
+	var running, peak atomic.Int32
+	_, err := Map(context.Background(), 4, make([]int, 50), func(int) int {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+		return 0
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := peak.Load(); p > 4 {
+		t.Errorf("peak concurrency = %d, want at most 4", p)
+	}
+}
+
+func TestMap_Cancel(t *testing.T) {
+	for _, workers := range []int{1, 4} {
+		ctx, cancel := context.WithCancel(context.Background())
+		var calls atomic.Int32
+		results, err := Map(ctx, workers, make([]int, 100), func(int) int {
+			if calls.Add(1) == 5 {
+				cancel()
+			}
+			return 1
+		})
+		if !errors.Is(err, context.Canceled) || results != nil {
+			t.Errorf("workers=%d: Map() = %v, %v; want context.Canceled", workers, results, err)
+		}
+		// Only the calls already running when the context was cancelled may complete.
+		if n := calls.Load(); n > int32(5+workers) {
+			t.Errorf("workers=%d: %d calls made after cancellation", workers, n)
+		}
+	}
+}
+
+func TestMap_Empty(t *testing.T) {
+	results, err := Map(context.Background(), 0, []string(nil), func(string) int { return 1 })
+	if err != nil || len(results) != 0 {
+		t.Errorf("Map() = %v, %v", results, err)
+	}
+}
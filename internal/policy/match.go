@@ -0,0 +1,184 @@
+package policy
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/biwakonbu/aglx/internal/glob"
+	"github.com/biwakonbu/aglx/internal/toolspec"
+)
+
+// BreadthOf returns how much of its tool an entry grants.
+func BreadthOf(t *toolspec.Tool) Breadth {
+	if t.Args == nil {
+		return BreadthUnrestricted
+	}
+	switch p := t.Args.Pattern.(type) {
+	case *toolspec.Command:
+		switch {
+		case p.Prefix && strings.TrimSpace(p.Command) == "":
+			return BreadthUnrestricted
+		case p.Prefix:
+			return BreadthPattern
+		}
+		return BreadthExact
+	case *toolspec.PathGlob:
+		glob := normalizeGlob(p.Glob)
+		switch {
+		case isLiteral(glob):
+			return BreadthExact
+		case literalPrefix(glob) == "" || literalPrefix(glob) == "/":
+			return BreadthUnrestricted
+		}
+		return BreadthPattern
+	case *toolspec.Domain:
+		return wildcardBreadth(p.Host)
+	case *toolspec.Opaque:
+		return wildcardBreadth(p.Text)
+	}
+	return BreadthUnrestricted
+}
+
+func wildcardBreadth(s string) Breadth {
+	switch {
+	case strings.TrimSpace(s) == "*":
+		return BreadthUnrestricted
+	case strings.Contains(s, "*"):
+		return BreadthPattern
+	}
+	return BreadthExact
+}
+
+// sameTool reports whether pattern names the tool of entry: the same name,
+// or the MCP server of an MCP tool.
+func sameTool(pattern, entry *toolspec.Tool) bool {
+	if pattern.Name == entry.Name {
+		return true
+	}
+	return pattern.MCP != nil && pattern.MCP.Tool == "" && entry.MCP != nil && pattern.MCP.Server == entry.MCP.Server
+}
+
+// overlaps reports whether the entry grants anything the pattern matches.
+// It errs on the side of overlapping, as it decides what is denied.
+func overlaps(pattern, entry *toolspec.Tool) bool {
+	if !sameTool(pattern, entry) && !sameTool(entry, pattern) {
+		return false
+	}
+	if BreadthOf(pattern) == BreadthUnrestricted || BreadthOf(entry) == BreadthUnrestricted {
+		return true
+	}
+	switch p := pattern.Args.Pattern.(type) {
+	case *toolspec.Command:
+		e, ok := entry.Args.Pattern.(*toolspec.Command)
+		return !ok || commandCovers(p, e) || commandCovers(e, p)
+	case *toolspec.PathGlob:
+		e, ok := entry.Args.Pattern.(*toolspec.PathGlob)
+		return !ok || globsOverlap(normalizeGlob(p.Glob), normalizeGlob(e.Glob))
+	case *toolspec.Domain:
+		e, ok := entry.Args.Pattern.(*toolspec.Domain)
+		return !ok || hostCovers(p.Host, e.Host) || hostCovers(e.Host, p.Host)
+	}
+	return pattern.Args.Raw == entry.Args.Raw
+}
+
+// covers reports whether the pattern grants everything the entry grants.
+// It errs on the side of not covering, as it decides what is allowed.
+func covers(pattern, entry *toolspec.Tool) bool {
+	if !sameTool(pattern, entry) {
+		return false
+	}
+	if BreadthOf(pattern) == BreadthUnrestricted {
+		return true
+	}
+	if BreadthOf(entry) == BreadthUnrestricted {
+		return false
+	}
+	switch p := pattern.Args.Pattern.(type) {
+	case *toolspec.Command:
+		e, ok := entry.Args.Pattern.(*toolspec.Command)
+		return ok && commandCovers(p, e)
+	case *toolspec.PathGlob:
+		e, ok := entry.Args.Pattern.(*toolspec.PathGlob)
+		return ok && globCovers(normalizeGlob(p.Glob), normalizeGlob(e.Glob))
+	case *toolspec.Domain:
+		e, ok := entry.Args.Pattern.(*toolspec.Domain)
+		return ok && hostCovers(p.Host, e.Host)
+	}
+	return pattern.Args.Raw == entry.Args.Raw
+}
+
+// commandCovers reports whether every command matched by e is matched by p.
+// A prefix matches any command starting with it, as in Claude Code.
+func commandCovers(p, e *toolspec.Command) bool {
+	pc, ec := strings.TrimSpace(p.Command), strings.TrimSpace(e.Command)
+	if p.Prefix {
+		return strings.HasPrefix(ec, pc)
+	}
+	return !e.Prefix && ec == pc
+}
+
+// hostCovers reports whether the host pattern p ("example.com" or
+// "*.example.com") matches every host matched by e.
+func hostCovers(p, e string) bool {
+	p, e = strings.ToLower(p), strings.ToLower(e)
+	if p == e || p == "*" {
+		return true
+	}
+	suffix, ok := strings.CutPrefix(p, "*")
+	return ok && strings.HasSuffix(e, suffix) && e != suffix[1:]
+}
+
+// globCovers reports whether every path matched by e is matched by p. Only
+// literal paths and "dir/**" patterns are compared precisely; other pairs of
+// globs cover each other only if they are equal.
+func globCovers(p, e string) bool {
+	if p == e {
+		return true
+	}
+	if isLiteral(e) {
+		return globRegexp(p).MatchString(e)
+	}
+	prefix, ok := strings.CutSuffix(p, "**")
+	return ok && isLiteral(prefix) && strings.HasPrefix(literalPrefix(e), prefix)
+}
+
+// globsOverlap reports whether some path may match both globs, comparing
+// the literal directories they start with when neither is a literal path.
+func globsOverlap(a, b string) bool {
+	switch {
+	case isLiteral(a):
+		return globRegexp(b).MatchString(a)
+	case isLiteral(b):
+		return globRegexp(a).MatchString(b)
+	}
+	pa, pb := literalPrefix(a), literalPrefix(b)
+	return strings.HasPrefix(pa, pb) || strings.HasPrefix(pb, pa)
+}
+
+// normalizeGlob removes a leading "./", which does not change what a glob
+// matches.
+func normalizeGlob(glob string) string {
+	return strings.TrimPrefix(strings.TrimSpace(glob), "./")
+}
+
+func isLiteral(glob string) bool {
+	return !strings.ContainsAny(glob, "*?[")
+}
+
+// literalPrefix returns the part of a glob before its first wildcard.
+func literalPrefix(glob string) string {
+	if i := strings.IndexAny(glob, "*?["); i >= 0 {
+		return glob[:i]
+	}
+	return glob
+}
+
+// globRegexp compiles a gitignore-style glob (see package glob).
+func globRegexp(pattern string) *regexp.Regexp {
+	re, err := glob.Compile(pattern)
+	if err != nil {
+		// Not reachable for globs accepted by toolspec; match nothing.
+		return regexp.MustCompile(`[^\s\S]`)
+	}
+	return re
+}
@@ -0,0 +1,189 @@
+// Package policy checks allowed-tools against a security policy: tool
+// patterns that must not be granted, allowlists, a maximum breadth per tool
+// and a maximum number of tools.
+package policy
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/biwakonbu/aglx/internal/toolspec"
+)
+
+// Breadth is how much of a tool an allowed-tools entry grants.
+type Breadth int
+
+const (
+	// BreadthExact grants one command, path or host: Bash(git status),
+	// Read(src/main.go), WebFetch(domain:example.com).
+	BreadthExact Breadth = iota + 1
+	// BreadthPattern grants a family: Bash(git:*), Read(src/**).
+	BreadthPattern
+	// BreadthUnrestricted grants every use of the tool: Bash, Bash(*), Write(**).
+	BreadthUnrestricted
+)
+
+// breadthNames are the names of the breadths in policy files.
+var breadthNames = map[Breadth]string{
+	BreadthExact:        "exact",
+	BreadthPattern:      "pattern",
+	BreadthUnrestricted: "unrestricted",
+}
+
+func (b Breadth) String() string {
+	if name, ok := breadthNames[b]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ParseBreadth converts "exact", "pattern" or "unrestricted" into a Breadth.
+func ParseBreadth(s string) (Breadth, error) {
+	for b, name := range breadthNames {
+		if name == s {
+			return b, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown breadth %q (expected exact, pattern or unrestricted)", s)
+}
+
+// Policy is a set of rules allowed-tools must satisfy.
+type Policy struct {
+	// Path is the file the policy was loaded from.
+	Path string
+
+	Rules []*Rule
+}
+
+// Rule is one named requirement of a policy. Every set field applies.
+type Rule struct {
+	// ID identifies the rule in violations, e.g. "no-network-commands".
+	ID string
+
+	// Description explains the rule; it is appended to violations.
+	Description string
+
+	// Deny lists patterns no entry may overlap: Bash(rm:*) rejects
+	// Bash(rm -rf:*), Bash(*) and Bash.
+	Deny []*toolspec.Tool
+
+	// Allow lists the only patterns entries of the tools in scope may grant:
+	// with Bash(git:*), Bash(git status) passes and Bash(npm:*) does not.
+	Allow []*toolspec.Tool
+
+	// Tools limits Allow and MaxBreadth to these tool names (or MCP servers
+	// as mcp__server). Without it, Allow applies to the tools it names and
+	// MaxBreadth to every tool.
+	Tools []string
+
+	// MaxBreadth is the broadest grant allowed for a tool in scope (0: no limit).
+	MaxBreadth Breadth
+
+	// MaxTools is the maximum number of entries (0: no limit).
+	MaxTools int
+}
+
+// Violation is an allowed-tools entry (or list) that breaks a policy rule.
+type Violation struct {
+	// Rule is the ID of the policy rule.
+	Rule string
+
+	// Offset is the byte offset in the allowed-tools value of the entry
+	// (0 for violations of the whole list).
+	Offset int
+
+	Message string
+}
+
+// Check returns the violations of the parsed allowed-tools list, rule by
+// rule in policy order.
+func (p *Policy) Check(list *toolspec.List) []Violation {
+	if p == nil {
+		return nil
+	}
+	var violations []Violation
+	for _, r := range p.Rules {
+		violations = append(violations, r.check(list)...)
+	}
+	return violations
+}
+
+func (r *Rule) check(list *toolspec.List) []Violation {
+	var violations []Violation
+	report := func(offset int, format string, args ...interface{}) {
+		msg := fmt.Sprintf(format, args...)
+		if r.Description != "" {
+			msg += ": " + r.Description
+		}
+		violations = append(violations, Violation{Rule: r.ID, Offset: offset, Message: msg})
+	}
+
+	if r.MaxTools > 0 && len(list.Tools) > r.MaxTools {
+		report(0, "allows %d tools (max-tools is %d)", len(list.Tools), r.MaxTools)
+	}
+	for _, t := range list.Tools {
+		if t.Name == "" {
+			continue
+		}
+		if d := firstMatch(r.Deny, t, overlaps); d != nil {
+			report(t.Offset, "%q is denied (matches %q)", t.Raw, d.Raw)
+			continue
+		}
+		if allow := r.allowFor(t); len(allow) > 0 && firstMatch(allow, t, covers) == nil {
+			report(t.Offset, "%q is not allowed (allowed: %s)", t.Raw, names(allow))
+			continue
+		}
+		if r.MaxBreadth > 0 && r.inScope(t) {
+			if b := BreadthOf(t); b > r.MaxBreadth {
+				report(t.Offset, "%q is %s (max-breadth is %s)", t.Raw, b, r.MaxBreadth)
+			}
+		}
+	}
+	return violations
+}
+
+// allowFor returns the allow patterns that apply to an entry: all of them
+// if the entry is in the rule's scope, none if it is not.
+func (r *Rule) allowFor(t *toolspec.Tool) []*toolspec.Tool {
+	if len(r.Tools) > 0 {
+		if r.inScope(t) {
+			return r.Allow
+		}
+		return nil
+	}
+	for _, a := range r.Allow {
+		if sameTool(a, t) || sameTool(t, a) {
+			return r.Allow
+		}
+	}
+	return nil
+}
+
+// inScope reports whether the rule's Tools include the entry's tool.
+func (r *Rule) inScope(t *toolspec.Tool) bool {
+	if len(r.Tools) == 0 {
+		return true
+	}
+	if slices.Contains(r.Tools, t.Name) {
+		return true
+	}
+	return t.MCP != nil && slices.Contains(r.Tools, toolspec.MCPPrefix+t.MCP.Server)
+}
+
+func firstMatch(patterns []*toolspec.Tool, t *toolspec.Tool, match func(pattern, entry *toolspec.Tool) bool) *toolspec.Tool {
+	for _, p := range patterns {
+		if match(p, t) {
+			return p
+		}
+	}
+	return nil
+}
+
+func names(tools []*toolspec.Tool) string {
+	raw := make([]string, len(tools))
+	for i, t := range tools {
+		raw[i] = t.Raw
+	}
+	return strings.Join(raw, ", ")
+}
@@ -0,0 +1,157 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/biwakonbu/aglx/internal/toolspec"
+)
+
+func tools(t *testing.T, value string) []*toolspec.Tool {
+	t.Helper()
+	list := toolspec.Parse(value)
+	if len(list.Errors) > 0 {
+		t.Fatalf("Parse(%q): %v", value, list.Errors)
+	}
+	return list.Tools
+}
+
+// security is the policy of the motivating example: no unrestricted Bash or
+// Write, no destructive or network commands.
+func security(t *testing.T) *Policy {
+	return &Policy{Rules: []*Rule{
+		{ID: "restricted-tools", Tools: []string{"Bash", "Write"}, MaxBreadth: BreadthPattern},
+		{ID: "no-dangerous-commands", Deny: tools(t, "Bash(rm:*) Bash(curl:*)"), Description: "may delete files or leak data"},
+	}}
+}
+
+func TestCheck_Security(t *testing.T) {
+	tests := []struct {
+		tools string
+		want  []string
+	}{
+		{"Read Grep Bash(git status) Bash(npm run test:*) Write(docs/**)", nil},
+		{"Bash", []string{
+			`restricted-tools: "Bash" is unrestricted (max-breadth is pattern)`,
+			`no-dangerous-commands: "Bash" is denied (matches "Bash(rm:*)"): may delete files or leak data`,
+		}},
+		{"Bash(*)", []string{
+			`restricted-tools: "Bash(*)" is unrestricted (max-breadth is pattern)`,
+			`no-dangerous-commands: "Bash(*)" is denied (matches "Bash(rm:*)"): may delete files or leak data`,
+		}},
+		{"Bash(rm:*)", []string{`no-dangerous-commands: "Bash(rm:*)" is denied (matches "Bash(rm:*)"): may delete files or leak data`}},
+		{"Bash(rm -rf build)", []string{`no-dangerous-commands: "Bash(rm -rf build)" is denied (matches "Bash(rm:*)"): may delete files or leak data`}},
+		{"Bash(curl https://example.com:*)", []string{`no-dangerous-commands: "Bash(curl https://example.com:*)" is denied (matches "Bash(curl:*)"): may delete files or leak data`}},
+		{"Write", []string{`restricted-tools: "Write" is unrestricted (max-breadth is pattern)`}},
+		{"Write(**)", []string{`restricted-tools: "Write(**)" is unrestricted (max-breadth is pattern)`}},
+		{"Read(**)", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tools, func(t *testing.T) {
+			var got []string
+			for _, v := range security(t).Check(toolspec.Parse(tt.tools)) {
+				got = append(got, v.Rule+": "+v.Message)
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("violations =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestCheck_Allow(t *testing.T) {
+	p := &Policy{Rules: []*Rule{
+		{ID: "git-only", Allow: tools(t, "Bash(git:*) Read(src/**) WebFetch(domain:*.example.com)")},
+	}}
+	tests := []struct {
+		tools   string
+		allowed bool
+	}{
+		{"Bash(git status)", true},
+		{"Bash(git log:*)", true},
+		{"Bash(npm:*)", false},
+		{"Bash", false},
+		{"Read(src/main.go)", true},
+		{"Read(./src/pkg/*.go)", true},
+		{"Read(docs/**)", false},
+		{"WebFetch(domain:docs.example.com)", true},
+		{"WebFetch(domain:example.org)", false},
+		{"Grep", true}, // not named by the allowlist
+	}
+	for _, tt := range tests {
+		violations := p.Check(toolspec.Parse(tt.tools))
+		if (len(violations) == 0) != tt.allowed {
+			t.Errorf("%s: violations = %v, want allowed = %v", tt.tools, violations, tt.allowed)
+		}
+	}
+
+	scoped := &Policy{Rules: []*Rule{{ID: "read-only", Tools: []string{"Read", "Grep", "Bash"}, Allow: tools(t, "Read Grep")}}}
+	if v := scoped.Check(toolspec.Parse("Read Grep Bash(ls)")); len(v) != 1 || v[0].Offset != 10 {
+		t.Errorf("expected Bash(ls) to break a scoped allowlist, got %v", v)
+	}
+}
+
+func TestCheck_MCPAndMaxTools(t *testing.T) {
+	p := &Policy{Rules: []*Rule{
+		{ID: "no-slack", Deny: tools(t, "mcp__slack")},
+		{ID: "small", MaxTools: 2},
+	}}
+	var got []string
+	for _, v := range p.Check(toolspec.Parse("mcp__slack__post_message mcp__github Read")) {
+		got = append(got, v.Rule+": "+v.Message)
+	}
+	want := []string{
+		`no-slack: "mcp__slack__post_message" is denied (matches "mcp__slack")`,
+		"small: allows 3 tools (max-tools is 2)",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("violations =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestBreadthOf(t *testing.T) {
+	tests := []struct {
+		tool string
+		want Breadth
+	}{
+		{"Bash", BreadthUnrestricted},
+		{"Bash(*)", BreadthUnrestricted},
+		{"Bash(:*)", BreadthUnrestricted},
+		{"Bash(git:*)", BreadthPattern},
+		{"Bash(git status)", BreadthExact},
+		{"Write(**)", BreadthUnrestricted},
+		{"Write(/**)", BreadthUnrestricted},
+		{"Write(./**)", BreadthUnrestricted},
+		{"Write(src/**)", BreadthPattern},
+		{"Write(README.md)", BreadthExact},
+		{"WebFetch(domain:example.com)", BreadthExact},
+		{"WebFetch(domain:*.example.com)", BreadthPattern},
+		{"Task(*)", BreadthUnrestricted},
+		{"mcp__github", BreadthUnrestricted},
+	}
+	for _, tt := range tests {
+		if got := BreadthOf(toolspec.Parse(tt.tool).Tools[0]); got != tt.want {
+			t.Errorf("BreadthOf(%s) = %s, want %s", tt.tool, got, tt.want)
+		}
+	}
+}
+
+func TestGlobsOverlap(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"src/**", "src/main.go", true},
+		{"src/*.go", "src/pkg/main.go", false},
+		{"src/**", "docs/**", false},
+		{"src/**", "src/pkg/*.go", true},
+		{".env", "*", true},
+		{"secrets/[a-c].txt", "secrets/b.txt", true},
+		{"**/*.env", ".env", true},
+	}
+	for _, tt := range tests {
+		if got := globsOverlap(tt.a, tt.b); got != tt.want {
+			t.Errorf("globsOverlap(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package report
+
+import (
+	"encoding/xml"
+	"io"
+	"path/filepath"
+
+	"github.com/biwakonbu/aglx/internal/checker"
+)
+
+// checkstyleVersion is the Checkstyle report format version understood by most CI plugins.
+const checkstyleVersion = "4.3"
+
+// Checkstyle XML object model.
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// WriteCheckstyle renders results as Checkstyle XML. Every skill directory
+// contributes a <file> entry for its SKILL.md (empty when it passes);
+// findings located in other files (CLAUDE.md, resource directories) get
+// their own <file> entries. Files appear in the order they are first seen.
+func WriteCheckstyle(w io.Writer, results []*checker.Result) error {
+	out := checkstyleReport{Version: checkstyleVersion}
+	index := make(map[string]int)
+
+	fileEntry := func(name string) *checkstyleFile {
+		i, ok := index[name]
+		if !ok {
+			i = len(out.Files)
+			index[name] = i
+			out.Files = append(out.Files, checkstyleFile{Name: name})
+		}
+		return &out.Files[i]
+	}
+
+	for _, r := range results {
+		fileEntry(filepath.Join(r.Path, "SKILL.md"))
+
+		for _, f := range Findings(r) {
+			entry := fileEntry(findingFile(r, f))
+			entry.Errors = append(entry.Errors, checkstyleError{
+				Line:     f.Pos.Line,
+				Column:   f.Pos.Column,
+				Severity: string(f.Level),
+				Message:  "[" + string(f.Origin) + "] " + f.Field + ": " + f.Message,
+				Source:   toolName + "." + f.RuleID,
+			})
+		}
+	}
+
+	return writeXML(w, out)
+}
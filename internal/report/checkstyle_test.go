@@ -0,0 +1,60 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/biwakonbu/aglx/internal/checker"
+	"github.com/biwakonbu/aglx/internal/skill"
+)
+
+func TestWriteCheckstyle(t *testing.T) {
+	results := checker.CheckMultipleWithOptions([]string{
+		"../../testdata/valid/simple-skill",
+		"../../testdata/invalid/uppercase-name",
+		"../../testdata/valid/with-hidden-files",
+	}, &checker.CheckOptions{Spec: skill.SpecAgentSkills})
+
+	var buf bytes.Buffer
+	if err := WriteCheckstyle(&buf, results); err != nil {
+		t.Fatalf("WriteCheckstyle() error = %v", err)
+	}
+
+	var out checkstyleReport
+	if err := xml.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid Checkstyle XML: %v\n%s", err, buf.String())
+	}
+
+	wantFiles := []string{
+		"../../testdata/valid/simple-skill/SKILL.md",
+		"../../testdata/invalid/uppercase-name/SKILL.md",
+		"../../testdata/valid/with-hidden-files/SKILL.md",
+		"../../testdata/valid/with-hidden-files/scripts/.hidden",
+	}
+	if len(out.Files) != len(wantFiles) {
+		t.Fatalf("expected %d files, got %+v", len(wantFiles), out.Files)
+	}
+	for i, want := range wantFiles {
+		if out.Files[i].Name != want {
+			t.Errorf("file %d = %q, want %q", i, out.Files[i].Name, want)
+		}
+	}
+
+	if len(out.Files[0].Errors) != 0 {
+		t.Errorf("expected no errors for valid skill, got %+v", out.Files[0].Errors)
+	}
+
+	errs := out.Files[1].Errors
+	if len(errs) != 3 {
+		t.Fatalf("expected 3 errors, got %+v", errs)
+	}
+	if errs[0].Line != 2 || errs[0].Column != 7 || errs[0].Severity != "error" || errs[0].Source != "aglx.name-format" {
+		t.Errorf("unexpected error entry: %+v", errs[0])
+	}
+
+	hidden := out.Files[3].Errors
+	if len(hidden) != 1 || hidden[0].Severity != "warning" || hidden[0].Line != 0 {
+		t.Errorf("unexpected hidden file entry: %+v", hidden)
+	}
+}
@@ -0,0 +1,195 @@
+// Package report renders checker results in machine-readable formats for CI systems.
+package report
+
+import (
+	"path/filepath"
+
+	"github.com/biwakonbu/aglx/internal/checker"
+	"github.com/biwakonbu/aglx/internal/claude"
+	"github.com/biwakonbu/aglx/internal/skill"
+	"github.com/biwakonbu/aglx/internal/source"
+)
+
+// Level is the severity of a finding.
+type Level string
+
+const (
+	LevelError   Level = "error"
+	LevelWarning Level = "warning"
+)
+
+// Origin identifies which validation produced a finding.
+type Origin string
+
+const (
+	// OriginSkill is used for SKILL.md parse failures, which precede spec validation.
+	OriginSkill       Origin = "skill"
+	OriginAgentSkills Origin = Origin(skill.SpecAgentSkills)
+	OriginClaudeCode  Origin = Origin(skill.SpecClaudeCode)
+	OriginClaudeMd    Origin = "claude-md"
+)
+
+// parseField is the field reported for files that could not be parsed.
+const parseField = "parse"
+
+// Rule IDs for parse failures, which are not part of any rule registry.
+const (
+	RuleParseError         = "parse-error"
+	RuleClaudeMdParseError = "claude-md-parse-error"
+)
+
+// Finding is a single error or warning flattened from a checker.Result.
+type Finding struct {
+	Origin  Origin
+	RuleID  string
+	Level   Level
+	Field   string
+	Message string
+	Pos     source.Position
+}
+
+// Findings flattens a result into findings in a stable order:
+// parse errors, Agent Skills, Claude Code, then CLAUDE.md.
+func Findings(r *checker.Result) []Finding {
+	var findings []Finding
+
+	if r.ParseError != nil {
+		findings = append(findings, Finding{
+			Origin:  OriginSkill,
+			RuleID:  RuleParseError,
+			Level:   LevelError,
+			Field:   parseField,
+			Message: r.ParseError.Error(),
+			Pos:     source.Position{File: filepath.Join(r.Path, "SKILL.md")},
+		})
+	}
+
+	findings = append(findings, specFindings(OriginAgentSkills, r.AgentSkillsResult)...)
+	findings = append(findings, specFindings(OriginClaudeCode, r.ClaudeCodeResult)...)
+
+	if r.ClaudeMdError != nil {
+		findings = append(findings, Finding{
+			Origin:  OriginClaudeMd,
+			RuleID:  RuleClaudeMdParseError,
+			Level:   LevelError,
+			Field:   parseField,
+			Message: r.ClaudeMdError.Error(),
+			Pos:     source.Position{File: r.Path},
+		})
+	}
+	if r.ClaudeMdResult != nil {
+		for _, w := range r.ClaudeMdResult.Warnings {
+			findings = append(findings, Finding{
+				Origin:  OriginClaudeMd,
+				RuleID:  w.Rule,
+				Level:   LevelWarning,
+				Field:   w.Field,
+				Message: w.Message,
+				Pos:     w.Pos,
+			})
+		}
+	}
+
+	return findings
+}
+
+// IsParseError reports whether the finding is a file that could not be parsed.
+func (f Finding) IsParseError() bool {
+	return f.Field == parseField
+}
+
+func specFindings(origin Origin, sr *checker.SpecResult) []Finding {
+	if sr == nil || sr.ValidationResult == nil {
+		return nil
+	}
+
+	var findings []Finding
+	add := func(level Level, errs []skill.ValidationError) {
+		for _, e := range errs {
+			findings = append(findings, Finding{
+				Origin:  origin,
+				RuleID:  e.Rule,
+				Level:   level,
+				Field:   e.Field,
+				Message: e.Message,
+				Pos:     e.Pos,
+			})
+		}
+	}
+	add(LevelError, sr.ValidationResult.Errors)
+	add(LevelWarning, sr.ValidationResult.Warnings)
+	return findings
+}
+
+// specOrigins returns the SKILL.md origins validated for a spec selection.
+func specOrigins(spec skill.Spec) []Origin {
+	switch spec {
+	case skill.SpecAgentSkills:
+		return []Origin{OriginAgentSkills}
+	case skill.SpecClaudeCode:
+		return []Origin{OriginClaudeCode}
+	default:
+		return []Origin{OriginAgentSkills, OriginClaudeCode}
+	}
+}
+
+// originApplies reports whether a validation was requested for a result.
+func originApplies(r *checker.Result, origin Origin) bool {
+	if origin == OriginClaudeMd {
+		return true
+	}
+	for _, o := range specOrigins(r.Spec) {
+		if o == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// findingBelongsTo reports whether a finding is part of the validation for origin.
+// SKILL.md parse errors belong to every SKILL.md validation.
+func findingBelongsTo(f Finding, origin Origin) bool {
+	if f.Origin == OriginSkill {
+		return origin != OriginClaudeMd
+	}
+	return f.Origin == origin
+}
+
+// defaultLevel returns the level a rule reports at by default,
+// falling back to the observed level for rules outside the registry.
+func defaultLevel(id string, observed Level) Level {
+	if rule, ok := skill.LookupRule(id); ok {
+		switch rule.DefaultSeverity {
+		case skill.SeverityError:
+			return LevelError
+		case skill.SeverityWarning:
+			return LevelWarning
+		}
+	}
+	return observed
+}
+
+// ruleDescription returns the one-line description of a rule ID.
+func ruleDescription(id string) string {
+	if rule, ok := skill.LookupRule(id); ok {
+		return rule.Description
+	}
+	if desc, ok := claude.RuleDescriptions[id]; ok {
+		return desc
+	}
+	switch id {
+	case RuleParseError:
+		return "SKILL.md must exist and contain valid YAML frontmatter"
+	case RuleClaudeMdParseError:
+		return "CLAUDE.md must be readable"
+	}
+	return id
+}
+
+// findingFile returns the file a finding belongs to, falling back to the skill directory.
+func findingFile(r *checker.Result, f Finding) string {
+	if f.Pos.IsValid() {
+		return f.Pos.File
+	}
+	return r.Path
+}
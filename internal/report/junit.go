@@ -0,0 +1,125 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/biwakonbu/aglx/internal/checker"
+)
+
+// JUnit XML object model (the de-facto schema understood by Jenkins, GitLab and GitHub Actions).
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string         `xml:"name,attr"`
+	ClassName string         `xml:"classname,attr"`
+	Failures  []junitProblem `xml:"failure"`
+	Errors    []junitProblem `xml:"error"`
+	SystemOut string         `xml:"system-out,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit renders results as JUnit XML. There is one test suite per
+// validation (Agent Skills, Claude Code, CLAUDE.md) and one test case per
+// skill directory. Validation errors become <failure> entries, parse errors
+// become <error> entries and warnings are listed in <system-out>.
+func WriteJUnit(w io.Writer, results []*checker.Result) error {
+	suites := junitTestSuites{Name: toolName}
+
+	for _, origin := range []Origin{OriginAgentSkills, OriginClaudeCode, OriginClaudeMd} {
+		suite := junitTestSuite{Name: string(origin)}
+
+		for _, r := range results {
+			if !originApplies(r, origin) {
+				continue
+			}
+			tc := junitTestCase{Name: r.Path, ClassName: toolName + "." + string(origin)}
+
+			var warnings []string
+			for _, f := range Findings(r) {
+				if !findingBelongsTo(f, origin) {
+					continue
+				}
+				problem := junitProblem{
+					Message: f.Field + ": " + f.Message,
+					Type:    f.RuleID,
+					Text:    formatFinding(r, f),
+				}
+				switch {
+				case f.IsParseError():
+					tc.Errors = append(tc.Errors, problem)
+				case f.Level == LevelError:
+					tc.Failures = append(tc.Failures, problem)
+				default:
+					warnings = append(warnings, "warning: "+formatFinding(r, f))
+				}
+			}
+			if len(warnings) > 0 {
+				tc.SystemOut = strings.Join(warnings, "\n")
+			}
+
+			suite.Tests++
+			if len(tc.Errors) > 0 {
+				suite.Errors++
+			} else if len(tc.Failures) > 0 {
+				suite.Failures++
+			}
+			suite.TestCases = append(suite.TestCases, tc)
+		}
+
+		if suite.Tests == 0 {
+			continue
+		}
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Errors += suite.Errors
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	return writeXML(w, suites)
+}
+
+// formatFinding renders a finding as "location: field: message".
+func formatFinding(r *checker.Result, f Finding) string {
+	location := findingFile(r, f)
+	if f.Pos.IsValid() {
+		location = f.Pos.String()
+	}
+	return fmt.Sprintf("%s: %s: %s", location, f.Field, f.Message)
+}
+
+// writeXML writes v as an indented XML document with a trailing newline.
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to marshal XML: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
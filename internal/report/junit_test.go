@@ -0,0 +1,69 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/biwakonbu/aglx/internal/checker"
+	"github.com/biwakonbu/aglx/internal/skill"
+)
+
+func TestWriteJUnit(t *testing.T) {
+	results := checker.CheckMultipleWithOptions([]string{
+		"../../testdata/valid/simple-skill",
+		"../../testdata/invalid/hyphen-end",
+		"../../testdata/invalid/invalid-yaml",
+		"../../testdata/valid/with-hidden-files",
+	}, &checker.CheckOptions{Spec: skill.SpecAgentSkills})
+
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, results); err != nil {
+		t.Fatalf("WriteJUnit() error = %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "<?xml") {
+		t.Errorf("expected XML header, got:\n%s", buf.String())
+	}
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatalf("invalid JUnit XML: %v\n%s", err, buf.String())
+	}
+
+	if len(suites.Suites) != 2 {
+		t.Fatalf("expected agent-skills and claude-md suites, got %d", len(suites.Suites))
+	}
+	suite := suites.Suites[0]
+	if suite.Name != "agent-skills" || suite.Tests != 4 || suite.Failures != 1 || suite.Errors != 1 {
+		t.Errorf("unexpected suite counts: %+v", suite)
+	}
+	if suites.Tests != 8 || suites.Failures != 1 || suites.Errors != 1 {
+		t.Errorf("unexpected totals: tests=%d failures=%d errors=%d", suites.Tests, suites.Failures, suites.Errors)
+	}
+
+	cases := map[string]junitTestCase{}
+	for _, tc := range suite.TestCases {
+		cases[tc.Name] = tc
+	}
+
+	if tc := cases["../../testdata/valid/simple-skill"]; len(tc.Failures)+len(tc.Errors) != 0 {
+		t.Errorf("expected passing test case, got %+v", tc)
+	}
+
+	tc := cases["../../testdata/invalid/hyphen-end"]
+	if len(tc.Failures) != 2 {
+		t.Fatalf("expected 2 failures, got %+v", tc.Failures)
+	}
+	if tc.Failures[0].Type != "name-format" || !strings.Contains(tc.Failures[0].Text, "SKILL.md:2:7") {
+		t.Errorf("unexpected failure: %+v", tc.Failures[0])
+	}
+
+	if tc := cases["../../testdata/invalid/invalid-yaml"]; len(tc.Errors) != 1 || tc.Errors[0].Type != "parse-error" {
+		t.Errorf("expected parse error entry, got %+v", tc)
+	}
+
+	if tc := cases["../../testdata/valid/with-hidden-files"]; !strings.Contains(tc.SystemOut, "warning:") || len(tc.Failures) != 0 {
+		t.Errorf("expected warning in system-out only, got %+v", tc)
+	}
+}
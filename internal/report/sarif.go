@@ -0,0 +1,202 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/biwakonbu/aglx/internal/checker"
+)
+
+const (
+	sarifVersion  = "2.1.0"
+	sarifSchema   = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName      = "aglx"
+	toolInfoURI   = "https://github.com/biwakonbu/aglx"
+	sarifCategory = "aglx/"
+)
+
+// ToolInfo describes the tool producing a report.
+type ToolInfo struct {
+	Version string
+}
+
+// SARIF 2.1.0 object model (the subset aglx emits).
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool              sarifTool              `json:"tool"`
+	AutomationDetails sarifAutomationDetails `json:"automationDetails"`
+	Results           []sarifResult          `json:"results"`
+}
+
+type sarifAutomationDetails struct {
+	ID string `json:"id"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// WriteSARIF renders results as a SARIF 2.1.0 log. Each validation
+// (Agent Skills, Claude Code and CLAUDE.md) becomes its own run so that
+// dashboards can track them as separate categories. SKILL.md parse errors
+// are reported in every SKILL.md run.
+func WriteSARIF(w io.Writer, results []*checker.Result, info ToolInfo) error {
+	log := sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{}}
+
+	for _, origin := range []Origin{OriginAgentSkills, OriginClaudeCode, OriginClaudeMd} {
+		active := false
+		var findings []sarifFinding
+		for _, r := range results {
+			if !originApplies(r, origin) {
+				continue
+			}
+			active = true
+			for _, f := range Findings(r) {
+				if findingBelongsTo(f, origin) {
+					findings = append(findings, sarifFinding{Finding: f, file: findingFile(r, f)})
+				}
+			}
+		}
+		if active {
+			log.Runs = append(log.Runs, newSARIFRun(origin, findings, info))
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+type sarifFinding struct {
+	Finding
+	file string
+}
+
+func newSARIFRun(origin Origin, findings []sarifFinding, info ToolInfo) sarifRun {
+	// Rules are listed once per run, sorted by ID for stable indices.
+	ruleLevels := make(map[string]Level)
+	for _, f := range findings {
+		if ruleLevels[f.RuleID] != LevelError {
+			ruleLevels[f.RuleID] = f.Level
+		}
+	}
+	ids := make([]string, 0, len(ruleLevels))
+	for id := range ruleLevels {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           toolName,
+			Version:        info.Version,
+			InformationURI: toolInfoURI,
+			Rules:          make([]sarifRule, 0, len(ids)),
+		}},
+		AutomationDetails: sarifAutomationDetails{ID: sarifCategory + string(origin) + "/"},
+		Results:           make([]sarifResult, 0, len(findings)),
+	}
+
+	index := make(map[string]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:                   id,
+			ShortDescription:     sarifMessage{Text: ruleDescription(id)},
+			DefaultConfiguration: sarifConfiguration{Level: string(defaultLevel(id, ruleLevels[id]))},
+		})
+	}
+
+	for _, f := range findings {
+		loc := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: toURI(f.file)},
+		}}
+		if f.Pos.Line > 0 {
+			loc.PhysicalLocation.Region = &sarifRegion{StartLine: f.Pos.Line, StartColumn: f.Pos.Column}
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    f.RuleID,
+			RuleIndex: index[f.RuleID],
+			Level:     string(f.Level),
+			Message:   sarifMessage{Text: f.Field + ": " + f.Message},
+			Locations: []sarifLocation{loc},
+		})
+	}
+
+	return run
+}
+
+// toURI converts a file path into a SARIF artifact URI. Relative paths stay
+// relative (resolved against the analysis root); absolute paths become file URIs.
+func toURI(path string) string {
+	slashed := filepath.ToSlash(path)
+	segments := strings.Split(slashed, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	escaped := strings.Join(segments, "/")
+
+	if filepath.IsAbs(path) {
+		if !strings.HasPrefix(escaped, "/") {
+			escaped = "/" + escaped // Windows drive letters
+		}
+		return "file://" + escaped
+	}
+	return strings.TrimPrefix(escaped, "./")
+}
@@ -0,0 +1,110 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/biwakonbu/aglx/internal/checker"
+	"github.com/biwakonbu/aglx/internal/skill"
+)
+
+func writeAndDecodeSARIF(t *testing.T, results []*checker.Result) sarifLog {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, results, ToolInfo{Version: "1.2.3"}); err != nil {
+		t.Fatalf("WriteSARIF() error = %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("invalid SARIF JSON: %v\n%s", err, buf.String())
+	}
+	return log
+}
+
+func TestWriteSARIF(t *testing.T) {
+	results := checker.CheckMultiple([]string{
+		"../../testdata/valid/simple-skill",
+		"../../testdata/invalid/uppercase-name",
+		"../../testdata/valid/with-hidden-files",
+	})
+	log := writeAndDecodeSARIF(t, results)
+
+	if log.Version != "2.1.0" || log.Schema == "" {
+		t.Errorf("unexpected header: version=%q schema=%q", log.Version, log.Schema)
+	}
+	if len(log.Runs) != 3 {
+		t.Fatalf("expected 3 runs (agent-skills, claude-code, claude-md), got %d", len(log.Runs))
+	}
+
+	run := log.Runs[0]
+	if run.AutomationDetails.ID != "aglx/agent-skills/" {
+		t.Errorf("unexpected automation id %q", run.AutomationDetails.ID)
+	}
+	if run.Tool.Driver.Name != "aglx" || run.Tool.Driver.Version != "1.2.3" {
+		t.Errorf("unexpected driver: %+v", run.Tool.Driver)
+	}
+
+	var sawError, sawWarning bool
+	for _, res := range run.Results {
+		rule := run.Tool.Driver.Rules[res.RuleIndex]
+		if rule.ID != res.RuleID {
+			t.Errorf("ruleIndex %d points to %q, want %q", res.RuleIndex, rule.ID, res.RuleID)
+		}
+		loc := res.Locations[0].PhysicalLocation
+		switch res.Level {
+		case "error":
+			sawError = true
+			if res.RuleID != "name-format" && res.RuleID != "name-dir-match" {
+				t.Errorf("unexpected error rule %q", res.RuleID)
+			}
+			if loc.ArtifactLocation.URI != "../../testdata/invalid/uppercase-name/SKILL.md" {
+				t.Errorf("unexpected URI %q", loc.ArtifactLocation.URI)
+			}
+			if loc.Region == nil || loc.Region.StartLine != 2 || loc.Region.StartColumn != 7 {
+				t.Errorf("unexpected region %+v", loc.Region)
+			}
+		case "warning":
+			sawWarning = true
+			if res.RuleID != "hidden-files" {
+				t.Errorf("unexpected warning rule %q", res.RuleID)
+			}
+			if loc.Region != nil {
+				t.Errorf("expected no region for file-level finding, got %+v", loc.Region)
+			}
+		}
+	}
+	if !sawError || !sawWarning {
+		t.Errorf("expected both errors and warnings, got %+v", run.Results)
+	}
+}
+
+func TestWriteSARIF_SpecSelectionAndParseErrors(t *testing.T) {
+	results := checker.CheckMultipleWithOptions([]string{
+		"../../testdata/invalid/invalid-yaml",
+	}, &checker.CheckOptions{Spec: skill.SpecClaudeCode})
+	log := writeAndDecodeSARIF(t, results)
+
+	if len(log.Runs) != 2 {
+		t.Fatalf("expected claude-code and claude-md runs, got %d", len(log.Runs))
+	}
+	if log.Runs[0].AutomationDetails.ID != "aglx/claude-code/" {
+		t.Errorf("unexpected first run %q", log.Runs[0].AutomationDetails.ID)
+	}
+	if len(log.Runs[0].Results) != 1 || log.Runs[0].Results[0].RuleID != "parse-error" {
+		t.Errorf("expected parse error result, got %+v", log.Runs[0].Results)
+	}
+}
+
+func TestToURI(t *testing.T) {
+	tests := map[string]string{
+		"./skills/a b/SKILL.md": "skills/a%20b/SKILL.md",
+		"../x/SKILL.md":         "../x/SKILL.md",
+		"/abs/path/SKILL.md":    "file:///abs/path/SKILL.md",
+	}
+	for in, want := range tests {
+		if got := toURI(in); got != want {
+			t.Errorf("toURI(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
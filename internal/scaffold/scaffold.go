@@ -0,0 +1,253 @@
+// Package scaffold creates new skill directories from templates (the target of `aglx init`).
+//
+// A template is a directory laid out like a skill. Files ending in ".tmpl"
+// are rendered with text/template and written without the suffix; other files
+// are copied verbatim. Files under the optional directories (scripts/,
+// references/, assets/) are only created when that directory is requested.
+package scaffold
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/biwakonbu/aglx/internal/skill"
+)
+
+const (
+	templateSuffix = ".tmpl"
+	skillFileName  = "SKILL.md"
+
+	// DefaultDescription is written when no description is given. It is a
+	// valid description so that a fresh skill passes validation.
+	DefaultDescription = "TODO: describe what this skill does and when to use it."
+)
+
+//go:embed templates/default
+var builtin embed.FS
+
+// ErrExists is returned when the skill directory already exists.
+var ErrExists = errors.New("already exists")
+
+// NameError reports a skill name rejected by the name rules.
+type NameError struct {
+	Name     string
+	Problems []skill.ValidationError
+}
+
+func (e *NameError) Error() string {
+	msgs := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		msgs[i] = fmt.Sprintf("%s [%s]", p.Message, p.Rule)
+	}
+	return fmt.Sprintf("invalid skill name %q: %s", e.Name, strings.Join(msgs, "; "))
+}
+
+// Options configures a new skill.
+type Options struct {
+	// Name is the skill name, also used as the directory name.
+	Name string
+
+	// Description is the frontmatter description (DefaultDescription if empty).
+	Description string
+
+	// Spec selects the frontmatter layout and the name rules that apply.
+	Spec skill.Spec
+
+	// Dirs lists the optional directories to create (see skill.OptionalDirs).
+	Dirs []string
+
+	// TemplateDir is a user template directory; "" uses the built-in template.
+	TemplateDir string
+}
+
+// Data is the value templates are executed with.
+type Data struct {
+	Name        string
+	Title       string
+	Description string
+	Spec        skill.Spec
+	Dirs        []string
+}
+
+// Has reports whether the optional directory dir is being created.
+func (d Data) Has(dir string) bool {
+	return slices.Contains(d.Dirs, dir)
+}
+
+// Create writes a new skill named opts.Name under parent and returns the
+// skill directory and the created files, relative to it, in creation order.
+func Create(parent string, opts *Options) (string, []string, error) {
+	if problems := skill.ValidateName(opts.Name, opts.Spec); len(problems) > 0 {
+		return "", nil, &NameError{Name: opts.Name, Problems: problems}
+	}
+	for _, dir := range opts.Dirs {
+		if !slices.Contains(skill.OptionalDirs, dir) {
+			return "", nil, fmt.Errorf("unknown optional directory %q (expected %s)", dir, strings.Join(skill.OptionalDirs, ", "))
+		}
+	}
+
+	tmpl, err := templateFS(opts.TemplateDir)
+	if err != nil {
+		return "", nil, err
+	}
+	data := Data{
+		Name:        opts.Name,
+		Title:       title(opts.Name),
+		Description: opts.Description,
+		Spec:        opts.Spec,
+		Dirs:        opts.Dirs,
+	}
+	if data.Description == "" {
+		data.Description = DefaultDescription
+	}
+	files, err := render(tmpl, data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	dir := filepath.Join(parent, opts.Name)
+	if _, err := os.Lstat(dir); err == nil {
+		return "", nil, fmt.Errorf("%s %w", dir, ErrExists)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	var created []string
+	for _, f := range files {
+		target := filepath.Join(dir, filepath.FromSlash(f.path))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err == nil {
+			err = os.WriteFile(target, f.content, f.mode)
+		}
+		if err != nil {
+			os.RemoveAll(dir)
+			return "", nil, fmt.Errorf("failed to write %s: %w", target, err)
+		}
+		created = append(created, f.path)
+	}
+	return dir, created, nil
+}
+
+// templateFS returns the template to render: dir, or the built-in one.
+func templateFS(dir string) (fs.FS, error) {
+	if dir == "" {
+		return fs.Sub(builtin, "templates/default")
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("template: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("template %s is not a directory", dir)
+	}
+	return os.DirFS(dir), nil
+}
+
+// file is a rendered template file.
+type file struct {
+	path    string // slash-separated, relative to the skill directory
+	content []byte
+	mode    fs.FileMode
+}
+
+// render executes every file of the template in lexical order.
+func render(tmpl fs.FS, data Data) ([]file, error) {
+	var files []file
+	err := fs.WalkDir(tmpl, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == "." {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			// Hidden files (e.g. .DS_Store, .git) never belong in a skill.
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		top, _, _ := strings.Cut(p, "/")
+		if slices.Contains(skill.OptionalDirs, top) && !data.Has(top) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		content, err := fs.ReadFile(tmpl, p)
+		if err != nil {
+			return err
+		}
+		// Scripts and files that are executable in the template stay executable.
+		mode := fs.FileMode(0644)
+		if info, err := d.Info(); top == "scripts" || (err == nil && info.Mode()&0111 != 0) {
+			mode = 0755
+		}
+
+		if name, ok := strings.CutSuffix(p, templateSuffix); ok {
+			content, err = execute(p, content, data)
+			if err != nil {
+				return err
+			}
+			p = name
+		}
+		files = append(files, file{path: p, content: content, mode: mode})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("template: %w", err)
+	}
+
+	if !slices.ContainsFunc(files, func(f file) bool { return f.path == skillFileName }) {
+		return nil, fmt.Errorf("template has no %s or %s%s", skillFileName, skillFileName, templateSuffix)
+	}
+	return files, nil
+}
+
+// funcs are the functions available to templates.
+var funcs = template.FuncMap{
+	// yaml quotes a string for use as a YAML scalar when needed.
+	"yaml": func(s string) (string, error) {
+		out, err := yaml.Marshal(s)
+		return strings.TrimSuffix(string(out), "\n"), err
+	},
+}
+
+func execute(name string, content []byte, data Data) ([]byte, error) {
+	t, err := template.New(path.Base(name)).Funcs(funcs).Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return nil, err
+	}
+	var sb strings.Builder
+	if err := t.Execute(&sb, data); err != nil {
+		return nil, err
+	}
+	return []byte(sb.String()), nil
+}
+
+// title turns a skill name into a heading, e.g. "pdf-processing" -> "Pdf Processing".
+func title(name string) string {
+	words := strings.Split(name, "-")
+	for i, w := range words {
+		if r, size := utf8.DecodeRuneInString(w); size > 0 {
+			words[i] = string(unicode.ToUpper(r)) + w[size:]
+		}
+	}
+	return strings.Join(words, " ")
+}
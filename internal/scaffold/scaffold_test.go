@@ -0,0 +1,187 @@
+package scaffold
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/biwakonbu/aglx/internal/skill"
+)
+
+func TestCreate_BuiltinPassesValidation(t *testing.T) {
+	tests := []struct {
+		spec skill.Spec
+		want string
+	}{
+		{skill.SpecAuto, "---\nname: pdf-tools\ndescription: 'TODO: describe what this skill does and when to use it.'\n---\n"},
+		{skill.SpecAgentSkills, "# allowed-tools: Read Grep Glob\n---\n"},
+		{skill.SpecClaudeCode, "# allowed-tools: Read, Grep, Glob\n---\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.spec), func(t *testing.T) {
+			parent := t.TempDir()
+			dir, files, err := Create(parent, &Options{Name: "pdf-tools", Spec: tt.spec, Dirs: skill.OptionalDirs})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if dir != filepath.Join(parent, "pdf-tools") {
+				t.Errorf("dir = %q", dir)
+			}
+			wantFiles := []string{"SKILL.md", "assets/README.md", "references/REFERENCE.md", "scripts/example.sh"}
+			if strings.Join(files, ",") != strings.Join(wantFiles, ",") {
+				t.Errorf("files = %v, want %v", files, wantFiles)
+			}
+
+			content, err := os.ReadFile(filepath.Join(dir, "SKILL.md"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(content), tt.want) {
+				t.Errorf("SKILL.md does not contain %q:\n%s", tt.want, content)
+			}
+			if !strings.Contains(string(content), "# Pdf Tools\n") || !strings.Contains(string(content), "references/REFERENCE.md") {
+				t.Errorf("unexpected body:\n%s", content)
+			}
+
+			parsed, err := skill.Parse(dir)
+			if err != nil {
+				t.Fatalf("generated SKILL.md does not parse: %v", err)
+			}
+			result := skill.ValidateWithOptions(parsed, &skill.ValidationOptions{Spec: tt.spec})
+			if len(result.Errors) > 0 || len(result.Warnings) > 0 {
+				t.Errorf("generated skill has findings: %v %v", result.Errors, result.Warnings)
+			}
+
+			info, err := os.Stat(filepath.Join(dir, "scripts", "example.sh"))
+			if err != nil || info.Mode().Perm()&0100 == 0 {
+				t.Errorf("scripts/example.sh should be executable (%v)", err)
+			}
+		})
+	}
+}
+
+func TestCreate_OnlyRequestedDirs(t *testing.T) {
+	dir, files, err := Create(t.TempDir(), &Options{Name: "minimal", Description: "A minimal skill: nothing else."})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) != 1 || files[0] != "SKILL.md" {
+		t.Errorf("files = %v, want only SKILL.md", files)
+	}
+	parsed, err := skill.Parse(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Description != "A minimal skill: nothing else." {
+		t.Errorf("Description = %q", parsed.Description)
+	}
+	if strings.Contains(parsed.Body, "## Scripts") {
+		t.Error("body should not mention directories that were not created")
+	}
+}
+
+func TestCreate_InvalidName(t *testing.T) {
+	parent := t.TempDir()
+	_, _, err := Create(parent, &Options{Name: "Claude-Helper", Spec: skill.SpecClaudeCode})
+
+	var nameErr *NameError
+	if !errors.As(err, &nameErr) {
+		t.Fatalf("expected a NameError, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "[name-format]") || !strings.Contains(err.Error(), "[name-reserved-words]") {
+		t.Errorf("unexpected message: %v", err)
+	}
+	if entries, _ := os.ReadDir(parent); len(entries) != 0 {
+		t.Error("nothing should be created for an invalid name")
+	}
+}
+
+func TestCreate_Exists(t *testing.T) {
+	parent := t.TempDir()
+	if err := os.Mkdir(filepath.Join(parent, "taken"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Create(parent, &Options{Name: "taken"}); !errors.Is(err, ErrExists) {
+		t.Errorf("expected ErrExists, got %v", err)
+	}
+}
+
+func TestCreate_UnknownDir(t *testing.T) {
+	if _, _, err := Create(t.TempDir(), &Options{Name: "dirs", Dirs: []string{"docs"}}); err == nil {
+		t.Error("expected an error for an unknown optional directory")
+	}
+}
+
+func TestCreate_UserTemplate(t *testing.T) {
+	tmpl := t.TempDir()
+	files := map[string]string{
+		"SKILL.md.tmpl":         "---\nname: {{.Name}}\ndescription: {{yaml .Description}}\nlicense: MIT\n---\n\n# {{.Title}}\n",
+		"LICENSE":               "MIT {{not rendered}}\n",
+		".DS_Store":             "junk",
+		"scripts/run.py.tmpl":   "print('{{.Name}}')\n",
+		"references/FORMS.md":   "# Forms\n",
+		"templates/report.html": "<html></html>\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpl, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dir, created, err := Create(t.TempDir(), &Options{Name: "custom", TemplateDir: tmpl, Dirs: []string{"scripts"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"LICENSE", "SKILL.md", "scripts/run.py", "templates/report.html"}
+	if strings.Join(created, ",") != strings.Join(want, ",") {
+		t.Errorf("created = %v, want %v", created, want)
+	}
+
+	for name, wantContent := range map[string]string{
+		"SKILL.md":       "---\nname: custom\ndescription: 'TODO: describe what this skill does and when to use it.'\nlicense: MIT\n---\n\n# Custom\n",
+		"LICENSE":        "MIT {{not rendered}}\n",
+		"scripts/run.py": "print('custom')\n",
+	} {
+		got, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != wantContent {
+			t.Errorf("%s = %q, want %q", name, got, wantContent)
+		}
+	}
+}
+
+func TestCreate_TemplateErrors(t *testing.T) {
+	noSkill := t.TempDir()
+	if err := os.WriteFile(filepath.Join(noSkill, "README.md"), []byte("# Readme\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	badSyntax := t.TempDir()
+	if err := os.WriteFile(filepath.Join(badSyntax, "SKILL.md.tmpl"), []byte("{{.Missing}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, dir := range map[string]string{
+		"no SKILL.md":   noSkill,
+		"unknown field": badSyntax,
+		"missing dir":   filepath.Join(noSkill, "missing"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			parent := t.TempDir()
+			if _, _, err := Create(parent, &Options{Name: "broken", TemplateDir: dir}); err == nil {
+				t.Error("expected an error")
+			}
+			if entries, _ := os.ReadDir(parent); len(entries) != 0 {
+				t.Error("nothing should be created for a broken template")
+			}
+		})
+	}
+}
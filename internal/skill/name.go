@@ -0,0 +1,65 @@
+package skill
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Maximum lengths of the frontmatter text fields, in characters (see CharCount).
+const (
+	MaxNameLength          = 64
+	MaxDescriptionLength   = 1024
+	MaxCompatibilityLength = 500
+)
+
+// IsValidName reports whether name satisfies the name-length and name-format rules:
+// 1-64 lowercase alphanumeric characters and hyphens, with no leading,
+// trailing or consecutive hyphens.
+func IsValidName(name string) bool {
+	if name == "" || CharCount(name) > MaxNameLength {
+		return false
+	}
+	if strings.HasPrefix(name, "-") || strings.HasSuffix(name, "-") || strings.Contains(name, "--") {
+		return false
+	}
+	for _, r := range name {
+		if !unicode.IsLower(r) && !unicode.IsDigit(r) && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeName converts name into the closest valid name: it lowercases it,
+// replaces any other character with a hyphen, collapses consecutive hyphens
+// and trims leading and trailing ones. The result may still be empty or too long.
+func NormalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLower(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			continue
+		}
+		if s := b.String(); s != "" && !strings.HasSuffix(s, "-") {
+			b.WriteRune('-')
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// nameRules are the rules that inspect the name on its own.
+var nameRules = []string{RuleNameRequired, RuleNameLength, RuleNameFormat, RuleNameXMLTags, RuleNameReservedWords, RuleNameNormalization, RuleNameConfusables, RuleInvisibleCharacters}
+
+// ValidateName checks a prospective skill name against the name rules that
+// apply to spec and returns their findings, e.g. before creating a skill.
+func ValidateName(name string, spec Spec) []ValidationError {
+	opts := &ValidationOptions{Spec: spec, Severities: make(map[string]Severity)}
+	for _, rule := range Rules() {
+		if !slices.Contains(nameRules, rule.ID) {
+			opts.Severities[rule.ID] = SeverityOff
+		}
+	}
+	result := ValidateWithOptions(&Skill{Name: name}, opts)
+	return append(result.Errors, result.Warnings...)
+}
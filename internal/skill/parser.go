@@ -2,96 +2,126 @@
 package skill
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/biwakonbu/aglx/internal/frontmatter"
+	"github.com/biwakonbu/aglx/internal/source"
+	"github.com/biwakonbu/aglx/internal/suppress"
 	"gopkg.in/yaml.v3"
 )
 
-const (
-	frontmatterDelimiter = "---"
-	skillFileName        = "SKILL.md"
-)
+const skillFileName = "SKILL.md"
 
 // Parse reads and parses a SKILL.md file from the given directory path.
 func Parse(dirPath string) (*Skill, error) {
-	skillPath := filepath.Join(dirPath, skillFileName)
+	return ParseFS(os.DirFS(dirPath), dirPath)
+}
 
-	file, err := os.Open(skillPath)
+// ParseFS reads and parses the SKILL.md file at the root of fsys, which holds
+// the skill directory (for example an archive's top-level folder). dirPath is
+// the path the skill is reported under; its base name is the directory name
+// the skill name must match.
+func ParseFS(fsys fs.FS, dirPath string) (*Skill, error) {
+	file, err := fsys.Open(skillFileName)
 	if err != nil {
-		if os.IsNotExist(err) {
+		if errors.Is(err, fs.ErrNotExist) {
 			return nil, fmt.Errorf("SKILL.md not found in %s", dirPath)
 		}
 		return nil, fmt.Errorf("failed to open SKILL.md: %w", err)
 	}
 	defer file.Close()
 
-	frontmatter, body, err := extractFrontmatter(file)
+	skill, err := ParseReader(file, dirPath)
+	if err != nil {
+		return nil, err
+	}
+	skill.FS = fsys
+	return skill, nil
+}
+
+// ParseReader parses SKILL.md content read from r for the skill directory
+// dirPath. The returned skill has no FS, so directory rules look at dirPath
+// on the OS file system (if set); use ParseFS to validate other trees.
+func ParseReader(r io.Reader, dirPath string) (*Skill, error) {
+	skillPath := filepath.Join(dirPath, skillFileName)
+
+	file, err := extractFrontmatter(r)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract frontmatter: %w", err)
 	}
 
-	var skill Skill
-	if err := yaml.Unmarshal([]byte(frontmatter), &skill); err != nil {
+	// Decode through yaml.Node to keep the source position of each key.
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(file.Frontmatter), &doc); err != nil {
 		return nil, fmt.Errorf("failed to parse YAML frontmatter: %w", err)
 	}
 
-	skill.Body = body
+	var skill Skill
+	if len(doc.Content) > 0 {
+		root := doc.Content[0]
+		if root.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("failed to parse YAML frontmatter: expected a mapping of keys to values, got %s", describeNode(root))
+		}
+		decoder := &schemaDecoder{path: skillPath, lines: strings.Split(file.Frontmatter, "\n"), skill: &skill}
+		decoder.decode(root)
+	}
+
+	skill.Body = file.Body
+	skill.BodyLine = file.BodyLine
 	skill.Path = dirPath
+	skill.Positions = fieldPositions(&doc, skillPath)
+	skill.Suppressions = suppress.Parse(skillPath, &doc, file.Body, file.BodyLine)
+	skill.FileIssues = file.Issues
 
 	return &skill, nil
 }
 
-// extractFrontmatter separates YAML frontmatter from Markdown body.
-// Returns frontmatter content (without delimiters) and body content.
-func extractFrontmatter(file *os.File) (string, string, error) {
-	scanner := bufio.NewScanner(file)
-
-	// Check for opening delimiter
-	if !scanner.Scan() {
-		return "", "", fmt.Errorf("empty file")
-	}
-	firstLine := strings.TrimSpace(scanner.Text())
-	if firstLine != frontmatterDelimiter {
-		return "", "", fmt.Errorf("missing opening frontmatter delimiter (---)")
+// fieldPositions maps each top-level key of the frontmatter document to
+// the position of its value in the SKILL.md file.
+func fieldPositions(doc *yaml.Node, filePath string) map[string]source.Position {
+	positions := make(map[string]source.Position)
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return positions
 	}
 
-	// Read frontmatter lines until closing delimiter
-	var frontmatterLines []string
-	foundClosing := false
-	for scanner.Scan() {
-		line := scanner.Text()
-		if strings.TrimSpace(line) == frontmatterDelimiter {
-			foundClosing = true
-			break
+	mapping := doc.Content[0]
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		if _, seen := positions[key.Value]; seen {
+			continue
+		}
+		positions[key.Value] = source.Position{
+			File: filePath,
+			// Frontmatter starts on line 2, after the opening delimiter.
+			Line:   value.Line + 1,
+			Column: value.Column,
 		}
-		frontmatterLines = append(frontmatterLines, line)
-	}
-
-	if !foundClosing {
-		return "", "", fmt.Errorf("missing closing frontmatter delimiter (---)")
 	}
+	return positions
+}
 
-	// Read the rest as body
-	var bodyLines []string
-	for scanner.Scan() {
-		bodyLines = append(bodyLines, scanner.Text())
+// extractFrontmatter reads SKILL.md and checks that it starts with a closed
+// frontmatter block.
+func extractFrontmatter(r io.Reader) (*frontmatter.Document, error) {
+	doc, err := frontmatter.Read(r)
+	if err != nil {
+		return nil, err
 	}
-
-	if err := scanner.Err(); err != nil {
-		return "", "", fmt.Errorf("error reading file: %w", err)
+	switch {
+	case doc.Unclosed:
+		return nil, fmt.Errorf("missing closing frontmatter delimiter (---)")
+	case !doc.HasFrontmatter && doc.BodyLine == 0:
+		return nil, fmt.Errorf("empty file")
+	case !doc.HasFrontmatter:
+		return nil, fmt.Errorf("missing opening frontmatter delimiter (---)")
 	}
-
-	frontmatter := strings.Join(frontmatterLines, "\n")
-	body := strings.Join(bodyLines, "\n")
-
-	// Trim leading newlines from body
-	body = strings.TrimLeft(body, "\n")
-
-	return frontmatter, body, nil
+	return doc, nil
 }
 
 // ParseMultiple parses multiple skill directories and returns all parsed skills.
@@ -1,10 +1,10 @@
 package skill
 
 import (
-	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 func TestParse_ValidSkill(t *testing.T) {
@@ -86,8 +86,7 @@ func TestParse_WithMetadata(t *testing.T) {
 }
 
 func TestParse_MissingSkillMd(t *testing.T) {
-	tmpDir := t.TempDir()
-	_, err := Parse(tmpDir)
+	_, err := ParseFS(fstest.MapFS{}, "empty")
 	if err == nil {
 		t.Error("expected error for missing SKILL.md")
 	}
@@ -97,30 +96,18 @@ func TestParse_MissingSkillMd(t *testing.T) {
 }
 
 func TestParse_MissingFrontmatter(t *testing.T) {
-	tmpDir := t.TempDir()
-	skillPath := filepath.Join(tmpDir, "SKILL.md")
-	if err := os.WriteFile(skillPath, []byte("# No frontmatter"), 0644); err != nil {
-		t.Fatal(err)
-	}
-
-	_, err := Parse(tmpDir)
+	_, err := ParseReader(strings.NewReader("# No frontmatter"), "test")
 	if err == nil {
 		t.Error("expected error for missing frontmatter delimiter")
 	}
 }
 
 func TestParse_UnclosedFrontmatter(t *testing.T) {
-	tmpDir := t.TempDir()
-	skillPath := filepath.Join(tmpDir, "SKILL.md")
 	content := `---
 name: test
 description: test
 `
-	if err := os.WriteFile(skillPath, []byte(content), 0644); err != nil {
-		t.Fatal(err)
-	}
-
-	_, err := Parse(tmpDir)
+	_, err := ParseReader(strings.NewReader(content), "test")
 	if err == nil {
 		t.Error("expected error for unclosed frontmatter")
 	}
@@ -147,18 +134,70 @@ func TestParse_NoFrontmatter(t *testing.T) {
 }
 
 func TestParse_EmptyFile(t *testing.T) {
-	tmpDir := t.TempDir()
-	skillPath := filepath.Join(tmpDir, "SKILL.md")
-	if err := os.WriteFile(skillPath, []byte(""), 0644); err != nil {
-		t.Fatal(err)
-	}
-
-	_, err := Parse(tmpDir)
+	_, err := ParseReader(strings.NewReader(""), "test")
 	if err == nil {
 		t.Error("expected error for empty file")
 	}
 }
 
+func TestParse_IrregularFiles(t *testing.T) {
+	long := strings.Repeat("word ", 20000) // a 100KB line
+	tests := []struct {
+		name    string
+		content string
+		issue   string
+	}{
+		{"byte order mark", "\uFEFF---\nname: test\ndescription: test\n---\n", "byte order mark"},
+		{"CRLF", "---\r\nname: test\r\ndescription: test\r\n---\r\n", "CRLF"},
+		{"long line", "---\nname: test\ndescription: test\n---\n" + long + "\n", "over 64KB"},
+		{"document end marker", "---\nname: test\ndescription: test\n...\n", `closed with "..."`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseReader(strings.NewReader(tt.content), "test")
+			if err != nil {
+				t.Fatalf("ParseReader() error = %v", err)
+			}
+			if s.Name != "test" || s.Description != "test" {
+				t.Errorf("frontmatter = %q, %q", s.Name, s.Description)
+			}
+			var issues []ValidationError
+			for _, w := range Validate(s).Warnings {
+				if w.Rule == RuleFileFormat {
+					issues = append(issues, w)
+				}
+			}
+			if len(issues) != 1 || !strings.Contains(issues[0].Message, tt.issue) {
+				t.Errorf("expected one %s warning containing %q, got %v", RuleFileFormat, tt.issue, issues)
+			}
+		})
+	}
+}
+
+func TestParseFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"SKILL.md":          {Data: []byte("---\nname: in-memory\ndescription: An in-memory fixture.\n---\n\n# In Memory\n\nRun `scripts/run.sh`.\n")},
+		"scripts/run.sh":    {Data: []byte("#!/bin/sh\n")},
+		"references/.notes": {Data: []byte("draft\n")},
+	}
+	skill, err := ParseFS(fsys, "fixtures/in-memory")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if skill.Name != "in-memory" || skill.Path != "fixtures/in-memory" || skill.BodyLine != 6 {
+		t.Errorf("unexpected skill: %+v", skill)
+	}
+	if pos := skill.FieldPosition("name"); pos.File != filepath.Join("fixtures/in-memory", "SKILL.md") || pos.Line != 2 {
+		t.Errorf("unexpected name position: %v", pos)
+	}
+
+	// Directory rules read the same file system, not the OS.
+	result := Validate(skill)
+	if !result.IsValid() || len(result.Warnings) != 1 || result.Warnings[0].Rule != RuleHiddenFiles {
+		t.Errorf("expected only a hidden-files warning, got errors %v, warnings %v", result.Errors, result.Warnings)
+	}
+}
+
 func TestParseMultiple(t *testing.T) {
 	skills, errs := ParseMultiple([]string{
 		"../../testdata/valid/pdf-processing",
@@ -250,3 +289,40 @@ func TestParsedAllowedTools_MCPTools(t *testing.T) {
 		}
 	}
 }
+
+func TestParse_Positions(t *testing.T) {
+	skill, err := Parse("../../testdata/valid/with-metadata")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	file := filepath.Join("../../testdata/valid/with-metadata", "SKILL.md")
+	tests := []struct {
+		field        string
+		line, column int
+	}{
+		{"name", 2, 7},
+		{"description", 3, 14},
+		{"allowed-tools", 6, 16},
+		{"metadata", 8, 3},
+	}
+	for _, tt := range tests {
+		pos := skill.FieldPosition(tt.field)
+		if pos.File != file || pos.Line != tt.line || pos.Column != tt.column {
+			t.Errorf("FieldPosition(%q) = %v, want %s:%d:%d", tt.field, pos, file, tt.line, tt.column)
+		}
+	}
+
+	// Missing fields fall back to the opening delimiter
+	if pos := skill.FieldPosition("compatibility-missing"); pos.Line != 1 {
+		t.Errorf("expected fallback to line 1, got %v", pos)
+	}
+
+	// Body starts after the closing delimiter and a blank line
+	if skill.BodyLine != 13 {
+		t.Errorf("expected body to start on line 13, got %d", skill.BodyLine)
+	}
+	if !strings.HasPrefix(skill.Body, "# Skill with Metadata") {
+		t.Errorf("unexpected body start: %q", skill.Body[:20])
+	}
+}
@@ -0,0 +1,218 @@
+package skill
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/biwakonbu/aglx/internal/mdref"
+	"github.com/biwakonbu/aglx/internal/source"
+)
+
+// referenceStatus is the outcome of resolving a reference in the skill
+// directory.
+type referenceStatus int
+
+const (
+	referenceFound referenceStatus = iota
+	referenceMissing
+	referenceOutside
+	referenceCaseMismatch
+)
+
+// resolvedReference is a reference resolved against the skill directory.
+type resolvedReference struct {
+	mdref.Ref
+	status referenceStatus
+
+	// name is the slash-separated path of the file relative to the skill
+	// directory, with the case of the names on disk when they differ.
+	name string
+}
+
+// BodyReferences returns the local file references of the body: links and
+// images, and paths into the optional directories (OptionalDirs).
+func (s *Skill) BodyReferences() []mdref.Ref {
+	return mdref.Find(s.Body, OptionalDirs)
+}
+
+// BodyLinePosition returns the position of the 1-based line and column of
+// the body.
+func (s *Skill) BodyLinePosition(line, column int) source.Position {
+	if s.BodyLine == 0 {
+		return s.BodyPosition()
+	}
+	return source.Position{File: s.FilePath(), Line: s.BodyLine + line - 1, Column: column}
+}
+
+// resolveReference resolves ref, found in the file at the slash-separated
+// path doc of fsys, against the skill directory. Links are relative to doc,
+// bare paths to the skill directory.
+func resolveReference(fsys fs.FS, doc string, ref mdref.Ref) resolvedReference {
+	r := resolvedReference{Ref: ref}
+	name := ref.Path
+	if ref.Kind == mdref.KindLink && !strings.HasPrefix(name, "/") {
+		name = path.Join(path.Dir(doc), name)
+	}
+	name = path.Clean(name)
+	if strings.HasPrefix(name, "/") || name == ".." || strings.HasPrefix(name, "../") {
+		r.status, r.name = referenceOutside, name
+		return r
+	}
+	r.name, r.status = lookupExact(fsys, name)
+	return r
+}
+
+// lookupExact finds the slash-separated path name in fsys one element at a
+// time, comparing names exactly: case-insensitive file systems (macOS,
+// Windows) would find "Guide.md" as "guide.md" and hide the mismatch. It
+// returns the path with the names found on disk.
+func lookupExact(fsys fs.FS, name string) (string, referenceStatus) {
+	if name == "." {
+		return name, referenceFound
+	}
+	dir, status := ".", referenceFound
+	for _, elem := range strings.Split(name, "/") {
+		entries, err := fs.ReadDir(fsys, dir)
+		if err != nil {
+			return name, referenceMissing
+		}
+		found := ""
+		for _, e := range entries {
+			if e.Name() == elem {
+				found = elem
+				break
+			}
+			if found == "" && strings.EqualFold(e.Name(), elem) {
+				found = e.Name()
+			}
+		}
+		switch {
+		case found == "":
+			return name, referenceMissing
+		case found != elem:
+			status = referenceCaseMismatch
+		}
+		dir = path.Join(dir, found)
+	}
+	return dir, status
+}
+
+// readableDirFS returns the file system of the skill directory, or nil if
+// the directory cannot be read: references cannot be resolved without it.
+func readableDirFS(s *Skill) fs.FS {
+	fsys := s.dirFS()
+	if fsys == nil {
+		return nil
+	}
+	if _, err := fs.Stat(fsys, "."); err != nil {
+		return nil
+	}
+	return fsys
+}
+
+// bodyReferences resolves the references of the body, or returns nil if
+// the skill directory cannot be read.
+func bodyReferences(s *Skill) []resolvedReference {
+	fsys := readableDirFS(s)
+	if fsys == nil {
+		return nil
+	}
+	var resolved []resolvedReference
+	for _, ref := range s.BodyReferences() {
+		resolved = append(resolved, resolveReference(fsys, skillFileName, ref))
+	}
+	return resolved
+}
+
+// validateReferences reports the body references with the given status.
+func validateReferences(status referenceStatus) func(ctx *RuleContext) {
+	return func(ctx *RuleContext) {
+		for _, r := range bodyReferences(ctx.Skill) {
+			if r.status != status {
+				continue
+			}
+			var msg string
+			switch status {
+			case referenceMissing:
+				if r.Kind == mdref.KindPath && slices.Contains(OptionalDirs, r.name) {
+					continue // "scripts/" names an optional directory, not a file
+				}
+				msg = fmt.Sprintf("references %q, which does not exist", r.Raw)
+			case referenceOutside:
+				msg = fmt.Sprintf("references %q, which is outside the skill directory and is not bundled with it", r.Raw)
+			case referenceCaseMismatch:
+				msg = fmt.Sprintf("references %q, but the file is %q (the reference breaks on case-sensitive file systems)", r.Raw, r.name)
+			}
+			ctx.Report("body", msg, ctx.Skill.BodyLinePosition(r.Line, r.Column))
+		}
+	}
+}
+
+// maxScannedFileSize is the size of the largest referenced file read for
+// further references.
+const maxScannedFileSize = 1 << 20
+
+// reachableFiles returns the slash-separated paths of the files and
+// directories reachable from the body: referenced by it, by a reachable
+// file (links and paths in Markdown files, paths in other text files such
+// as scripts) or inside a reachable directory.
+func reachableFiles(fsys fs.FS, s *Skill) map[string]bool {
+	reached := map[string]bool{skillFileName: true}
+	var queue []string
+	reach := func(doc string, refs []mdref.Ref) {
+		for _, ref := range refs {
+			r := resolveReference(fsys, doc, ref)
+			if r.status != referenceFound && r.status != referenceCaseMismatch || reached[r.name] {
+				continue
+			}
+			reached[r.name] = true
+			queue = append(queue, r.name)
+		}
+	}
+
+	reach(skillFileName, s.BodyReferences())
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		info, err := fs.Stat(fsys, name)
+		if err != nil {
+			continue
+		}
+		if info.IsDir() {
+			_ = fs.WalkDir(fsys, name, func(p string, d fs.DirEntry, err error) error {
+				if err == nil && !d.IsDir() && !reached[p] {
+					reached[p] = true
+					queue = append(queue, p)
+				}
+				return nil
+			})
+			continue
+		}
+		reach(name, fileReferences(fsys, name, info))
+	}
+	return reached
+}
+
+// fileReferences returns the references of a reachable file: links and
+// paths for Markdown, paths for other text files, nothing for binary or
+// large files.
+func fileReferences(fsys fs.FS, name string, info fs.FileInfo) []mdref.Ref {
+	if !info.Mode().IsRegular() || info.Size() > maxScannedFileSize {
+		return nil
+	}
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil || !utf8.Valid(data) || bytes.IndexByte(data, 0) >= 0 {
+		return nil
+	}
+	refs := mdref.Find(string(data), OptionalDirs)
+	switch strings.ToLower(path.Ext(name)) {
+	case ".md", ".markdown":
+		return refs
+	}
+	return slices.DeleteFunc(refs, func(r mdref.Ref) bool { return r.Kind != mdref.KindPath })
+}
@@ -0,0 +1,176 @@
+package skill
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/biwakonbu/aglx/internal/source"
+	"github.com/biwakonbu/aglx/internal/suppress"
+)
+
+// Severity is the level at which a rule reports its findings.
+type Severity int
+
+const (
+	// SeverityOff disables a rule.
+	SeverityOff Severity = iota
+	// SeverityWarning reports findings as warnings.
+	SeverityWarning
+	// SeverityError reports findings as errors.
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityOff:
+		return "off"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseSeverity converts "off", "warning" (or "warn") and "error" into a Severity.
+func ParseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "off":
+		return SeverityOff, nil
+	case "warning", "warn":
+		return SeverityWarning, nil
+	case "error":
+		return SeverityError, nil
+	default:
+		return SeverityOff, fmt.Errorf("unknown severity %q (expected off, warning or error)", s)
+	}
+}
+
+// Rule is a named validation check.
+type Rule struct {
+	// ID is the stable identifier used by reporters, config files and suppressions.
+	// It must be lowercase kebab-case and unique within the registry.
+	ID string
+
+	// Description is a one-line summary of what the rule checks.
+	Description string
+
+	// DefaultSeverity is used unless overridden by ValidationOptions.Severities.
+	DefaultSeverity Severity
+
+	// Specs lists the specifications the rule applies to.
+	// An empty list means the rule applies to every specification, including SpecAuto.
+	Specs []Spec
+
+	// Check inspects the skill and reports findings through the context.
+	Check func(ctx *RuleContext)
+}
+
+// AppliesTo reports whether the rule runs when validating against spec.
+func (r Rule) AppliesTo(spec Spec) bool {
+	if len(r.Specs) == 0 {
+		return true
+	}
+	for _, s := range r.Specs {
+		if s == spec {
+			return true
+		}
+	}
+	return false
+}
+
+// RuleContext carries the skill under validation and collects a rule's findings.
+type RuleContext struct {
+	Skill   *Skill
+	Options *ValidationOptions
+
+	rule         *Rule
+	severity     Severity
+	result       *ValidationResult
+	suppressions *suppress.Tracker
+}
+
+// Report records a finding for the running rule at its effective severity.
+// Findings of rules disabled by an inline suppression are dropped.
+func (c *RuleContext) Report(field, message string, pos source.Position) {
+	if c.suppressions != nil && c.suppressions.Suppresses(c.rule.ID) {
+		return
+	}
+	finding := ValidationError{
+		Rule:    c.rule.ID,
+		Field:   field,
+		Message: message,
+		Pos:     pos,
+	}
+	switch c.severity {
+	case SeverityError:
+		c.result.Errors = append(c.result.Errors, finding)
+	case SeverityWarning:
+		c.result.Warnings = append(c.result.Warnings, finding)
+	}
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   []Rule
+)
+
+// Register adds a rule to the registry. Rules run in registration order.
+// It returns an error if the rule is incomplete or its ID is already taken.
+func Register(rule Rule) error {
+	if rule.ID == "" {
+		return fmt.Errorf("rule ID must not be empty")
+	}
+	if rule.ID != strings.ToLower(rule.ID) || strings.ContainsAny(rule.ID, " \t_") {
+		return fmt.Errorf("rule ID %q must be lowercase kebab-case", rule.ID)
+	}
+	if rule.Check == nil {
+		return fmt.Errorf("rule %q has no Check function", rule.ID)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for _, r := range registry {
+		if r.ID == rule.ID {
+			return fmt.Errorf("rule %q is already registered", rule.ID)
+		}
+	}
+	registry = append(registry, rule)
+	return nil
+}
+
+// MustRegister is like Register but panics on error. Intended for init functions.
+func MustRegister(rule Rule) {
+	if err := Register(rule); err != nil {
+		panic(err)
+	}
+}
+
+// Rules returns a copy of all registered rules in registration order.
+func Rules() []Rule {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return append([]Rule(nil), registry...)
+}
+
+// LookupRule returns the registered rule with the given ID.
+func LookupRule(id string) (Rule, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, r := range registry {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
+
+// effectiveSeverity returns the severity of a rule after applying overrides.
+func effectiveSeverity(rule Rule, opts *ValidationOptions) Severity {
+	if sev, ok := opts.Severities[rule.ID]; ok {
+		return sev
+	}
+	return rule.DefaultSeverity
+}
@@ -0,0 +1,275 @@
+package skill
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/biwakonbu/aglx/internal/suppress"
+)
+
+// registerForTest registers a rule and removes it when the test finishes.
+func registerForTest(t *testing.T, rule Rule) {
+	t.Helper()
+	if err := Register(rule); err != nil {
+		t.Fatalf("Register(%q) failed: %v", rule.ID, err)
+	}
+	t.Cleanup(func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		for i, r := range registry {
+			if r.ID == rule.ID {
+				registry = append(registry[:i], registry[i+1:]...)
+				return
+			}
+		}
+	})
+}
+
+func TestRegister_Invalid(t *testing.T) {
+	check := func(*RuleContext) {}
+	tests := []struct {
+		name string
+		rule Rule
+	}{
+		{"empty ID", Rule{Check: check}},
+		{"uppercase ID", Rule{ID: "Name-Format", Check: check}},
+		{"underscore ID", Rule{ID: "name_format", Check: check}},
+		{"space in ID", Rule{ID: "name format", Check: check}},
+		{"no check", Rule{ID: "no-check"}},
+		{"duplicate ID", Rule{ID: RuleNameFormat, Check: check}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Register(tt.rule); err == nil {
+				t.Errorf("expected error registering %+v", tt.rule)
+			}
+		})
+	}
+}
+
+func TestRules_BuiltinIDsAreUnique(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, r := range Rules() {
+		if seen[r.ID] {
+			t.Errorf("duplicate rule ID %q", r.ID)
+		}
+		seen[r.ID] = true
+		if r.Description == "" {
+			t.Errorf("rule %q has no description", r.ID)
+		}
+	}
+	for _, id := range []string{RuleNameFormat, RuleNameDirMatch, RuleBodySize} {
+		if _, ok := LookupRule(id); !ok {
+			t.Errorf("LookupRule(%q) not found", id)
+		}
+	}
+	if _, ok := LookupRule("does-not-exist"); ok {
+		t.Error("LookupRule found an unregistered rule")
+	}
+}
+
+func TestValidate_ReportsRuleIDs(t *testing.T) {
+	s := &Skill{
+		Name:        "Bad--Name",
+		Description: "A test skill.",
+		Path:        "/path/to/bad-name",
+	}
+
+	result := Validate(s)
+	rules := make(map[string]bool)
+	for _, e := range result.Errors {
+		if e.Rule == "" {
+			t.Errorf("finding %q has no rule ID", e.Error())
+		}
+		rules[e.Rule] = true
+	}
+	if !rules[RuleNameFormat] || !rules[RuleNameDirMatch] {
+		t.Errorf("expected %s and %s, got %v", RuleNameFormat, RuleNameDirMatch, result.Errors)
+	}
+}
+
+func TestValidate_SeverityOverrides(t *testing.T) {
+	s := &Skill{
+		Name:        "Bad-Name",
+		Description: "A test skill.",
+		Path:        "/path/to/bad-name",
+	}
+
+	result := ValidateWithOptions(s, &ValidationOptions{
+		Spec: SpecAgentSkills,
+		Severities: map[string]Severity{
+			RuleNameFormat:   SeverityWarning,
+			RuleNameDirMatch: SeverityOff,
+		},
+	})
+
+	if !result.IsValid() {
+		t.Errorf("expected no errors after overrides, got %v", result.Errors)
+	}
+	if len(result.Warnings) == 0 {
+		t.Fatal("expected name-format findings to be downgraded to warnings")
+	}
+	for _, w := range result.Warnings {
+		if w.Rule != RuleNameFormat {
+			t.Errorf("unexpected warning from rule %q", w.Rule)
+		}
+	}
+}
+
+func TestValidate_SpecApplicability(t *testing.T) {
+	s := &Skill{
+		Name:        "claude-helper",
+		Description: "A test skill.",
+		Path:        "/path/to/claude-helper",
+	}
+
+	if result := ValidateWithOptions(s, &ValidationOptions{Spec: SpecAgentSkills}); !result.IsValid() {
+		t.Errorf("reserved words should not apply to agent-skills, got %v", result.Errors)
+	}
+	result := ValidateWithOptions(s, &ValidationOptions{Spec: SpecClaudeCode})
+	if len(result.Errors) != 1 || result.Errors[0].Rule != RuleNameReservedWords {
+		t.Errorf("expected only %s for claude-code, got %v", RuleNameReservedWords, result.Errors)
+	}
+}
+
+func TestRegister_CustomRule(t *testing.T) {
+	registerForTest(t, Rule{
+		ID:              "test-no-todo",
+		Description:     "description must not contain TODO",
+		DefaultSeverity: SeverityWarning,
+		Specs:           []Spec{SpecClaudeCode},
+		Check: func(ctx *RuleContext) {
+			if ctx.Skill.Description == "TODO" {
+				ctx.Report("description", "contains TODO", ctx.Skill.FieldPosition("description"))
+			}
+		},
+	})
+
+	s := &Skill{Name: "todo", Description: "TODO", Path: "/path/to/todo"}
+
+	result := ValidateWithOptions(s, &ValidationOptions{Spec: SpecClaudeCode})
+	if len(result.Warnings) != 1 || result.Warnings[0].Rule != "test-no-todo" {
+		t.Fatalf("expected custom rule warning, got %v", result.Warnings)
+	}
+	if got := result.Warnings[0].Pos; got.Line != 1 {
+		t.Errorf("expected fallback position on line 1, got %v", got)
+	}
+
+	if result := ValidateWithOptions(s, &ValidationOptions{Spec: SpecAgentSkills}); len(result.Warnings) != 0 {
+		t.Errorf("custom rule should not apply to agent-skills, got %v", result.Warnings)
+	}
+
+	result = ValidateWithOptions(s, &ValidationOptions{
+		Spec:       SpecClaudeCode,
+		Severities: map[string]Severity{"test-no-todo": SeverityError},
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Rule != "test-no-todo" {
+		t.Errorf("expected custom rule to be promoted to error, got %v", result.Errors)
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Severity
+		wantErr bool
+	}{
+		{"off", SeverityOff, false},
+		{"warning", SeverityWarning, false},
+		{"warn", SeverityWarning, false},
+		{"ERROR", SeverityError, false},
+		{"fatal", SeverityOff, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSeverity(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSeverity(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSeverity(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestValidate_BodyThresholds(t *testing.T) {
+	s := &Skill{
+		Name:        "big",
+		Description: "A test skill.",
+		Path:        "/path/to/big",
+		Body:        strings.Repeat("line\n", 200),
+	}
+
+	result := ValidateWithOptions(s, &ValidationOptions{Spec: SpecClaudeCode})
+	if result.HasWarnings() {
+		t.Fatalf("expected no warnings with default thresholds, got %v", result.Warnings)
+	}
+
+	result = ValidateWithOptions(s, &ValidationOptions{Spec: SpecClaudeCode, MaxBodyTokens: 100, MaxBodyLines: 100})
+	rules := make(map[string]bool)
+	for _, w := range result.Warnings {
+		rules[w.Rule] = true
+	}
+	if !rules[RuleBodySize] || !rules[RuleBodyLines] {
+		t.Errorf("expected %s and %s with lowered thresholds, got %v", RuleBodySize, RuleBodyLines, result.Warnings)
+	}
+}
+
+func TestValidate_Suppressions(t *testing.T) {
+	s, err := Parse("../../testdata/valid/with-suppressions")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	result := ValidateWithOptions(s, &ValidationOptions{Spec: SpecAgentSkills})
+	if !result.IsValid() {
+		t.Errorf("expected valid skill, got %v", result.Errors)
+	}
+	if len(result.Warnings) != 1 {
+		t.Fatalf("expected only the unused suppression warning, got %v", result.Warnings)
+	}
+	w := result.Warnings[0]
+	if w.Rule != RuleUnusedSuppression || !strings.Contains(w.Message, `"hidden-files"`) || w.Pos.Line != 5 {
+		t.Errorf("unexpected warning %+v", w)
+	}
+}
+
+func TestValidate_UnusedSuppressions(t *testing.T) {
+	s := &Skill{
+		Name:        "demo",
+		Description: "A test skill.",
+		Path:        "/path/to/demo",
+		Suppressions: []suppress.Directive{
+			{Rule: "no-such-rule"},
+			{Rule: ""},
+			{Rule: RuleBodyLines},
+			{Rule: RuleNameFormat},
+		},
+	}
+
+	// body-lines does not apply to agent-skills, so its suppression is not reported.
+	result := ValidateWithOptions(s, &ValidationOptions{Spec: SpecAgentSkills})
+	var messages []string
+	for _, w := range result.Warnings {
+		messages = append(messages, w.Message)
+	}
+	want := []string{
+		`unknown rule "no-such-rule"`,
+		"aglx-disable directive does not name any rule",
+		`rule "name-format" reported nothing to suppress`,
+	}
+	if strings.Join(messages, "\n") != strings.Join(want, "\n") {
+		t.Errorf("warnings = %q, want %q", messages, want)
+	}
+
+	// The unused-suppression rule itself can be turned off.
+	result = ValidateWithOptions(s, &ValidationOptions{
+		Spec:       SpecAgentSkills,
+		Severities: map[string]Severity{RuleUnusedSuppression: SeverityOff},
+	})
+	if result.HasWarnings() {
+		t.Errorf("expected no warnings with unused-suppression off, got %v", result.Warnings)
+	}
+}
@@ -0,0 +1,298 @@
+package skill
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/biwakonbu/aglx/internal/source"
+	"github.com/biwakonbu/aglx/internal/suggest"
+	"github.com/biwakonbu/aglx/internal/suppress"
+)
+
+// SchemaProblem is a frontmatter key or value that does not fit the schema,
+// found while decoding SKILL.md. The rule named by Rule reports it.
+type SchemaProblem struct {
+	Rule    string
+	Field   string
+	Message string
+	Pos     source.Position
+
+	// Specs limits the problem to validation against these specifications
+	// (nil means all).
+	Specs []Spec
+}
+
+// valueKind is the YAML type a frontmatter key expects.
+type valueKind int
+
+const (
+	kindString valueKind = iota
+	kindBool
+	kindStringMap
+)
+
+// specFields are the frontmatter keys defined by the Agent Skills
+// specification, in the order they are documented.
+var specFields = []string{"name", "description", "license", "compatibility", "allowed-tools", "metadata"}
+
+// claudeCodeFields are the keys Claude Code reads in addition to specFields.
+var claudeCodeFields = []string{"model", "argument-hint", "disable-model-invocation", "user-invocable"}
+
+var fieldKinds = map[string]valueKind{
+	"name":                     kindString,
+	"description":              kindString,
+	"license":                  kindString,
+	"compatibility":            kindString,
+	"allowed-tools":            kindString,
+	"metadata":                 kindStringMap,
+	"model":                    kindString,
+	"argument-hint":            kindString,
+	"disable-model-invocation": kindBool,
+	"user-invocable":           kindBool,
+}
+
+// schemaDecoder fills in a Skill from the frontmatter mapping, collecting
+// every schema problem instead of failing on the first one.
+type schemaDecoder struct {
+	path  string
+	lines []string // frontmatter lines, for the positions inside values
+	skill *Skill
+}
+
+func (d *schemaDecoder) position(n *yaml.Node) source.Position {
+	// Frontmatter starts on line 2, after the opening delimiter.
+	return source.Position{File: d.path, Line: n.Line + 1, Column: n.Column}
+}
+
+func (d *schemaDecoder) report(rule, field string, n *yaml.Node, format string, args ...interface{}) {
+	d.skill.SchemaProblems = append(d.skill.SchemaProblems, SchemaProblem{
+		Rule:    rule,
+		Field:   field,
+		Message: fmt.Sprintf(format, args...),
+		Pos:     d.position(n),
+	})
+}
+
+// mapping iterates over the key/value pairs of a mapping node. Duplicate
+// keys are reported and skipped, so the first occurrence wins.
+func (d *schemaDecoder) mapping(n *yaml.Node, field string, fn func(key, value *yaml.Node)) {
+	firstLine := make(map[string]int)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], resolve(n.Content[i+1])
+		if line, seen := firstLine[key.Value]; seen {
+			f := key.Value
+			if field != "" {
+				f = field
+			}
+			d.report(RuleFrontmatterDuplicateKey, f, key, "duplicate key %q (first defined on line %d)", key.Value, line)
+			continue
+		}
+		firstLine[key.Value] = key.Line + 1
+		fn(key, value)
+	}
+}
+
+// decode fills in the skill from the root mapping of the frontmatter.
+func (d *schemaDecoder) decode(root *yaml.Node) {
+	d.mapping(root, "", func(key, value *yaml.Node) {
+		kind, known := fieldKinds[key.Value]
+		if !known {
+			d.report(RuleFrontmatterUnknownKey, key.Value, key, "%s", unknownKeyMessage(key.Value))
+			return
+		}
+		if slices.Contains(claudeCodeFields, key.Value) {
+			d.skill.SchemaProblems = append(d.skill.SchemaProblems, SchemaProblem{
+				Rule:    RuleFrontmatterUnknownKey,
+				Field:   key.Value,
+				Message: fmt.Sprintf("%q is a Claude Code extension, not an Agent Skills field (move it under metadata or validate with --spec claude-code)", key.Value),
+				Pos:     d.position(key),
+				Specs:   []Spec{SpecAgentSkills},
+			})
+		}
+		switch kind {
+		case kindString:
+			if s, ok := d.scalar(RuleFrontmatterType, key.Value, value, "must be a string"); ok {
+				d.setString(key.Value, s)
+				d.recordInline(key.Value, value)
+			}
+		case kindBool:
+			if value.Kind != yaml.ScalarNode || value.Tag != "!!bool" {
+				d.report(RuleFrontmatterType, key.Value, value, "must be true or false, not %s", describeNode(value))
+			}
+		case kindStringMap:
+			d.decodeMetadata(value)
+		}
+	})
+}
+
+func (d *schemaDecoder) setString(field, value string) {
+	switch field {
+	case "name":
+		d.skill.Name = value
+	case "description":
+		d.skill.Description = value
+	case "license":
+		d.skill.License = value
+	case "compatibility":
+		d.skill.Compatibility = value
+	case "allowed-tools":
+		d.skill.AllowedTools = value
+	}
+}
+
+// recordInline remembers the column of a string value written verbatim on
+// one line, so that findings can point inside the value.
+func (d *schemaDecoder) recordInline(field string, n *yaml.Node) {
+	if n.Line < 1 || n.Line > len(d.lines) || n.Column < 1 {
+		return
+	}
+	line := d.lines[n.Line-1]
+	column := n.Column
+	if n.Style == yaml.SingleQuotedStyle || n.Style == yaml.DoubleQuotedStyle {
+		column++
+	} else if n.Style != 0 {
+		return
+	}
+	// Columns count characters; the value must appear unchanged (no
+	// escapes, folding or continuation lines) for inner columns to hold.
+	start := 0
+	for i := 1; i < column && start < len(line); i++ {
+		_, size := utf8.DecodeRuneInString(line[start:])
+		start += size
+	}
+	if !strings.HasPrefix(line[start:], n.Value) {
+		return
+	}
+	if d.skill.inlineColumns == nil {
+		d.skill.inlineColumns = make(map[string]int)
+	}
+	d.skill.inlineColumns[field] = column
+}
+
+// scalar returns the text of a string value. Other scalars (numbers,
+// booleans, timestamps) are reported but still used as text, as a plain
+// YAML decoder would; lists and mappings are reported and dropped. Null
+// values count as absent.
+func (d *schemaDecoder) scalar(rule, field string, n *yaml.Node, what string) (string, bool) {
+	switch {
+	case n.Kind == yaml.ScalarNode && n.Tag == "!!null":
+		return "", false
+	case n.Kind == yaml.ScalarNode && n.Tag == "!!str":
+		return n.Value, true
+	case n.Kind == yaml.ScalarNode:
+		d.report(rule, field, n, "%s, not %s (quote the value: %q)", what, describeNode(n), n.Value)
+		return n.Value, true
+	case n.Kind == yaml.SequenceNode && field == "allowed-tools":
+		var tools []string
+		for _, item := range n.Content {
+			tools = append(tools, resolve(item).Value)
+		}
+		d.report(rule, field, n, "%s, not %s (write the tools on one line: %q)", what, describeNode(n), strings.Join(tools, " "))
+		return "", false
+	default:
+		d.report(rule, field, n, "%s, not %s", what, describeNode(n))
+		return "", false
+	}
+}
+
+// decodeMetadata fills in the metadata map. The specification allows only
+// string keys and values; the suppression list (metadata.aglx-ignore) may
+// also be a list of rule IDs.
+func (d *schemaDecoder) decodeMetadata(n *yaml.Node) {
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!null" {
+		return
+	}
+	if n.Kind != yaml.MappingNode {
+		d.report(RuleFrontmatterType, "metadata", n, "must be a mapping of string keys to string values, not %s", describeNode(n))
+		return
+	}
+	d.skill.Metadata = make(map[string]string)
+	d.mapping(n, "metadata", func(key, value *yaml.Node) {
+		if key.Kind != yaml.ScalarNode || key.Tag != "!!str" {
+			d.report(RuleMetadataType, "metadata", key, "key %s must be a string, not %s", key.Value, describeNode(key))
+			return
+		}
+		if key.Value == suppress.MetadataKey && value.Kind == yaml.SequenceNode {
+			if list, ok := d.stringList(key.Value, value); ok {
+				d.skill.Metadata[key.Value] = list
+			}
+			return
+		}
+		if s, ok := d.scalar(RuleMetadataType, "metadata", value, fmt.Sprintf("value of %q must be a string", key.Value)); ok {
+			d.skill.Metadata[key.Value] = s
+		}
+	})
+}
+
+// stringList joins a list of strings into a comma-separated string.
+func (d *schemaDecoder) stringList(key string, n *yaml.Node) (string, bool) {
+	items := make([]string, 0, len(n.Content))
+	for _, item := range n.Content {
+		item = resolve(item)
+		if item.Kind != yaml.ScalarNode {
+			d.report(RuleMetadataType, "metadata", item, "entries of %q must be strings, not %s", key, describeNode(item))
+			return "", false
+		}
+		items = append(items, item.Value)
+	}
+	return strings.Join(items, ", "), true
+}
+
+// resolve follows an alias to the node it refers to.
+func resolve(n *yaml.Node) *yaml.Node {
+	if n.Kind == yaml.AliasNode && n.Alias != nil {
+		return n.Alias
+	}
+	return n
+}
+
+// describeNode names the YAML type of a node for messages, e.g. "a list".
+func describeNode(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.SequenceNode:
+		return "a list"
+	case yaml.MappingNode:
+		return "a mapping"
+	}
+	switch n.Tag {
+	case "!!int", "!!float":
+		return "a number"
+	case "!!bool":
+		return "a boolean"
+	case "!!null":
+		return "null"
+	case "!!timestamp":
+		return "a timestamp"
+	case "!!str":
+		return "a string"
+	default:
+		return fmt.Sprintf("a %s value", strings.TrimPrefix(n.Tag, "!!"))
+	}
+}
+
+// unknownKeyMessage describes an unknown frontmatter key, suggesting the
+// key it most likely misspells.
+func unknownKeyMessage(key string) string {
+	if s := suggest.Closest(key, append(slices.Clone(specFields), claudeCodeFields...)); s != "" {
+		return fmt.Sprintf("unknown key %q (did you mean %q?)", key, s)
+	}
+	return fmt.Sprintf("unknown key %q (expected %s or %s; put custom properties under metadata)",
+		key, strings.Join(specFields[:len(specFields)-1], ", "), specFields[len(specFields)-1])
+}
+
+// reportSchemaProblems returns the Check of a rule that reports the schema
+// problems recorded for it while parsing.
+func reportSchemaProblems(rule string) func(*RuleContext) {
+	return func(ctx *RuleContext) {
+		for _, p := range ctx.Skill.SchemaProblems {
+			if p.Rule != rule || (p.Specs != nil && !slices.Contains(p.Specs, ctx.Options.Spec)) {
+				continue
+			}
+			ctx.Report(p.Field, p.Message, p.Pos)
+		}
+	}
+}
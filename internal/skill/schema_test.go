@@ -0,0 +1,113 @@
+package skill
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse_SchemaProblems(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter string
+		rule        string
+		field       string
+		message     string
+		line        int
+	}{
+		{"misspelled key", "descripton: x\n", RuleFrontmatterUnknownKey, "descripton", `unknown key "descripton" (did you mean "description"?)`, 2},
+		{"underscore key", "allowed_tools: Read\n", RuleFrontmatterUnknownKey, "allowed_tools", `(did you mean "allowed-tools"?)`, 2},
+		{"unrelated key", "version: 1\n", RuleFrontmatterUnknownKey, "version", "put custom properties under metadata", 2},
+		{"duplicate key", "name: a\nname: b\n", RuleFrontmatterDuplicateKey, "name", `duplicate key "name" (first defined on line 2)`, 3},
+		{"duplicate metadata key", "metadata:\n  a: x\n  a: y\n", RuleFrontmatterDuplicateKey, "metadata", `duplicate key "a" (first defined on line 3)`, 4},
+		{"list for allowed-tools", "allowed-tools:\n  - Read\n  - Grep\n", RuleFrontmatterType, "allowed-tools", `must be a string, not a list (write the tools on one line: "Read Grep")`, 3},
+		{"mapping for description", "description:\n  en: x\n", RuleFrontmatterType, "description", "must be a string, not a mapping", 3},
+		{"number for name", "name: 42\n", RuleFrontmatterType, "name", `must be a string, not a number (quote the value: "42")`, 2},
+		{"string for boolean", "disable-model-invocation: yes please\n", RuleFrontmatterType, "disable-model-invocation", "must be true or false, not a string", 2},
+		{"list for metadata", "metadata: [a]\n", RuleFrontmatterType, "metadata", "must be a mapping of string keys to string values, not a list", 2},
+		{"number in metadata", "metadata:\n  version: 1.0\n", RuleMetadataType, "metadata", `value of "version" must be a string, not a number`, 3},
+		{"nested metadata", "metadata:\n  owner:\n    team: x\n", RuleMetadataType, "metadata", `value of "owner" must be a string, not a mapping`, 4},
+		{"boolean in metadata", "metadata:\n  beta: true\n", RuleMetadataType, "metadata", `value of "beta" must be a string, not a boolean`, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseReader(strings.NewReader("---\n"+tt.frontmatter+"---\n"), "demo")
+			if err != nil {
+				t.Fatalf("ParseReader() error = %v", err)
+			}
+			var problems []SchemaProblem
+			for _, p := range s.SchemaProblems {
+				if p.Rule == tt.rule {
+					problems = append(problems, p)
+				}
+			}
+			if len(problems) != 1 {
+				t.Fatalf("SchemaProblems = %+v, want one %s problem", s.SchemaProblems, tt.rule)
+			}
+			p := problems[0]
+			if p.Rule != tt.rule || p.Field != tt.field || !strings.Contains(p.Message, tt.message) || p.Pos.Line != tt.line {
+				t.Errorf("problem = %+v, want rule %s, field %s, message containing %q on line %d", p, tt.rule, tt.field, tt.message, tt.line)
+			}
+		})
+	}
+}
+
+func TestParse_SchemaValues(t *testing.T) {
+	frontmatter := "---\n" +
+		"name: demo\n" +
+		"name: other\n" +
+		"description: &d Shared text\n" +
+		"license: *d\n" +
+		"compatibility:\n" +
+		"allowed-tools: [Read]\n" +
+		"metadata:\n" +
+		"  version: 2\n" +
+		"  aglx-ignore: [body-size, hidden-files]\n" +
+		"---\n"
+	s, err := ParseReader(strings.NewReader(frontmatter), "demo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first of two duplicate keys wins, aliases resolve, null is absent,
+	// lists are dropped and other scalars are kept as text.
+	if s.Name != "demo" || s.Description != "Shared text" || s.License != "Shared text" || s.Compatibility != "" || s.AllowedTools != "" {
+		t.Errorf("fields = %q %q %q %q %q", s.Name, s.Description, s.License, s.Compatibility, s.AllowedTools)
+	}
+	want := map[string]string{"version": "2", "aglx-ignore": "body-size, hidden-files"}
+	if !reflect.DeepEqual(s.Metadata, want) {
+		t.Errorf("Metadata = %v, want %v", s.Metadata, want)
+	}
+	if len(s.Suppressions) != 2 {
+		t.Errorf("Suppressions = %+v, want 2", s.Suppressions)
+	}
+}
+
+func TestParse_FrontmatterNotMapping(t *testing.T) {
+	_, err := ParseReader(strings.NewReader("---\n- name\n---\n"), "demo")
+	if err == nil || !strings.Contains(err.Error(), "expected a mapping of keys to values, got a list") {
+		t.Errorf("ParseReader() error = %v", err)
+	}
+}
+
+func TestValidate_ClaudeCodeFields(t *testing.T) {
+	frontmatter := "---\nname: demo\ndescription: A demo skill.\nmodel: sonnet\ndisable-model-invocation: true\n---\n"
+	s, err := ParseReader(strings.NewReader(frontmatter), "demo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result := ValidateWithOptions(s, &ValidationOptions{Spec: SpecClaudeCode}); len(result.Errors) != 0 {
+		t.Errorf("claude-code: expected no errors, got %v", result.Errors)
+	}
+
+	var keys []string
+	for _, e := range ValidateWithOptions(s, &ValidationOptions{Spec: SpecAgentSkills}).Errors {
+		if e.Rule == RuleFrontmatterUnknownKey && strings.Contains(e.Message, "Claude Code extension") {
+			keys = append(keys, e.Field)
+		}
+	}
+	if !reflect.DeepEqual(keys, []string{"model", "disable-model-invocation"}) {
+		t.Errorf("agent-skills: extension keys = %v", keys)
+	}
+}
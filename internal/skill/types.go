@@ -1,7 +1,19 @@
 // Package skill provides types and utilities for parsing and validating Agent Skills.
 package skill
 
-import "strings"
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/biwakonbu/aglx/internal/frontmatter"
+	"github.com/biwakonbu/aglx/internal/policy"
+	"github.com/biwakonbu/aglx/internal/source"
+	"github.com/biwakonbu/aglx/internal/suppress"
+	"github.com/biwakonbu/aglx/internal/toolspec"
+)
 
 // Spec represents the specification to validate against.
 type Spec string
@@ -15,11 +27,58 @@ const (
 	SpecClaudeCode Spec = "claude-code"
 )
 
+// ParseSpec converts a user-supplied specification name into a Spec.
+// Both "" and "auto" map to SpecAuto.
+func ParseSpec(s string) (Spec, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "auto":
+		return SpecAuto, nil
+	case string(SpecAgentSkills):
+		return SpecAgentSkills, nil
+	case string(SpecClaudeCode):
+		return SpecClaudeCode, nil
+	default:
+		return SpecAuto, fmt.Errorf("unknown spec %q (expected auto, %s or %s)", s, SpecAgentSkills, SpecClaudeCode)
+	}
+}
+
 // ValidationOptions configures additional validation behavior.
 type ValidationOptions struct {
 	// Spec specifies which specification to validate against.
 	// If empty, both formats are accepted.
 	Spec Spec
+
+	// Severities overrides the default severity of rules, keyed by rule ID.
+	// Setting a rule to SeverityOff disables it.
+	Severities map[string]Severity
+
+	// MaxBodyTokens overrides MaxBodyTokensRecommended for the body-size rule (0 uses the default).
+	MaxBodyTokens int
+
+	// MaxBodyLines overrides MaxBodyLinesClaudeCode for the body-lines rule (0 uses the default).
+	MaxBodyLines int
+
+	// Tools lists custom and MCP tool names known in addition to the
+	// built-in catalogue of the specification (see ToolCatalog).
+	Tools []string
+
+	// Policy is the security policy allowed-tools must satisfy (nil for
+	// none), checked by the allowed-tools-policy rule.
+	Policy *policy.Policy
+}
+
+func (o *ValidationOptions) maxBodyTokens() int {
+	if o.MaxBodyTokens > 0 {
+		return o.MaxBodyTokens
+	}
+	return MaxBodyTokensRecommended
+}
+
+func (o *ValidationOptions) maxBodyLines() int {
+	if o.MaxBodyLines > 0 {
+		return o.MaxBodyLines
+	}
+	return MaxBodyLinesClaudeCode
 }
 
 // Skill represents a parsed SKILL.md file.
@@ -51,64 +110,94 @@ type Skill struct {
 
 	// Path is the directory path containing this skill.
 	Path string `yaml:"-"`
+
+	// FS holds the skill directory's files, rooted at the directory.
+	// If nil, the directory at Path on the OS file system is used.
+	FS fs.FS `yaml:"-"`
+
+	// Positions maps top-level frontmatter keys to the position of their values.
+	Positions map[string]source.Position `yaml:"-"`
+
+	// inlineColumns maps string fields written verbatim on one line to the
+	// column of the first character of their value.
+	inlineColumns map[string]int
+
+	// BodyLine is the 1-based line on which the body starts (0 if unknown).
+	BodyLine int `yaml:"-"`
+
+	// Suppressions lists the rules disabled by inline directives.
+	Suppressions []suppress.Directive `yaml:"-"`
+
+	// FileIssues lists the irregularities found while reading SKILL.md
+	// (byte order mark, CRLF line endings, long lines, unusual delimiters).
+	FileIssues []frontmatter.Issue `yaml:"-"`
+
+	// SchemaProblems lists the unknown, duplicate and mistyped frontmatter
+	// keys found while parsing; the frontmatter-* rules report them.
+	SchemaProblems []SchemaProblem `yaml:"-"`
 }
 
-// ParsedAllowedTools returns the allowed-tools as a slice of strings.
-// It supports both formats:
-// - Agent Skills (agentskills.io): space-separated, e.g., "Bash(ls -la) Read"
-// - Claude Code: comma-separated, e.g., "Read, Grep, Glob"
-// It handles spaces within parentheses, e.g., "Bash(ls -la) Read" will return ["Bash(ls -la)", "Read"].
-func (s *Skill) ParsedAllowedTools() []string {
-	if s.AllowedTools == "" {
-		return nil
+// FilePath returns the path to the SKILL.md file, or "" if Path is unset.
+func (s *Skill) FilePath() string {
+	if s.Path == "" {
+		return ""
 	}
+	return filepath.Join(s.Path, skillFileName)
+}
 
-	// Detect format: if it contains comma followed by space or end, it's Claude Code format
-	isCommaFormat := strings.Contains(s.AllowedTools, ", ") || strings.HasSuffix(s.AllowedTools, ",")
-
-	if isCommaFormat {
-		// Claude Code format: comma-separated
-		parts := strings.Split(s.AllowedTools, ",")
-		var tools []string
-		for _, part := range parts {
-			trimmed := strings.TrimSpace(part)
-			if trimmed != "" {
-				tools = append(tools, trimmed)
-			}
-		}
-		return tools
+// dirFS returns the file system holding the skill directory, or nil if the
+// skill has neither FS nor Path.
+func (s *Skill) dirFS() fs.FS {
+	switch {
+	case s.FS != nil:
+		return s.FS
+	case s.Path != "":
+		return os.DirFS(s.Path)
+	default:
+		return nil
 	}
+}
 
-	// Agent Skills format: space-separated (with parentheses handling)
-	var tools []string
-	var current []rune
-	inParens := false
-
-	for _, c := range s.AllowedTools {
-		switch c {
-		case '(', '[', '{':
-			inParens = true
-			current = append(current, c)
-		case ')', ']', '}':
-			inParens = false
-			current = append(current, c)
-		case ' ', '\t':
-			if inParens {
-				current = append(current, c)
-			} else {
-				if len(current) > 0 {
-					tools = append(tools, string(current))
-					current = nil
-				}
-			}
-		default:
-			current = append(current, c)
-		}
+// FieldPosition returns the source position of a frontmatter field.
+// Fields absent from the frontmatter are reported at the opening delimiter.
+func (s *Skill) FieldPosition(field string) source.Position {
+	if pos, ok := s.Positions[field]; ok {
+		return pos
 	}
+	file := s.FilePath()
+	if file == "" {
+		return source.Position{}
+	}
+	return source.Position{File: file, Line: 1, Column: 1}
+}
 
-	if len(current) > 0 {
-		tools = append(tools, string(current))
+// BodyPosition returns the position where the Markdown body starts.
+func (s *Skill) BodyPosition() source.Position {
+	return source.Position{File: s.FilePath(), Line: s.BodyLine}
+}
+
+// ValuePosition returns the position of the character at the 1-based
+// column col of a field's value. If the value is not written verbatim on
+// one line (quoted with escapes, folded, or on several lines), it returns
+// the position of the value.
+func (s *Skill) ValuePosition(field string, col int) source.Position {
+	pos := s.FieldPosition(field)
+	if start, ok := s.inlineColumns[field]; ok {
+		pos.Column = start + col - 1
 	}
+	return pos
+}
+
+// ParsedAllowedTools returns the allowed-tools as a slice of strings.
+// It supports both formats:
+// - Agent Skills (agentskills.io): space-separated, e.g., "Bash(ls -la) Read"
+// - Claude Code: comma-separated, e.g., "Read, Grep, Glob"
+// It handles spaces within parentheses, e.g., "Bash(ls -la) Read" will return ["Bash(ls -la)", "Read"].
+func (s *Skill) ParsedAllowedTools() []string {
+	return s.Tools().Names()
+}
 
-	return tools
+// Tools returns the allowed-tools parsed into a syntax tree.
+func (s *Skill) Tools() *toolspec.List {
+	return toolspec.Parse(s.AllowedTools)
 }
@@ -0,0 +1,151 @@
+package skill
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// CharCount returns the number of characters in s as the specification's
+// length limits count them: Unicode code points after NFC normalization, so
+// that "é" counts once whether it is stored precomposed or as "e" plus a
+// combining accent, and a Japanese character counts once rather than as its
+// three UTF-8 bytes.
+func CharCount(s string) int {
+	return utf8.RuneCountInString(norm.NFC.String(s))
+}
+
+// confusables maps characters from other scripts to the ASCII letter they
+// are commonly mistaken for. It covers the homoglyphs used in practice to
+// spoof Latin identifiers (a subset of Unicode's confusables.txt).
+var confusables = map[rune]rune{
+	// Cyrillic
+	'\u0430': 'a', '\u0435': 'e', '\u043E': 'o', '\u0440': 'p', '\u0441': 'c',
+	'\u0443': 'y', '\u0445': 'x', '\u0455': 's', '\u0456': 'i', '\u0458': 'j',
+	'\u0501': 'd', '\u051B': 'q', '\u051D': 'w', '\u04BB': 'h', '\u04CF': 'l',
+	'\u0410': 'A', '\u0412': 'B', '\u0415': 'E', '\u041A': 'K', '\u041C': 'M',
+	'\u041D': 'H', '\u041E': 'O', '\u0420': 'P', '\u0421': 'C', '\u0422': 'T',
+	'\u0425': 'X', '\u0423': 'Y', '\u0406': 'I', '\u0408': 'J', '\u0405': 'S',
+	// Greek
+	'\u03B1': 'a', '\u03BF': 'o', '\u03BD': 'v', '\u03C1': 'p', '\u03B9': 'i',
+	'\u03BA': 'k', '\u03C5': 'u',
+	'\u0391': 'A', '\u0392': 'B', '\u0395': 'E', '\u0396': 'Z', '\u0397': 'H',
+	'\u0399': 'I', '\u039A': 'K', '\u039C': 'M', '\u039D': 'N', '\u039F': 'O',
+	'\u03A1': 'P', '\u03A4': 'T', '\u03A5': 'Y', '\u03A7': 'X',
+	// Armenian
+	'\u0585': 'o', '\u057D': 'u', '\u0570': 'h', '\u0578': 'n',
+	// Latin letters outside ASCII
+	'\u0131': 'i', '\u0251': 'a', '\u0261': 'g', '\u028F': 'y',
+}
+
+// Joiners, which are allowed where they shape the surrounding text.
+const (
+	zeroWidthNonJoiner = '\u200C'
+	zeroWidthJoiner    = '\u200D'
+	variationSelector  = '\uFE0F' // emoji presentation
+)
+
+// invisibleNames names the zero-width, invisible formatting and
+// bidirectional control characters that are flagged in text fields.
+var invisibleNames = map[rune]string{
+	'\u00AD': "soft hyphen",
+	'\u034F': "combining grapheme joiner",
+	'\u061C': "arabic letter mark",
+	'\u180E': "mongolian vowel separator",
+	'\u200B': "zero width space",
+	'\u200C': "zero width non-joiner",
+	'\u200D': "zero width joiner",
+	'\u200E': "left-to-right mark",
+	'\u200F': "right-to-left mark",
+	'\u202A': "left-to-right embedding",
+	'\u202B': "right-to-left embedding",
+	'\u202C': "pop directional formatting",
+	'\u202D': "left-to-right override",
+	'\u202E': "right-to-left override",
+	'\u2060': "word joiner",
+	'\u2061': "function application",
+	'\u2062': "invisible times",
+	'\u2063': "invisible separator",
+	'\u2064': "invisible plus",
+	'\u2066': "left-to-right isolate",
+	'\u2067': "right-to-left isolate",
+	'\u2068': "first strong isolate",
+	'\u2069': "pop directional isolate",
+	'\uFEFF': "zero width no-break space",
+}
+
+// describeRune formats a character with its code point, so that look-alike
+// characters can be told apart in messages.
+func describeRune(r rune) string {
+	return fmt.Sprintf("%q (U+%04X)", string(r), r)
+}
+
+// findInvisible returns a message for each invisible character in s.
+// Joiners that are part of the text are allowed: a zero width joiner
+// between emoji (e.g. family emoji), and joiners between letters of scripts
+// that use them for shaping (e.g. Persian or Devanagari).
+func findInvisible(s string) []string {
+	runes := []rune(s)
+	var found []string
+	for i, r := range runes {
+		name, ok := invisibleNames[r]
+		if !ok {
+			continue
+		}
+		if (r == zeroWidthNonJoiner || r == zeroWidthJoiner) && i > 0 && i+1 < len(runes) && joinsText(runes[i-1], runes[i+1], r) {
+			continue
+		}
+		found = append(found, fmt.Sprintf("contains invisible character U+%04X (%s) at character %d", r, name, i+1))
+	}
+	return found
+}
+
+// joinsText reports whether a joiner between prev and next is part of the text.
+func joinsText(prev, next, joiner rune) bool {
+	if joiner == zeroWidthJoiner && isEmojiPart(prev) && isEmojiPart(next) {
+		return true
+	}
+	return isNonLatinLetter(prev) && isNonLatinLetter(next)
+}
+
+func isEmojiPart(r rune) bool {
+	// Emoji are symbols (So); sequences may end in a variation selector or a
+	// skin tone modifier (Sk).
+	return unicode.Is(unicode.So, r) || unicode.Is(unicode.Sk, r) || r == variationSelector
+}
+
+func isNonLatinLetter(r rune) bool {
+	return (unicode.IsLetter(r) || unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Mc, r)) && !unicode.Is(unicode.Latin, r)
+}
+
+// mixedScriptWords returns a message for each word of s that combines
+// ASCII letters with look-alike characters from another script, such as
+// "paypal" spelled with a Cyrillic "a" (U+0430). Words written entirely in
+// one script are not reported.
+func mixedScriptWords(s string) []string {
+	var found []string
+	seen := make(map[string]bool)
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+	})
+	for _, word := range words {
+		var hasASCII bool
+		var lookalike rune
+		for _, r := range word {
+			if r < utf8.RuneSelf && unicode.IsLetter(r) {
+				hasASCII = true
+			} else if _, ok := confusables[r]; ok && lookalike == 0 {
+				lookalike = r
+			}
+		}
+		if !hasASCII || lookalike == 0 || seen[word] {
+			continue
+		}
+		seen[word] = true
+		found = append(found, fmt.Sprintf("contains %q, which mixes Latin letters with %s that looks like %q", word, describeRune(lookalike), string(confusables[lookalike])))
+	}
+	return found
+}
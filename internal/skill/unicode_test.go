@@ -0,0 +1,159 @@
+package skill
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCharCount(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"", 0},
+		{"pdf-processing", 14},
+		{"日本語の説明", 6},
+		{"café", 4},
+		{"cafe\u0301", 4}, // decomposed é counts as one character
+		{"Привет", 6},
+	}
+	for _, tt := range tests {
+		if got := CharCount(tt.in); got != tt.want {
+			t.Errorf("CharCount(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestValidate_MultibyteLengths(t *testing.T) {
+	// 400 Japanese characters are 1200 bytes in UTF-8.
+	desc := strings.Repeat("説", 400)
+	result := Validate(&Skill{Name: "test-skill", Description: desc, Path: "/path/to/test-skill"})
+	if !result.IsValid() {
+		t.Errorf("expected 400-character Japanese description to be valid, got: %v", result.Errors)
+	}
+
+	result = Validate(&Skill{Name: "test-skill", Description: strings.Repeat("説", 1025), Path: "/path/to/test-skill"})
+	if !hasFinding(result.Errors, RuleDescriptionLength, "got 1025") {
+		t.Errorf("expected description length error counting characters, got: %v", result.Errors)
+	}
+
+	result = Validate(&Skill{Name: "test-skill", Description: "Some description", Compatibility: strings.Repeat("é", 500), Path: "/path/to/test-skill"})
+	if !result.IsValid() {
+		t.Errorf("expected 500-character compatibility to be valid, got: %v", result.Errors)
+	}
+}
+
+func TestValidate_UnicodeRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		skill       *Skill
+		rule        string
+		wantMessage string
+	}{
+		{
+			name:        "cyrillic letter in name",
+			skill:       &Skill{Name: "pаyments", Description: "Some description"},
+			rule:        RuleNameConfusables,
+			wantMessage: `"а" (U+0430), which looks like "a"`,
+		},
+		{
+			name:        "greek letter in name",
+			skill:       &Skill{Name: "οcr-tool", Description: "Some description"},
+			rule:        RuleNameConfusables,
+			wantMessage: `"ο" (U+03BF), which looks like "o"`,
+		},
+		{
+			name:        "full-width name",
+			skill:       &Skill{Name: "ｐdf", Description: "Some description"},
+			rule:        RuleNameNormalization,
+			wantMessage: `normalizes to "pdf"`,
+		},
+		{
+			name:        "zero width space in name",
+			skill:       &Skill{Name: "pdf\u200Btools", Description: "Some description"},
+			rule:        RuleInvisibleCharacters,
+			wantMessage: "U+200B (zero width space) at character 4",
+		},
+		{
+			name:        "bidi override in description",
+			skill:       &Skill{Name: "test-skill", Description: "Runs \u202Eexe.txt"},
+			rule:        RuleInvisibleCharacters,
+			wantMessage: "U+202E (right-to-left override)",
+		},
+		{
+			name:        "decomposed description",
+			skill:       &Skill{Name: "test-skill", Description: "Formats a re\u0301sume\u0301"},
+			rule:        RuleDescriptionNormalization,
+			wantMessage: "NFC",
+		},
+		{
+			name:        "mixed-script word in description",
+			skill:       &Skill{Name: "test-skill", Description: "Log in to PаyPal"},
+			rule:        RuleDescriptionConfusables,
+			wantMessage: `"PаyPal", which mixes Latin letters with "а" (U+0430)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.skill.Path = "/path/to/" + tt.skill.Name
+			result := Validate(tt.skill)
+			all := append(result.Errors, result.Warnings...)
+			if !hasFinding(all, tt.rule, tt.wantMessage) {
+				t.Errorf("expected %s finding containing %q, got: %v", tt.rule, tt.wantMessage, all)
+			}
+		})
+	}
+}
+
+func TestValidate_UnicodeAllowed(t *testing.T) {
+	descriptions := []string{
+		"PDFファイルからテキストを抽出します。（全角の括弧）",
+		"Обработка текста и таблиц",
+		"Επεξεργασία κειμένου",
+		"Résumé and café in NFC",
+		"فارسی: می\u200Cخواهم",                              // ZWNJ inside a Persian word
+		"हिन्दी: क्\u200Dष",                                 // ZWJ in a Devanagari conjunct
+		"Family \U0001F468\u200D\U0001F469\u200D\U0001F467", // emoji ZWJ sequence
+		"Rainbow flag \U0001F3F3\uFE0F\u200D\U0001F308",
+		"Translate English and Русский",
+	}
+	for _, desc := range descriptions {
+		result := Validate(&Skill{Name: "test-skill", Description: desc, Path: "/path/to/test-skill"})
+		if len(result.Errors) != 0 || len(result.Warnings) != 0 {
+			t.Errorf("Validate(%q) reported %v %v, want no findings", desc, result.Errors, result.Warnings)
+		}
+	}
+}
+
+func TestValidate_NameConfusablesOnce(t *testing.T) {
+	result := Validate(&Skill{Name: "раура", Description: "Some description", Path: "/path/to/раура"})
+	var messages []string
+	for _, e := range result.Errors {
+		if e.Rule == RuleNameConfusables {
+			messages = append(messages, e.Message)
+		}
+	}
+	want := `contains "р" (U+0440), which looks like "p"; "а" (U+0430), which looks like "a"; "у" (U+0443), which looks like "y"`
+	if len(messages) != 1 || messages[0] != want {
+		t.Errorf("name-confusables findings = %q, want [%q]", messages, want)
+	}
+}
+
+func TestValidateName_Unicode(t *testing.T) {
+	var rules []string
+	for _, e := range ValidateName("pаy", SpecAgentSkills) {
+		rules = append(rules, e.Rule)
+	}
+	if strings.Join(rules, ",") != RuleNameConfusables {
+		t.Errorf("rules = %v, want [%s]", rules, RuleNameConfusables)
+	}
+}
+
+func hasFinding(findings []ValidationError, rule, substr string) bool {
+	for _, f := range findings {
+		if f.Rule == rule && strings.Contains(f.Message, substr) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,19 +2,30 @@
 package skill
 
 import (
+	"context"
 	"fmt"
-	"io"
-	"os"
+	"io/fs"
 	"path/filepath"
 	"regexp"
 	"strings"
 	"unicode"
+
+	"github.com/biwakonbu/aglx/internal/parallel"
+	"github.com/biwakonbu/aglx/internal/source"
+	"github.com/biwakonbu/aglx/internal/suppress"
+	"github.com/biwakonbu/aglx/internal/toolspec"
+	"golang.org/x/text/unicode/norm"
 )
 
-// ValidationError represents a single validation error.
+// ValidationError represents a single validation finding (error or warning).
 type ValidationError struct {
+	// Rule is the ID of the rule that produced the finding.
+	Rule    string
 	Field   string
 	Message string
+
+	// Pos is the location of the finding (file, line and column when known).
+	Pos source.Position
 }
 
 func (e ValidationError) Error() string {
@@ -45,6 +56,8 @@ func Validate(skill *Skill) *ValidationResult {
 }
 
 // ValidateWithOptions checks if a skill conforms to the specification with custom options.
+// Every registered rule that applies to opts.Spec runs in registration order,
+// except unused-suppression, which runs last so that it sees every other finding.
 func ValidateWithOptions(skill *Skill, opts *ValidationOptions) *ValidationResult {
 	result := &ValidationResult{Skill: skill}
 
@@ -52,56 +65,151 @@ func ValidateWithOptions(skill *Skill, opts *ValidationOptions) *ValidationResul
 		opts = &ValidationOptions{}
 	}
 
-	// Validate name (required)
-	validateName(skill, result, opts)
-
-	// Validate description (required)
-	validateDescription(skill, result, opts)
+	suppressions := suppress.NewTracker(skill.Suppressions)
+	run := func(rule Rule) {
+		if !rule.AppliesTo(opts.Spec) {
+			return
+		}
+		severity := effectiveSeverity(rule, opts)
+		if severity == SeverityOff {
+			return
+		}
+		rule.Check(&RuleContext{
+			Skill:        skill,
+			Options:      opts,
+			rule:         &rule,
+			severity:     severity,
+			result:       result,
+			suppressions: suppressions,
+		})
+	}
 
-	// Validate compatibility (optional, but has constraints if present)
-	validateCompatibility(skill, result)
+	var deferred []Rule
+	for _, rule := range Rules() {
+		if rule.ID == RuleUnusedSuppression {
+			deferred = append(deferred, rule)
+			continue
+		}
+		run(rule)
+	}
+	for _, rule := range deferred {
+		run(rule)
+	}
 
-	// Validate name matches directory name
-	validateDirectoryMatch(skill, result)
+	return result
+}
 
-	// Validate allowed-tools (optional, experimental)
-	validateAllowedTools(skill, result, opts)
+// Built-in rule IDs.
+const (
+	RuleFileFormat               = "file-format"
+	RuleFrontmatterUnknownKey    = "frontmatter-unknown-key"
+	RuleFrontmatterDuplicateKey  = "frontmatter-duplicate-key"
+	RuleFrontmatterType          = "frontmatter-type"
+	RuleMetadataType             = "metadata-type"
+	RuleNameRequired             = "name-required"
+	RuleNameLength               = "name-length"
+	RuleNameFormat               = "name-format"
+	RuleNameXMLTags              = "name-xml-tags"
+	RuleNameReservedWords        = "name-reserved-words"
+	RuleNameNormalization        = "name-normalization"
+	RuleNameConfusables          = "name-confusables"
+	RuleDescriptionRequired      = "description-required"
+	RuleDescriptionLength        = "description-length"
+	RuleDescriptionXMLTags       = "description-xml-tags"
+	RuleDescriptionNormalization = "description-normalization"
+	RuleDescriptionConfusables   = "description-confusables"
+	RuleInvisibleCharacters      = "invisible-characters"
+	RuleCompatibilityLength      = "compatibility-length"
+	RuleNameDirMatch             = "name-dir-match"
+	RuleAllowedToolsSeparator    = "allowed-tools-separator"
+	RuleAllowedToolsSyntax       = "allowed-tools-syntax"
+	RuleAllowedToolsUnknown      = "allowed-tools-unknown"
+	RuleAllowedToolsPolicy       = "allowed-tools-policy"
+	RuleOptionalDirs             = "optional-dirs"
+	RuleOrphanedResources        = "orphaned-resources"
+	RuleHiddenFiles              = "hidden-files"
+	RuleReferenceMissing         = "reference-missing"
+	RuleReferenceOutside         = "reference-outside"
+	RuleReferenceCase            = "reference-case"
+	RuleBodySize                 = "body-size"
+	RuleBodyLines                = "body-lines"
+	RuleUnusedSuppression        = "unused-suppression"
+)
 
-	// Validate optional directories
-	validateOptionalDirectories(skill, result)
+func init() {
+	claudeCodeOnly := []Spec{SpecClaudeCode}
+
+	for _, rule := range []Rule{
+		{ID: RuleFileFormat, Description: "SKILL.md should be plain UTF-8 with LF line endings and \"---\" delimiters", DefaultSeverity: SeverityWarning, Check: validateFileFormat},
+		{ID: RuleFrontmatterUnknownKey, Description: "frontmatter must only use the keys of the selected specification", DefaultSeverity: SeverityError, Check: reportSchemaProblems(RuleFrontmatterUnknownKey)},
+		{ID: RuleFrontmatterDuplicateKey, Description: "frontmatter keys must not be repeated", DefaultSeverity: SeverityError, Check: reportSchemaProblems(RuleFrontmatterDuplicateKey)},
+		{ID: RuleFrontmatterType, Description: "frontmatter values must have the documented type (string, boolean or mapping)", DefaultSeverity: SeverityError, Check: reportSchemaProblems(RuleFrontmatterType)},
+		{ID: RuleMetadataType, Description: "metadata must map string keys to string values", DefaultSeverity: SeverityError, Check: reportSchemaProblems(RuleMetadataType)},
+		{ID: RuleNameRequired, Description: "name is required", DefaultSeverity: SeverityError, Check: validateNameRequired},
+		{ID: RuleNameLength, Description: "name must be 1-64 characters", DefaultSeverity: SeverityError, Check: validateNameLength},
+		{ID: RuleNameFormat, Description: "name must be lowercase alphanumeric with single inner hyphens", DefaultSeverity: SeverityError, Check: validateNameFormat},
+		{ID: RuleNameXMLTags, Description: "name must not contain XML tags", DefaultSeverity: SeverityError, Specs: claudeCodeOnly, Check: validateNameXMLTags},
+		{ID: RuleNameReservedWords, Description: "name must not contain 'anthropic' or 'claude'", DefaultSeverity: SeverityError, Specs: claudeCodeOnly, Check: validateNameReservedWords},
+		{ID: RuleNameNormalization, Description: "name must be NFKC-normalized (no full-width or compatibility characters)", DefaultSeverity: SeverityError, Check: validateNameNormalization},
+		{ID: RuleNameConfusables, Description: "name must not contain characters that look like ASCII letters", DefaultSeverity: SeverityError, Check: validateNameConfusables},
+		{ID: RuleDescriptionRequired, Description: "description is required", DefaultSeverity: SeverityError, Check: validateDescriptionRequired},
+		{ID: RuleDescriptionLength, Description: "description must be 1-1024 characters", DefaultSeverity: SeverityError, Check: validateDescriptionLength},
+		{ID: RuleDescriptionXMLTags, Description: "description must not contain XML tags", DefaultSeverity: SeverityError, Specs: claudeCodeOnly, Check: validateDescriptionXMLTags},
+		{ID: RuleDescriptionNormalization, Description: "description should be NFC-normalized", DefaultSeverity: SeverityWarning, Check: validateDescriptionNormalization},
+		{ID: RuleDescriptionConfusables, Description: "description should not mix Latin letters with look-alike characters", DefaultSeverity: SeverityWarning, Check: validateDescriptionConfusables},
+		{ID: RuleInvisibleCharacters, Description: "name and description should not contain zero-width or bidirectional control characters", DefaultSeverity: SeverityWarning, Check: validateInvisibleCharacters},
+		{ID: RuleCompatibilityLength, Description: "compatibility must be 1-500 characters if present", DefaultSeverity: SeverityError, Check: validateCompatibility},
+		{ID: RuleNameDirMatch, Description: "name must match the parent directory name", DefaultSeverity: SeverityError, Check: validateDirectoryMatch},
+		{ID: RuleAllowedToolsSeparator, Description: "allowed-tools must use the separator of the selected specification", DefaultSeverity: SeverityError, Specs: []Spec{SpecAgentSkills, SpecClaudeCode}, Check: validateAllowedToolsSeparator},
+		{ID: RuleAllowedToolsSyntax, Description: "allowed-tools entries must be ToolName or ToolName(pattern) with balanced brackets and a valid pattern", DefaultSeverity: SeverityError, Check: validateAllowedToolsSyntax},
+		{ID: RuleAllowedToolsUnknown, Description: "allowed-tools should only name tools in the catalogue of the specification or the config", DefaultSeverity: SeverityWarning, Check: validateAllowedToolsKnown},
+		{ID: RuleAllowedToolsPolicy, Description: "allowed-tools must satisfy the security policy (--policy or policy in .aglx.yaml)", DefaultSeverity: SeverityError, Check: validateAllowedToolsPolicy},
+		{ID: RuleOptionalDirs, Description: "scripts/, assets/ and references/ must be non-empty directories if present", DefaultSeverity: SeverityError, Check: validateOptionalDirectories},
+		{ID: RuleOrphanedResources, Description: "files in scripts/, assets/ and references/ should be referenced from the body or the files it references", DefaultSeverity: SeverityWarning, Check: validateOrphanedResources},
+		{ID: RuleHiddenFiles, Description: "optional directories should not contain hidden files", DefaultSeverity: SeverityWarning, Check: checkForHiddenFiles},
+		{ID: RuleReferenceMissing, Description: "files linked or mentioned in the body (scripts/, references/, assets/) must exist", DefaultSeverity: SeverityError, Check: validateReferences(referenceMissing)},
+		{ID: RuleReferenceOutside, Description: "body links must stay inside the skill directory", DefaultSeverity: SeverityError, Check: validateReferences(referenceOutside)},
+		{ID: RuleReferenceCase, Description: "body references must match the case of the file names", DefaultSeverity: SeverityError, Check: validateReferences(referenceCaseMismatch)},
+		{ID: RuleBodySize, Description: "body should stay under the recommended token budget", DefaultSeverity: SeverityWarning, Check: validateBodySize},
+		{ID: RuleBodyLines, Description: "body should stay under the recommended line count for Claude Code", DefaultSeverity: SeverityWarning, Specs: claudeCodeOnly, Check: validateBodyLines},
+		{ID: RuleUnusedSuppression, Description: "suppression directives should name a known rule that reports something", DefaultSeverity: SeverityWarning, Check: checkUnusedSuppressions},
+	} {
+		MustRegister(rule)
+	}
+}
 
-	// Validate body size (warning)
-	validateBodySize(skill, result, opts)
+func validateFileFormat(ctx *RuleContext) {
+	for _, issue := range ctx.Skill.FileIssues {
+		ctx.Report("file", issue.Message, source.Position{File: ctx.Skill.FilePath(), Line: issue.Line})
+	}
+}
 
-	return result
+func validateNameRequired(ctx *RuleContext) {
+	if ctx.Skill.Name == "" {
+		ctx.Report("name", "is required", ctx.Skill.FieldPosition("name"))
+	}
 }
 
-func validateName(skill *Skill, result *ValidationResult, opts *ValidationOptions) {
-	name := skill.Name
+func validateNameLength(ctx *RuleContext) {
+	name := ctx.Skill.Name
 
-	if name == "" {
-		result.Errors = append(result.Errors, ValidationError{
-			Field:   "name",
-			Message: "is required",
-		})
-		return
+	// Length check: 1-64 characters
+	if n := CharCount(name); n > MaxNameLength {
+		ctx.Report("name", fmt.Sprintf("must be 1-64 characters (got %d)", n), ctx.Skill.FieldPosition("name"))
 	}
+}
 
-	// Length check: 1-64 characters
-	if len(name) > 64 {
-		result.Errors = append(result.Errors, ValidationError{
-			Field:   "name",
-			Message: fmt.Sprintf("must be 1-64 characters (got %d)", len(name)),
-		})
+func validateNameFormat(ctx *RuleContext) {
+	name := ctx.Skill.Name
+	if name == "" {
+		return
 	}
+	pos := ctx.Skill.FieldPosition("name")
 
 	// Check for uppercase characters
 	for _, r := range name {
 		if unicode.IsUpper(r) {
-			result.Errors = append(result.Errors, ValidationError{
-				Field:   "name",
-				Message: "must be lowercase (uppercase characters not allowed)",
-			})
+			ctx.Report("name", "must be lowercase (uppercase characters not allowed)", pos)
 			break
 		}
 	}
@@ -109,223 +217,293 @@ func validateName(skill *Skill, result *ValidationResult, opts *ValidationOption
 	// Check for invalid characters (only lowercase alphanumeric and hyphens allowed)
 	for _, r := range name {
 		if !unicode.IsLower(r) && !unicode.IsDigit(r) && r != '-' {
-			result.Errors = append(result.Errors, ValidationError{
-				Field:   "name",
-				Message: "may only contain lowercase alphanumeric characters (a-z, 0-9) and hyphens (-)",
-			})
+			ctx.Report("name", "may only contain lowercase alphanumeric characters (a-z, 0-9) and hyphens (-)", pos)
 			break
 		}
 	}
 
 	// Check for leading/trailing hyphens
 	if strings.HasPrefix(name, "-") {
-		result.Errors = append(result.Errors, ValidationError{
-			Field:   "name",
-			Message: "must not start with a hyphen",
-		})
+		ctx.Report("name", "must not start with a hyphen", pos)
 	}
 	if strings.HasSuffix(name, "-") {
-		result.Errors = append(result.Errors, ValidationError{
-			Field:   "name",
-			Message: "must not end with a hyphen",
-		})
+		ctx.Report("name", "must not end with a hyphen", pos)
 	}
 
 	// Check for consecutive hyphens
 	if strings.Contains(name, "--") {
-		result.Errors = append(result.Errors, ValidationError{
-			Field:   "name",
-			Message: "must not contain consecutive hyphens (--)",
-		})
+		ctx.Report("name", "must not contain consecutive hyphens (--)", pos)
 	}
+}
 
-	// Claude Code specific validations
-	if opts.Spec == SpecClaudeCode {
-		// Check for XML tags (error)
-		if containsXMLTags(name) {
-			result.Errors = append(result.Errors, ValidationError{
-				Field:   "name",
-				Message: "must not contain XML tags",
-			})
-		}
+func validateNameXMLTags(ctx *RuleContext) {
+	if containsXMLTags(ctx.Skill.Name) {
+		ctx.Report("name", "must not contain XML tags", ctx.Skill.FieldPosition("name"))
+	}
+}
 
-		// Check for reserved words: "anthropic", "claude" (error)
-		lowerName := strings.ToLower(name)
-		if strings.Contains(lowerName, "anthropic") || strings.Contains(lowerName, "claude") {
-			result.Errors = append(result.Errors, ValidationError{
-				Field:   "name",
-				Message: "must not contain reserved words 'anthropic' or 'claude'",
-			})
-		}
+func validateNameReservedWords(ctx *RuleContext) {
+	lowerName := strings.ToLower(ctx.Skill.Name)
+	if strings.Contains(lowerName, "anthropic") || strings.Contains(lowerName, "claude") {
+		ctx.Report("name", "must not contain reserved words 'anthropic' or 'claude'", ctx.Skill.FieldPosition("name"))
 	}
 }
 
-func validateDescription(skill *Skill, result *ValidationResult, opts *ValidationOptions) {
-	desc := skill.Description
+func validateNameNormalization(ctx *RuleContext) {
+	name := ctx.Skill.Name
+	if normalized := norm.NFKC.String(name); normalized != name {
+		ctx.Report("name", fmt.Sprintf("must be NFKC-normalized (%q normalizes to %q)", name, normalized), ctx.Skill.FieldPosition("name"))
+	}
+}
 
-	if desc == "" {
-		result.Errors = append(result.Errors, ValidationError{
-			Field:   "description",
-			Message: "is required",
-		})
-		return
+// validateNameConfusables reports the look-alike characters of the name in
+// one finding, each listed once.
+func validateNameConfusables(ctx *RuleContext) {
+	var found []string
+	seen := make(map[rune]bool)
+	for _, r := range ctx.Skill.Name {
+		ascii, ok := confusables[r]
+		if !ok || seen[r] {
+			continue
+		}
+		seen[r] = true
+		found = append(found, fmt.Sprintf("%s, which looks like %q", describeRune(r), string(ascii)))
+	}
+	if len(found) > 0 {
+		ctx.Report("name", "contains "+strings.Join(found, "; "), ctx.Skill.FieldPosition("name"))
 	}
+}
+
+func validateDescriptionRequired(ctx *RuleContext) {
+	if ctx.Skill.Description == "" {
+		ctx.Report("description", "is required", ctx.Skill.FieldPosition("description"))
+	}
+}
+
+func validateDescriptionLength(ctx *RuleContext) {
+	desc := ctx.Skill.Description
 
 	// Length check: 1-1024 characters
-	if len(desc) > 1024 {
-		result.Errors = append(result.Errors, ValidationError{
-			Field:   "description",
-			Message: fmt.Sprintf("must be 1-1024 characters (got %d)", len(desc)),
-		})
+	if n := CharCount(desc); n > MaxDescriptionLength {
+		ctx.Report("description", fmt.Sprintf("must be 1-1024 characters (got %d)", n), ctx.Skill.FieldPosition("description"))
+	}
+}
+
+func validateDescriptionXMLTags(ctx *RuleContext) {
+	if containsXMLTags(ctx.Skill.Description) {
+		ctx.Report("description", "must not contain XML tags", ctx.Skill.FieldPosition("description"))
 	}
+}
 
-	// Claude Code specific validations
-	if opts.Spec == SpecClaudeCode {
-		// Check for XML tags (error)
-		if containsXMLTags(desc) {
-			result.Errors = append(result.Errors, ValidationError{
-				Field:   "description",
-				Message: "must not contain XML tags",
-			})
+// validateDescriptionNormalization checks for canonical (NFC) form only:
+// NFKC would also rewrite the full-width punctuation and symbols that are
+// normal in CJK text.
+func validateDescriptionNormalization(ctx *RuleContext) {
+	if desc := ctx.Skill.Description; !norm.NFC.IsNormalString(desc) {
+		ctx.Report("description", "should be NFC-normalized (it contains decomposed characters, e.g. \"e\" followed by a combining accent)", ctx.Skill.FieldPosition("description"))
+	}
+}
+
+func validateDescriptionConfusables(ctx *RuleContext) {
+	for _, msg := range mixedScriptWords(ctx.Skill.Description) {
+		ctx.Report("description", msg, ctx.Skill.FieldPosition("description"))
+	}
+}
+
+func validateInvisibleCharacters(ctx *RuleContext) {
+	for _, field := range []struct{ name, value string }{
+		{"name", ctx.Skill.Name},
+		{"description", ctx.Skill.Description},
+		{"compatibility", ctx.Skill.Compatibility},
+	} {
+		for _, msg := range findInvisible(field.value) {
+			ctx.Report(field.name, msg, ctx.Skill.FieldPosition(field.name))
 		}
 	}
 }
 
-func validateCompatibility(skill *Skill, result *ValidationResult) {
-	compat := skill.Compatibility
+func validateCompatibility(ctx *RuleContext) {
+	compat := ctx.Skill.Compatibility
 
 	if compat == "" {
 		return // Optional field
 	}
 
 	// Length check: 1-500 characters
-	if len(compat) > 500 {
-		result.Errors = append(result.Errors, ValidationError{
-			Field:   "compatibility",
-			Message: fmt.Sprintf("must be 1-500 characters (got %d)", len(compat)),
-		})
+	if n := CharCount(compat); n > MaxCompatibilityLength {
+		ctx.Report("compatibility", fmt.Sprintf("must be 1-500 characters (got %d)", n), ctx.Skill.FieldPosition("compatibility"))
 	}
 }
 
-func validateDirectoryMatch(skill *Skill, result *ValidationResult) {
+func validateDirectoryMatch(ctx *RuleContext) {
+	skill := ctx.Skill
 	if skill.Path == "" || skill.Name == "" {
 		return // Can't validate without path or name
 	}
 
 	dirName := filepath.Base(skill.Path)
 	if dirName != skill.Name {
-		result.Errors = append(result.Errors, ValidationError{
-			Field:   "name",
-			Message: fmt.Sprintf("must match parent directory name (expected %q, got %q)", dirName, skill.Name),
-		})
+		ctx.Report("name", fmt.Sprintf("must match parent directory name (expected %q, got %q)", dirName, skill.Name), skill.FieldPosition("name"))
 	}
 }
 
-// toolPattern matches valid tool names: letters, digits, hyphens, and underscores.
-// It can optionally include arguments in parentheses like ToolName(arg:*)
-// Examples: Read, Bash(git:*), mcp__figma-desktop, mcp__chrome-devtools
-var toolPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*(\(.*\))?$`)
-
-func validateAllowedTools(skill *Skill, result *ValidationResult, opts *ValidationOptions) {
+func validateAllowedToolsSeparator(ctx *RuleContext) {
+	skill := ctx.Skill
 	if skill.AllowedTools == "" {
 		return
 	}
 
-	// Check format based on specification
-	if opts.Spec != SpecAuto {
-		isCommaFormat := strings.Contains(skill.AllowedTools, ", ") || strings.Contains(skill.AllowedTools, ",")
-		isSpaceFormat := !isCommaFormat
+	separator := skill.Tools().Separator
+	pos := skill.FieldPosition("allowed-tools")
 
-		switch opts.Spec {
-		case SpecAgentSkills:
-			if isCommaFormat {
-				result.Errors = append(result.Errors, ValidationError{
-					Field:   "allowed-tools",
-					Message: "must use space-separated format for Agent Skills specification (e.g., 'Read Glob Grep')",
-				})
-				return
-			}
-		case SpecClaudeCode:
-			if isSpaceFormat && len(skill.ParsedAllowedTools()) > 1 {
-				result.Errors = append(result.Errors, ValidationError{
-					Field:   "allowed-tools",
-					Message: "must use comma-separated format for Claude Code specification (e.g., 'Read, Grep, Glob')",
-				})
-				return
-			}
+	switch ctx.Options.Spec {
+	case SpecAgentSkills:
+		if separator == toolspec.SeparatorComma {
+			ctx.Report("allowed-tools", "must use space-separated format for Agent Skills specification (e.g., 'Read Glob Grep')", pos)
+		}
+	case SpecClaudeCode:
+		if separator == toolspec.SeparatorSpace {
+			ctx.Report("allowed-tools", "must use comma-separated format for Claude Code specification (e.g., 'Read, Grep, Glob')", pos)
 		}
 	}
+}
+
+// validateAllowedToolsSyntax reports the syntax errors found by the
+// allowed-tools parser at the character they refer to.
+func validateAllowedToolsSyntax(ctx *RuleContext) {
+	for _, e := range ctx.Skill.Tools().Errors {
+		ctx.Report("allowed-tools", e.Error(), ctx.Skill.ValuePosition("allowed-tools", e.Column()))
+	}
+}
 
-	// Validate individual tool names
-	tools := skill.ParsedAllowedTools()
-	for _, tool := range tools {
-		if !toolPattern.MatchString(tool) {
-			result.Errors = append(result.Errors, ValidationError{
-				Field:   "allowed-tools",
-				Message: fmt.Sprintf("invalid tool format: %q (must be alphanumeric or ToolName(args))", tool),
-			})
+// toolCatalogs are the built-in tool catalogues by specification. The Agent
+// Skills specification leaves the meaning of allowed-tools to each agent and
+// its examples use Claude Code names, so it shares Claude Code's catalogue.
+var toolCatalogs = map[Spec]*toolspec.Catalog{
+	SpecAgentSkills: toolspec.ClaudeCode,
+	SpecClaudeCode:  toolspec.ClaudeCode,
+}
+
+// ToolCatalog returns the tools known when validating against spec: the
+// built-in catalogue of the specification extended with extra, the custom and MCP tools of a project.
+func ToolCatalog(spec Spec, extra []string) *toolspec.Catalog {
+	catalog, ok := toolCatalogs[spec]
+	if !ok {
+		catalog = toolspec.ClaudeCode // SpecAuto
+	}
+	return catalog.Extend(extra...)
+}
+
+// validateAllowedToolsKnown warns about tools missing from the catalogue,
+// which grant nothing (a misspelled "Raed" or a lowercase "bash").
+func validateAllowedToolsKnown(ctx *RuleContext) {
+	catalog := ToolCatalog(ctx.Options.Spec, ctx.Options.Tools)
+	list := ctx.Skill.Tools()
+	for _, tool := range list.Tools {
+		if tool.Name == "" || catalog.Known(tool) {
+			continue
+		}
+		msg := fmt.Sprintf("unknown tool %q (not a %s tool; add custom and MCP tools to tools in .aglx.yaml)", tool.Name, catalog)
+		if s := catalog.Suggest(tool.Name); s != "" {
+			msg = fmt.Sprintf("unknown tool %q (did you mean %q?)", tool.Name, s)
 		}
+		ctx.Report("allowed-tools", msg, ctx.Skill.ValuePosition("allowed-tools", list.Column(tool.Offset)))
+	}
+}
+
+// validateAllowedToolsPolicy reports the entries that break the security
+// policy, naming the policy rule.
+func validateAllowedToolsPolicy(ctx *RuleContext) {
+	if ctx.Options.Policy == nil {
+		return
+	}
+	list := ctx.Skill.Tools()
+	for _, v := range ctx.Options.Policy.Check(list) {
+		ctx.Report("allowed-tools", fmt.Sprintf("violates policy rule %q: %s", v.Rule, v.Message), ctx.Skill.ValuePosition("allowed-tools", list.Column(v.Offset)))
 	}
 }
 
-func validateOptionalDirectories(skill *Skill, result *ValidationResult) {
-	if skill.Path == "" {
+// OptionalDirs are the optional resource directories defined by the specification.
+var OptionalDirs = []string{"scripts", "assets", "references"}
+
+func validateOptionalDirectories(ctx *RuleContext) {
+	fsys := ctx.Skill.dirFS()
+	if fsys == nil {
 		return
 	}
 
-	optionalDirs := []string{"scripts", "assets", "references"}
-	for _, dir := range optionalDirs {
-		dirPath := filepath.Join(skill.Path, dir)
-		info, err := os.Stat(dirPath)
+	for _, dir := range OptionalDirs {
+		dirPath := filepath.Join(ctx.Skill.Path, dir)
+		info, err := fs.Stat(fsys, dir)
 		if err != nil {
-			if os.IsNotExist(err) {
-				continue
-			}
-			// Other error, maybe permission?
+			// Missing (or unreadable) optional directories are fine
 			continue
 		}
 
 		if !info.IsDir() {
-			result.Errors = append(result.Errors, ValidationError{
-				Field:   dir,
-				Message: "must be a directory if present (found a file)",
-			})
+			ctx.Report(dir, "must be a directory if present (found a file)", source.Position{File: dirPath})
 			continue
 		}
 
 		// Check if directory is empty
-		f, err := os.Open(dirPath)
-		if err != nil {
+		files, err := fs.ReadDir(fsys, dir)
+		if err == nil && len(files) == 0 {
+			ctx.Report(dir, "must not be empty if present", source.Position{File: dirPath})
+		}
+	}
+}
+
+// validateOrphanedResources warns about the files of the optional
+// directories that nothing reachable from the body references: they are
+// bundled with the skill but an agent never learns about them. Hidden files
+// are left to hidden-files.
+func validateOrphanedResources(ctx *RuleContext) {
+	fsys := readableDirFS(ctx.Skill)
+	if fsys == nil {
+		return
+	}
+
+	var reached map[string]bool
+	for _, dir := range OptionalDirs {
+		if info, err := fs.Stat(fsys, dir); err != nil || !info.IsDir() {
 			continue
 		}
-		defer f.Close()
-
-		_, err = f.Readdirnames(1)
-		if err == io.EOF {
-			result.Errors = append(result.Errors, ValidationError{
-				Field:   dir,
-				Message: "must not be empty if present",
-			})
+		if reached == nil {
+			reached = reachableFiles(fsys, ctx.Skill)
 		}
-		f.Close() // Close early as we're in a loop
-
-		// Check for hidden files (warning)
-		checkForHiddenFiles(dirPath, dir, result)
+		_ = fs.WalkDir(fsys, dir, func(name string, d fs.DirEntry, err error) error {
+			switch {
+			case err != nil:
+				return nil
+			case strings.HasPrefix(d.Name(), "."):
+				if d.IsDir() {
+					return fs.SkipDir
+				}
+			case !d.IsDir() && !reached[name]:
+				ctx.Report(dir, fmt.Sprintf("%q is not referenced from SKILL.md or the files it references", name), source.Position{File: filepath.Join(ctx.Skill.Path, filepath.FromSlash(name))})
+			}
+			return nil
+		})
 	}
 }
 
-func checkForHiddenFiles(dirPath, rootField string, result *ValidationResult) {
-	files, err := os.ReadDir(dirPath)
-	if err != nil {
+func checkForHiddenFiles(ctx *RuleContext) {
+	fsys := ctx.Skill.dirFS()
+	if fsys == nil {
 		return
 	}
 
-	for _, f := range files {
-		if strings.HasPrefix(f.Name(), ".") {
-			result.Warnings = append(result.Warnings, ValidationError{
-				Field:   rootField,
-				Message: fmt.Sprintf("contains hidden file or directory: %q", f.Name()),
-			})
+	for _, dir := range OptionalDirs {
+		dirPath := filepath.Join(ctx.Skill.Path, dir)
+		files, err := fs.ReadDir(fsys, dir)
+		if err != nil {
+			continue
+		}
+
+		for _, f := range files {
+			if strings.HasPrefix(f.Name(), ".") {
+				ctx.Report(dir, fmt.Sprintf("contains hidden file or directory: %q", f.Name()), source.Position{File: filepath.Join(dirPath, f.Name())})
+			}
 		}
 	}
 }
@@ -340,36 +518,63 @@ const (
 	MaxBodyLinesClaudeCode = 500
 )
 
-func validateBodySize(skill *Skill, result *ValidationResult, opts *ValidationOptions) {
+func validateBodySize(ctx *RuleContext) {
+	body := ctx.Skill.Body
+	maxTokens := ctx.Options.maxBodyTokens()
+
 	// Check token count (Agent Skills recommendation)
-	if len(skill.Body) > MaxBodyCharsRecommended {
-		result.Warnings = append(result.Warnings, ValidationError{
-			Field:   "body",
-			Message: fmt.Sprintf("is very large (approximately %d tokens), recommendation is to keep it under 5000 tokens", len(skill.Body)/4),
-		})
+	if len(body) > maxTokens*4 {
+		ctx.Report("body", fmt.Sprintf("is very large (approximately %d tokens), recommendation is to keep it under %d tokens", len(body)/4, maxTokens), ctx.Skill.BodyPosition())
 	}
+}
 
+func validateBodyLines(ctx *RuleContext) {
 	// Claude Code specific: check line count
-	if opts.Spec == SpecClaudeCode {
-		lineCount := strings.Count(skill.Body, "\n") + 1
-		if lineCount > MaxBodyLinesClaudeCode {
-			result.Warnings = append(result.Warnings, ValidationError{
-				Field:   "body",
-				Message: fmt.Sprintf("exceeds recommended %d lines (got %d lines), consider splitting into separate files", MaxBodyLinesClaudeCode, lineCount),
-			})
+	lineCount := strings.Count(ctx.Skill.Body, "\n") + 1
+	maxLines := ctx.Options.maxBodyLines()
+	if lineCount > maxLines {
+		ctx.Report("body", fmt.Sprintf("exceeds recommended %d lines (got %d lines), consider splitting into separate files", maxLines, lineCount), ctx.Skill.BodyPosition())
+	}
+}
+
+// checkUnusedSuppressions reports directives that did not suppress anything.
+// Directives for rules that did not run (other spec, severity off) are not reported.
+func checkUnusedSuppressions(ctx *RuleContext) {
+	for _, d := range ctx.suppressions.Unused() {
+		if d.Rule == RuleUnusedSuppression {
+			continue
+		}
+		if d.Rule == "" {
+			ctx.Report("suppression", "aglx-disable directive does not name any rule", d.Pos)
+			continue
 		}
+		rule, ok := LookupRule(d.Rule)
+		if !ok {
+			ctx.Report("suppression", fmt.Sprintf("unknown rule %q", d.Rule), d.Pos)
+			continue
+		}
+		if !rule.AppliesTo(ctx.Options.Spec) || effectiveSeverity(rule, ctx.Options) == SeverityOff {
+			continue
+		}
+		ctx.Report("suppression", fmt.Sprintf("rule %q reported nothing to suppress", d.Rule), d.Pos)
 	}
 }
 
 // ValidateMultiple validates multiple skills and returns all results.
 func ValidateMultiple(skills []*Skill) []*ValidationResult {
-	var results []*ValidationResult
-	for _, skill := range skills {
-		results = append(results, Validate(skill))
-	}
+	results, _ := ValidateMultipleContext(context.Background(), skills, nil, 1)
 	return results
 }
 
+// ValidateMultipleContext validates skills on a pool of workers goroutines
+// (0 uses one per CPU). Results are in the order of skills. If ctx is
+// cancelled, no new skills are started and ctx.Err() is returned without results.
+func ValidateMultipleContext(ctx context.Context, skills []*Skill, opts *ValidationOptions, workers int) ([]*ValidationResult, error) {
+	return parallel.Map(ctx, workers, skills, func(skill *Skill) *ValidationResult {
+		return ValidateWithOptions(skill, opts)
+	})
+}
+
 // containsXMLTags checks if the string contains XML-like tags.
 var xmlTagPattern = regexp.MustCompile(`<[a-zA-Z][^>]*>`)
 
@@ -1,10 +1,17 @@
 package skill
 
 import (
-	"os"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
 	"path/filepath"
 	"strings"
 	"testing"
+	"testing/fstest"
+
+	"github.com/biwakonbu/aglx/internal/policy"
+	"github.com/biwakonbu/aglx/internal/toolspec"
 )
 
 func TestValidate_ValidSkill(t *testing.T) {
@@ -422,40 +429,119 @@ func TestValidate_AllowedTools(t *testing.T) {
 	}
 }
 
-func TestValidate_OptionalDirectories(t *testing.T) {
-	tempDir := t.TempDir()
+func TestValidate_AllowedToolsPositions(t *testing.T) {
+	tests := []struct {
+		line    string
+		message string
+		column  int
+	}{
+		{"allowed-tools: Read Bash(git", `unclosed "(" at character 10`, 25},
+		{"allowed-tools: 'Read Bash()'", `empty arguments in "Bash()"`, 26},
+		{"allowed-tools: \"Grep\\tBash()\"", `empty arguments in "Bash()"`, 16}, // escapes: value start
+		{"allowed-tools: Read,\n  Bash()", `empty arguments in "Bash()"`, 16},   // folded: value start
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			content := "---\nname: demo\ndescription: Demo.\n" + tt.line + "\n---\n"
+			s, err := ParseReader(strings.NewReader(content), "demo")
+			if err != nil {
+				t.Fatal(err)
+			}
+			result := Validate(s)
+			for _, e := range result.Errors {
+				if e.Rule == RuleAllowedToolsSyntax && strings.Contains(e.Message, tt.message) {
+					if e.Pos.Line != 4 || e.Pos.Column != tt.column {
+						t.Errorf("position = %d:%d, want 4:%d", e.Pos.Line, e.Pos.Column, tt.column)
+					}
+					return
+				}
+			}
+			t.Errorf("expected %s error containing %q, got: %v", RuleAllowedToolsSyntax, tt.message, result.Errors)
+		})
+	}
+}
 
-	// 1. Valid: script exists and is not empty
-	scriptsDir := filepath.Join(tempDir, "scripts")
-	err := os.Mkdir(scriptsDir, 0755)
-	if err != nil {
-		t.Fatal(err)
+func TestValidate_AllowedToolsUnknown(t *testing.T) {
+	tests := []struct {
+		tools   string
+		extra   []string
+		warning string
+	}{
+		{"Read Grep Bash(git:*) WebFetch(domain:example.com)", nil, ""},
+		{"Raed Grep", nil, `unknown tool "Raed" (did you mean "Read"?)`},
+		{"bash(git:*)", nil, `unknown tool "bash" (did you mean "Bash"?)`},
+		{"Deploy", nil, `unknown tool "Deploy" (not a Claude Code 2.0 tool`},
+		{"Deploy", []string{"Deploy"}, ""},
+		{"mcp__github__get_issue", nil, ""}, // no MCP tools configured
+		{"mcp__github__get_issue mcp__figma", []string{"mcp__github"}, `unknown tool "mcp__figma"`},
+		{"mcp__githb__get_issue", []string{"mcp__github"}, `unknown tool "mcp__githb__get_issue"`},
+		{"mcp__github__get_issue", []string{"mcp__github__get_issue"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tools, func(t *testing.T) {
+			s := &Skill{Name: "test-skill", Description: "Description", AllowedTools: tt.tools, Path: "/path/to/test-skill"}
+			result := ValidateWithOptions(s, &ValidationOptions{Spec: SpecAgentSkills, Tools: tt.extra})
+			var got []string
+			for _, w := range result.Warnings {
+				if w.Rule == RuleAllowedToolsUnknown {
+					got = append(got, w.Message)
+				}
+			}
+			switch {
+			case tt.warning == "" && len(got) != 0:
+				t.Errorf("unexpected warnings: %v", got)
+			case tt.warning != "" && (len(got) != 1 || !strings.Contains(got[0], tt.warning)):
+				t.Errorf("warnings = %v, want one containing %q", got, tt.warning)
+			}
+		})
 	}
-	err = os.WriteFile(filepath.Join(scriptsDir, "test.sh"), []byte("echo hello"), 0644)
+}
+
+func TestValidate_AllowedToolsPolicy(t *testing.T) {
+	s, err := ParseReader(strings.NewReader("---\nname: demo\ndescription: Demo.\nallowed-tools: Read Bash(rm:*)\n---\n"), "demo")
 	if err != nil {
 		t.Fatal(err)
 	}
+	deny := toolspec.Parse("Bash(rm:*)").Tools
+	opts := &ValidationOptions{Policy: &policy.Policy{Rules: []*policy.Rule{{ID: "no-rm", Deny: deny}}}}
+
+	result := ValidateWithOptions(s, opts)
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected one policy error, got %v", result.Errors)
+	}
+	e := result.Errors[0]
+	if e.Rule != RuleAllowedToolsPolicy || e.Message != `violates policy rule "no-rm": "Bash(rm:*)" is denied (matches "Bash(rm:*)")` {
+		t.Errorf("unexpected error: %+v", e)
+	}
+	if e.Pos.Line != 4 || e.Pos.Column != 21 {
+		t.Errorf("position = %d:%d, want 4:21", e.Pos.Line, e.Pos.Column)
+	}
+
+	if result := Validate(s); !result.IsValid() {
+		t.Errorf("expected no policy errors without a policy, got %v", result.Errors)
+	}
+}
 
+func TestValidate_OptionalDirectories(t *testing.T) {
+	fsys := fstest.MapFS{
+		"SKILL.md": {Data: []byte("---\nname: temp-skill\n---\n")},
+		// 1. Valid: script exists and is not empty
+		"scripts/test.sh": {Data: []byte("echo hello")},
+	}
 	skill := &Skill{
 		Name:        "temp-skill",
 		Description: "Description",
-		Path:        tempDir,
+		Path:        "skills/temp-skill",
+		FS:          fsys,
 	}
 
-	// We need to match the directory name to the skill name to avoid that error
-	skill.Name = filepath.Base(tempDir)
-
 	result := Validate(skill)
 	if !result.IsValid() {
 		t.Errorf("expected valid for non-empty scripts dir, got: %v", result.Errors)
 	}
 
 	// 2. Invalid: assets is a file, not a directory
-	assetsFile := filepath.Join(tempDir, "assets")
-	err = os.WriteFile(assetsFile, []byte("not a dir"), 0644)
-	if err != nil {
-		t.Fatal(err)
-	}
+	fsys["assets"] = &fstest.MapFile{Data: []byte("not a dir")}
 
 	result = Validate(skill)
 	found := false
@@ -468,20 +554,19 @@ func TestValidate_OptionalDirectories(t *testing.T) {
 	if !found {
 		t.Errorf("expected error for assets as file, got: %v", result.Errors)
 	}
-	os.Remove(assetsFile)
+	delete(fsys, "assets")
 
 	// 3. Invalid: references exists but is empty
-	refsDir := filepath.Join(tempDir, "references")
-	err = os.Mkdir(refsDir, 0755)
-	if err != nil {
-		t.Fatal(err)
-	}
+	fsys["references"] = &fstest.MapFile{Mode: fs.ModeDir | 0755}
 
 	result = Validate(skill)
 	found = false
 	for _, e := range result.Errors {
 		if e.Field == "references" && strings.Contains(e.Message, "must not be empty") {
 			found = true
+			if want := filepath.Join("skills/temp-skill", "references"); e.Pos.File != want {
+				t.Errorf("expected the error at %s, got %s", want, e.Pos.File)
+			}
 			break
 		}
 	}
@@ -490,6 +575,94 @@ func TestValidate_OptionalDirectories(t *testing.T) {
 	}
 }
 
+func TestValidate_References(t *testing.T) {
+	body := "# Guide\n\n" +
+		"Run `scripts/extract.py`, then read [the guide](references/guide.md#usage).\n" +
+		"See [the API](references/API.md), [forms](references/forms.md) and ![logo](assets/logo.png).\n" +
+		"Shared notes: [notes](../shared/notes.md) and [setup](/etc/setup.md).\n" +
+		"Output goes to https://example.com/scripts/missing.sh and [docs](https://example.com).\n"
+	skill := &Skill{
+		Name:        "temp-skill",
+		Description: "Description",
+		Body:        body,
+		BodyLine:    5,
+		Path:        "skills/temp-skill",
+		FS: fstest.MapFS{
+			"SKILL.md":            {Data: []byte("---\nname: temp-skill\n---\n\n" + body)},
+			"scripts/extract.py":  {Data: []byte("print(1)")},
+			"references/guide.md": {Data: []byte("# Guide")},
+			"references/api.md":   {Data: []byte("# API")},
+			"assets/Logo.png":     {Data: []byte("png")},
+		},
+	}
+
+	var got []string
+	for _, e := range Validate(skill).Errors {
+		got = append(got, fmt.Sprintf("%s %d:%d %s", e.Rule, e.Pos.Line, e.Pos.Column, e.Message))
+	}
+	want := []string{
+		`reference-missing 8:43 references "references/forms.md", which does not exist`,
+		`reference-outside 9:23 references "../shared/notes.md", which is outside the skill directory and is not bundled with it`,
+		`reference-outside 9:55 references "/etc/setup.md", which is outside the skill directory and is not bundled with it`,
+		`reference-case 8:15 references "references/API.md", but the file is "references/api.md" (the reference breaks on case-sensitive file systems)`,
+		`reference-case 8:76 references "assets/logo.png", but the file is "assets/Logo.png" (the reference breaks on case-sensitive file systems)`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("errors =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// Without a readable directory there is nothing to resolve against.
+	skill.FS = nil
+	skill.Path = "/nonexistent/temp-skill"
+	for _, e := range Validate(skill).Errors {
+		if strings.HasPrefix(e.Rule, "reference-") {
+			t.Errorf("unexpected %s error without a skill directory: %v", e.Rule, e)
+		}
+	}
+}
+
+func TestValidate_OrphanedResources(t *testing.T) {
+	fsys := fstest.MapFS{
+		"SKILL.md":                  {Data: []byte("---\nname: temp-skill\n---\n")},
+		"references/guide.md":       {Data: []byte("Fill forms as in [forms](forms/FORMS.md).\n")},
+		"references/forms/FORMS.md": {Data: []byte("Run `scripts/fill.sh`, then see [the guide](../guide.md).\n")},
+		"references/old.md":         {Data: []byte("Run scripts/old.sh.\n")},
+		"references/.draft.md":      {Data: []byte("hidden\n")},
+		"scripts/fill.sh":           {Data: []byte("#!/bin/sh\npython scripts/helpers/fill.py\n")},
+		"scripts/helpers/fill.py":   {Data: []byte("print('fill')\n")},
+		"scripts/old.sh":            {Data: []byte("#!/bin/sh\n")},
+		"assets/templates/a.docx":   {Data: []byte("PK\x00")},
+		"assets/templates/b.docx":   {Data: []byte("PK\x00")},
+		"assets/logo.png":           {Data: []byte("\x89PNG")},
+	}
+	skill := &Skill{
+		Name:        "temp-skill",
+		Description: "Description",
+		Body:        "Read [the guide](references/guide.md). Templates live in `assets/templates/`.\n",
+		Path:        "skills/temp-skill",
+		FS:          fsys,
+	}
+
+	var got []string
+	for _, w := range Validate(skill).Warnings {
+		if w.Rule == RuleOrphanedResources {
+			got = append(got, w.Message)
+			if want := filepath.Join("skills/temp-skill", "scripts", "old.sh"); w.Field == "scripts" && w.Pos.File != want {
+				t.Errorf("expected the warning at %s, got %s", want, w.Pos.File)
+			}
+		}
+	}
+	// references/old.md mentions scripts/old.sh, but nothing reaches old.md.
+	want := []string{
+		`"scripts/old.sh" is not referenced from SKILL.md or the files it references`,
+		`"assets/logo.png" is not referenced from SKILL.md or the files it references`,
+		`"references/old.md" is not referenced from SKILL.md or the files it references`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("warnings =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
 func TestValidateMultiple(t *testing.T) {
 	skills := []*Skill{
 		{Name: "valid-skill", Description: "Valid", Path: "/path/to/valid-skill"},
@@ -513,12 +686,42 @@ func TestValidateMultiple(t *testing.T) {
 	}
 }
 
+func TestValidateMultipleContext(t *testing.T) {
+	var skills []*Skill
+	for i := range 50 {
+		name := fmt.Sprintf("skill-%d", i)
+		if i%3 == 0 {
+			name = strings.ToUpper(name)
+		}
+		skills = append(skills, &Skill{Name: name, Description: "Generated"})
+	}
+
+	results, err := ValidateMultipleContext(context.Background(), skills, nil, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range results {
+		if r.Skill != skills[i] || r.IsValid() == (i%3 == 0) {
+			t.Fatalf("result %d does not belong to %s", i, skills[i].Name)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ValidateMultipleContext(ctx, skills, nil, 8); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
 // Integration tests using testdata directory
 func TestValidate_Integration_ValidSkills(t *testing.T) {
 	validPaths := []string{
 		"../../testdata/valid/pdf-processing",
 		"../../testdata/valid/simple-skill",
 		"../../testdata/valid/with-metadata",
+		"../../testdata/valid/japanese-description",
+		"../../testdata/valid/multilingual-description",
+		"../../testdata/valid/broad-tools",
 	}
 
 	for _, path := range validPaths {
@@ -532,6 +735,9 @@ func TestValidate_Integration_ValidSkills(t *testing.T) {
 			if !result.IsValid() {
 				t.Errorf("expected valid skill, got errors: %v", result.Errors)
 			}
+			if len(result.Warnings) != 0 {
+				t.Errorf("expected no warnings, got: %v", result.Warnings)
+			}
 		})
 	}
 }
@@ -548,6 +754,10 @@ func TestValidate_Integration_InvalidSkills(t *testing.T) {
 		{"../../testdata/invalid/consecutive-hyphens", "name", "consecutive hyphens"},
 		{"../../testdata/invalid/missing-name", "name", "required"},
 		{"../../testdata/invalid/missing-description", "description", "required"},
+		{"../../testdata/invalid/homoglyph-name", "name", "looks like \"a\""},
+		{"../../testdata/invalid/fullwidth-name", "name", "NFKC"},
+		{"../../testdata/invalid/frontmatter-schema", "allowed_tools", "did you mean"},
+		{"../../testdata/invalid/broken-references", "body", "does not exist"},
 	}
 
 	for _, tt := range tests {
@@ -608,20 +818,15 @@ func TestValidate_Warnings(t *testing.T) {
 	})
 
 	t.Run("Hidden files warning", func(t *testing.T) {
-		tmpDir := t.TempDir()
-		skillDir := filepath.Join(tmpDir, "hidden-skill")
-		os.Mkdir(skillDir, 0755)
-		scriptsDir := filepath.Join(skillDir, "scripts")
-		os.Mkdir(scriptsDir, 0755)
-
-		// Create normal file and hidden file
-		os.WriteFile(filepath.Join(scriptsDir, "script.sh"), []byte("echo hi"), 0644)
-		os.WriteFile(filepath.Join(scriptsDir, ".env"), []byte("SECRET=123"), 0644)
-
 		skill := &Skill{
 			Name:        "hidden-skill",
 			Description: "Skill with hidden files",
-			Path:        skillDir,
+			Path:        "hidden-skill",
+			// Create normal file and hidden file
+			FS: fstest.MapFS{
+				"scripts/script.sh": {Data: []byte("echo hi")},
+				"scripts/.env":      {Data: []byte("SECRET=123")},
+			},
 		}
 		result := Validate(skill)
 		if !result.HasWarnings() {
@@ -639,3 +844,104 @@ func TestValidate_Warnings(t *testing.T) {
 		}
 	})
 }
+
+func TestValidate_Positions(t *testing.T) {
+	skill, err := Parse("../../testdata/invalid/uppercase-name")
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	skill.Body = strings.Repeat("A", MaxBodyCharsRecommended+1)
+
+	result := Validate(skill)
+	file := filepath.Join("../../testdata/invalid/uppercase-name", "SKILL.md")
+	for _, e := range result.Errors {
+		if e.Field != "name" {
+			continue
+		}
+		if e.Pos.File != file || e.Pos.Line != 2 || e.Pos.Column != 7 {
+			t.Errorf("expected %s:2:7 for %q, got %v", file, e.Message, e.Pos)
+		}
+	}
+
+	found := false
+	for _, w := range result.Warnings {
+		if w.Field == "body" {
+			found = true
+			if w.Pos.File != file || w.Pos.Line != skill.BodyLine {
+				t.Errorf("expected body warning at %s:%d, got %v", file, skill.BodyLine, w.Pos)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected body size warning")
+	}
+
+	hidden := Validate(&Skill{
+		Name:        "with-hidden-files",
+		Description: "Hidden",
+		Body:        "Run `scripts/valid.sh`.",
+		Path:        "../../testdata/valid/with-hidden-files",
+	})
+	if len(hidden.Warnings) != 1 {
+		t.Fatalf("expected 1 warning, got %v", hidden.Warnings)
+	}
+	if want := filepath.Join("../../testdata/valid/with-hidden-files", "scripts", ".hidden"); hidden.Warnings[0].Pos.File != want {
+		t.Errorf("expected hidden file position %q, got %v", want, hidden.Warnings[0].Pos)
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"pdf-processing", "pdf-processing"},
+		{"Uppercase-Name", "uppercase-name"},
+		{"my--skill", "my-skill"},
+		{"-leading-and-trailing-", "leading-and-trailing"},
+		{"My Skill_v2", "my-skill-v2"},
+		{"---", ""},
+	}
+
+	for _, tt := range tests {
+		got := NormalizeName(tt.in)
+		if got != tt.want {
+			t.Errorf("NormalizeName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+		if got != "" && !IsValidName(got) {
+			t.Errorf("NormalizeName(%q) = %q is not a valid name", tt.in, got)
+		}
+	}
+}
+
+func TestIsValidName(t *testing.T) {
+	for _, name := range []string{"a", "pdf-processing", "v2"} {
+		if !IsValidName(name) {
+			t.Errorf("IsValidName(%q) = false, want true", name)
+		}
+	}
+	for _, name := range []string{"", "-a", "a-", "a--b", "A", "a_b", strings.Repeat("a", 65)} {
+		if IsValidName(name) {
+			t.Errorf("IsValidName(%q) = true, want false", name)
+		}
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	if errs := ValidateName("pdf-processing", SpecClaudeCode); len(errs) != 0 {
+		t.Errorf("expected no findings, got %v", errs)
+	}
+
+	var rules []string
+	for _, e := range ValidateName("Claude--Helper", SpecClaudeCode) {
+		rules = append(rules, e.Rule)
+	}
+	want := []string{RuleNameFormat, RuleNameFormat, RuleNameFormat, RuleNameReservedWords}
+	if strings.Join(rules, ",") != strings.Join(want, ",") {
+		t.Errorf("rules = %v, want %v", rules, want)
+	}
+
+	// Reserved words only apply to claude-code.
+	if errs := ValidateName("claude-helper", SpecAgentSkills); len(errs) != 0 {
+		t.Errorf("expected no findings for agent-skills, got %v", errs)
+	}
+}
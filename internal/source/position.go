@@ -0,0 +1,32 @@
+// Package source provides source location types shared by the skill and claude packages.
+package source
+
+import "fmt"
+
+// Position identifies a location in a file.
+// Line and Column are 1-based; zero means unknown. A Position with only
+// File set refers to a whole file or directory.
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// IsValid reports whether the position refers to a file.
+func (p Position) IsValid() bool {
+	return p.File != ""
+}
+
+// String formats the position as "file:line:column", omitting unknown parts.
+func (p Position) String() string {
+	switch {
+	case p.File == "":
+		return ""
+	case p.Line == 0:
+		return p.File
+	case p.Column == 0:
+		return fmt.Sprintf("%s:%d", p.File, p.Line)
+	default:
+		return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+	}
+}
@@ -0,0 +1,20 @@
+package source
+
+import "testing"
+
+func TestPosition_String(t *testing.T) {
+	tests := []struct {
+		pos  Position
+		want string
+	}{
+		{Position{}, ""},
+		{Position{File: "a/SKILL.md"}, "a/SKILL.md"},
+		{Position{File: "a/SKILL.md", Line: 3}, "a/SKILL.md:3"},
+		{Position{File: "a/SKILL.md", Line: 3, Column: 7}, "a/SKILL.md:3:7"},
+	}
+	for _, tt := range tests {
+		if got := tt.pos.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.pos, got, tt.want)
+		}
+	}
+}
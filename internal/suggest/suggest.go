@@ -0,0 +1,80 @@
+// Package suggest finds the closest match for a misspelled identifier, for
+// "did you mean" hints in findings.
+package suggest
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Closest returns the candidate that word most likely misspells, or "" if
+// none is close enough. Comparison ignores case and treats "_", " " and
+// camelCase boundaries like "-", so "allowed_tools" and "allowedTools" both
+// match "allowed-tools". Ties go to the earlier candidate.
+func Closest(word string, candidates []string) string {
+	w := fold(word)
+	best, bestDist := "", maxDistance(w)+1
+	for _, c := range candidates {
+		if c == word {
+			continue
+		}
+		d := distance(w, fold(c))
+		if d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+// maxDistance is the largest edit distance still considered a typo of word:
+// one edit for short words, growing to a third of the length.
+func maxDistance(word string) int {
+	return max(1, len([]rune(word))/3)
+}
+
+// fold normalizes case and word separators.
+func fold(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '_' || r == ' ':
+			b.WriteRune('-')
+		case unicode.IsUpper(r):
+			if b.Len() > 0 && !strings.HasSuffix(b.String(), "-") {
+				b.WriteRune('-')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// distance is the optimal string alignment distance between a and b: the
+// number of insertions, deletions, substitutions and adjacent
+// transpositions needed to turn one into the other.
+func distance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(s)][len(t)]
+}
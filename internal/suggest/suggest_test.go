@@ -0,0 +1,45 @@
+package suggest
+
+import "testing"
+
+func TestClosest(t *testing.T) {
+	keys := []string{"name", "description", "license", "compatibility", "allowed-tools", "metadata"}
+	tests := []struct {
+		word, want string
+	}{
+		{"descripton", "description"},
+		{"desciption", "description"},
+		{"allowed_tools", "allowed-tools"},
+		{"allowedTools", "allowed-tools"},
+		{"Allowed-Tools", "allowed-tools"},
+		{"lisence", "license"},
+		{"nmae", "name"},
+		{"meta-data", "metadata"},
+		{"version", ""},
+		{"tools", ""},
+		{"name", ""},
+	}
+	for _, tt := range tests {
+		if got := Closest(tt.word, keys); got != tt.want {
+			t.Errorf("Closest(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"ab", "ba", 1},
+		{"grep", "gerp", 1},
+	}
+	for _, tt := range tests {
+		if got := distance(tt.a, tt.b); got != tt.want {
+			t.Errorf("distance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// Package suppress parses inline suppression directives shared by the skill and claude packages.
+//
+// A rule can be disabled for a whole file with an HTML comment anywhere in
+// the Markdown body (outside fenced code blocks):
+//
+//	<!-- aglx-disable body-size, hidden-files -->
+//
+// or with the "aglx-ignore" key of the frontmatter metadata map, as a string
+// or a list:
+//
+//	metadata:
+//	  aglx-ignore: body-size hidden-files
+package suppress
+
+import (
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/biwakonbu/aglx/internal/frontmatter"
+	"github.com/biwakonbu/aglx/internal/source"
+)
+
+// MetadataKey is the frontmatter metadata key holding the ignore list.
+const MetadataKey = "aglx-ignore"
+
+// commentPattern matches a disable directive and captures its rule list.
+var commentPattern = regexp.MustCompile(`<!--\s*aglx-disable\b(.*?)-->`)
+
+// Directive disables one rule for the file it appears in.
+type Directive struct {
+	// Rule is the suppressed rule ID ("" if the directive names no rule).
+	Rule string
+
+	// Pos is where the directive appears.
+	Pos source.Position
+}
+
+// Parse collects the directives of a file. doc is the decoded frontmatter
+// document (may be nil); its lines are shifted by one to account for the
+// opening delimiter. body starts on line bodyLine of the file.
+func Parse(file string, doc *yaml.Node, body string, bodyLine int) []Directive {
+	var directives []Directive
+	directives = append(directives, parseFrontmatter(file, doc)...)
+	directives = append(directives, parseBody(file, body, bodyLine)...)
+	return directives
+}
+
+func parseFrontmatter(file string, doc *yaml.Node) []Directive {
+	if doc == nil || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+	metadata := lookup(doc.Content[0], "metadata")
+	if metadata == nil || metadata.Kind != yaml.MappingNode {
+		return nil
+	}
+	value := lookup(metadata, MetadataKey)
+	if value == nil {
+		return nil
+	}
+
+	// The ignore list is a string or a list of rule IDs.
+	items := []*yaml.Node{value}
+	if value.Kind == yaml.SequenceNode {
+		items = value.Content
+	}
+	var directives []Directive
+	for _, item := range items {
+		if item.Kind != yaml.ScalarNode {
+			continue
+		}
+		pos := source.Position{File: file, Line: item.Line + 1, Column: item.Column}
+		directives = append(directives, directivesFor(item.Value, pos)...)
+	}
+	return directives
+}
+
+// lookup returns the value of key in a mapping node.
+func lookup(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func parseBody(file, body string, bodyLine int) []Directive {
+	var directives []Directive
+	var fence string
+
+	for i, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if marker := frontmatter.FenceMarker(trimmed); marker != "" {
+			fence = marker
+			continue
+		}
+
+		for _, m := range commentPattern.FindAllStringSubmatchIndex(line, -1) {
+			pos := source.Position{File: file, Line: bodyLine + i, Column: m[0] + 1}
+			directives = append(directives, directivesFor(line[m[2]:m[3]], pos)...)
+		}
+	}
+	return directives
+}
+
+// directivesFor splits a comma- or space-separated rule list into directives.
+// An empty list yields a single directive with no rule so that it can be reported.
+func directivesFor(list string, pos source.Position) []Directive {
+	ids := strings.FieldsFunc(list, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	if len(ids) == 0 {
+		return []Directive{{Pos: pos}}
+	}
+	directives := make([]Directive, 0, len(ids))
+	for _, id := range ids {
+		directives = append(directives, Directive{Rule: id, Pos: pos})
+	}
+	return directives
+}
+
+// Tracker records which directives suppressed a finding during one validation run.
+type Tracker struct {
+	directives []Directive
+	used       []bool
+}
+
+// NewTracker returns a tracker over directives.
+func NewTracker(directives []Directive) *Tracker {
+	return &Tracker{directives: directives, used: make([]bool, len(directives))}
+}
+
+// Suppresses reports whether rule is disabled, marking the matching directives as used.
+func (t *Tracker) Suppresses(rule string) bool {
+	suppressed := false
+	for i, d := range t.directives {
+		if d.Rule == rule {
+			t.used[i] = true
+			suppressed = true
+		}
+	}
+	return suppressed
+}
+
+// Unused returns the directives that did not suppress any finding, in source order.
+func (t *Tracker) Unused() []Directive {
+	var unused []Directive
+	for i, d := range t.directives {
+		if !t.used[i] {
+			unused = append(unused, d)
+		}
+	}
+	return unused
+}
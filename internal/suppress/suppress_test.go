@@ -0,0 +1,91 @@
+package suppress
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/biwakonbu/aglx/internal/source"
+)
+
+func TestParse_Body(t *testing.T) {
+	body := "# Title\n" +
+		"<!-- aglx-disable body-size -->\n" +
+		"text <!--aglx-disable hidden-files,  body-lines--> more\n" +
+		"```\n" +
+		"<!-- aglx-disable name-format -->\n" +
+		"```\n" +
+		"<!-- aglx-disable -->\n" +
+		"<!-- aglx-disabled body-size -->\n"
+
+	got := Parse("SKILL.md", nil, body, 10)
+	want := []Directive{
+		{Rule: "body-size", Pos: source.Position{File: "SKILL.md", Line: 11, Column: 1}},
+		{Rule: "hidden-files", Pos: source.Position{File: "SKILL.md", Line: 12, Column: 6}},
+		{Rule: "body-lines", Pos: source.Position{File: "SKILL.md", Line: 12, Column: 6}},
+		{Rule: "", Pos: source.Position{File: "SKILL.md", Line: 16, Column: 1}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestParse_Frontmatter(t *testing.T) {
+	var doc yaml.Node
+	frontmatter := "name: demo\nmetadata:\n  author: me\n  aglx-ignore: \"body-size, hidden-files\"\n"
+	if err := yaml.Unmarshal([]byte(frontmatter), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	got := Parse("SKILL.md", &doc, "", 7)
+	pos := source.Position{File: "SKILL.md", Line: 5, Column: 16}
+	want := []Directive{{Rule: "body-size", Pos: pos}, {Rule: "hidden-files", Pos: pos}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParse_FrontmatterList(t *testing.T) {
+	var doc yaml.Node
+	frontmatter := "name: demo\nmetadata:\n  aglx-ignore:\n    - body-size\n    - hidden-files\n"
+	if err := yaml.Unmarshal([]byte(frontmatter), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	got := Parse("SKILL.md", &doc, "", 7)
+	want := []Directive{
+		{Rule: "body-size", Pos: source.Position{File: "SKILL.md", Line: 5, Column: 7}},
+		{Rule: "hidden-files", Pos: source.Position{File: "SKILL.md", Line: 6, Column: 7}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParse_FrontmatterWithoutIgnoreList(t *testing.T) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte("name: demo\nmetadata:\n  author: me\n"), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if got := Parse("SKILL.md", &doc, "no directives here", 5); len(got) != 0 {
+		t.Errorf("expected no directives, got %+v", got)
+	}
+}
+
+func TestTracker(t *testing.T) {
+	directives := []Directive{{Rule: "body-size"}, {Rule: "hidden-files"}, {Rule: "body-size"}}
+	tracker := NewTracker(directives)
+
+	if !tracker.Suppresses("body-size") {
+		t.Error("expected body-size to be suppressed")
+	}
+	if tracker.Suppresses("name-format") {
+		t.Error("name-format should not be suppressed")
+	}
+
+	unused := tracker.Unused()
+	if len(unused) != 1 || unused[0].Rule != "hidden-files" {
+		t.Errorf("Unused() = %+v, want only hidden-files", unused)
+	}
+}
@@ -0,0 +1,84 @@
+package toolspec
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/biwakonbu/aglx/internal/suggest"
+)
+
+// Catalog is a versioned list of the tool names an agent understands.
+type Catalog struct {
+	// Name names the agent the list was taken from, e.g. "Claude Code".
+	Name string
+
+	// Version is the release of the agent the list was taken from.
+	Version string
+
+	// Tools lists the tool names. Names starting with "mcp__" are MCP
+	// servers (mcp__server, allowing every tool of the server) or MCP tools
+	// (mcp__server__tool).
+	Tools []string
+}
+
+// ClaudeCode lists the built-in tools of Claude Code.
+var ClaudeCode = &Catalog{
+	Name:    "Claude Code",
+	Version: "2.0",
+	Tools: []string{
+		"AskUserQuestion", "Bash", "BashOutput", "Edit", "ExitPlanMode", "Glob",
+		"Grep", "KillShell", "LS", "MultiEdit", "NotebookEdit", "NotebookRead",
+		"Read", "Skill", "SlashCommand", "Task", "TodoWrite", "WebFetch",
+		"WebSearch", "Write",
+	},
+}
+
+// String returns the name and version of the catalogue, e.g. "Claude Code 2.0".
+func (c *Catalog) String() string {
+	return fmt.Sprintf("%s %s", c.Name, c.Version)
+}
+
+// Extend returns a copy of the catalogue with extra tool names, such as the
+// custom and MCP tools of a project.
+func (c *Catalog) Extend(names ...string) *Catalog {
+	if len(names) == 0 {
+		return c
+	}
+	extended := *c
+	extended.Tools = append(slices.Clone(c.Tools), names...)
+	return &extended
+}
+
+// Known reports whether the catalogue has the tool. An MCP tool is known if
+// the catalogue lists it or its server. MCP tools cannot be checked against
+// a catalogue without MCP entries, so they are all known then.
+func (c *Catalog) Known(t *Tool) bool {
+	if slices.Contains(c.Tools, t.Name) {
+		return true
+	}
+	if t.MCP == nil {
+		return false
+	}
+	if !slices.ContainsFunc(c.Tools, isMCP) {
+		return true
+	}
+	return slices.Contains(c.Tools, MCPPrefix+t.MCP.Server)
+}
+
+// Suggest returns the catalogue entry the tool name most likely misspells,
+// or "".
+func (c *Catalog) Suggest(name string) string {
+	if strings.HasPrefix(name, MCPPrefix) {
+		return suggest.Closest(name, slices.DeleteFunc(slices.Clone(c.Tools), isNotMCP))
+	}
+	return suggest.Closest(name, slices.DeleteFunc(slices.Clone(c.Tools), isMCP))
+}
+
+func isMCP(name string) bool {
+	return strings.HasPrefix(name, MCPPrefix)
+}
+
+func isNotMCP(name string) bool {
+	return !isMCP(name)
+}
@@ -0,0 +1,49 @@
+package toolspec
+
+import "testing"
+
+func TestCatalog_Known(t *testing.T) {
+	project := ClaudeCode.Extend("Deploy", "mcp__github", "mcp__figma__get_code")
+	tests := []struct {
+		catalog *Catalog
+		tool    string
+		known   bool
+	}{
+		{ClaudeCode, "Read", true},
+		{ClaudeCode, "read", false},
+		{ClaudeCode, "Deploy", false},
+		{ClaudeCode, "mcp__anything__at_all", true},
+		{project, "Deploy", true},
+		{project, "mcp__github", true},
+		{project, "mcp__github__get_issue", true},
+		{project, "mcp__figma__get_code", true},
+		{project, "mcp__figma__get_image", false},
+		{project, "mcp__slack", false},
+	}
+	for _, tt := range tests {
+		list := Parse(tt.tool)
+		if got := tt.catalog.Known(list.Tools[0]); got != tt.known {
+			t.Errorf("%s.Known(%q) = %v, want %v", tt.catalog, tt.tool, got, tt.known)
+		}
+	}
+}
+
+func TestCatalog_Suggest(t *testing.T) {
+	project := ClaudeCode.Extend("mcp__github")
+	tests := []struct {
+		name, want string
+	}{
+		{"Raed", "Read"},
+		{"bash", "Bash"},
+		{"webfetch", "WebFetch"},
+		{"Web_Search", "WebSearch"},
+		{"Deploy", ""},
+		{"mcp__githb", "mcp__github"},
+		{"Grpe", "Grep"},
+	}
+	for _, tt := range tests {
+		if got := project.Suggest(tt.name); got != tt.want {
+			t.Errorf("Suggest(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,317 @@
+package toolspec
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// MCPPrefix starts the names of tools provided by MCP servers.
+const MCPPrefix = "mcp__"
+
+// pathTools are the tools whose pattern is a path glob.
+var pathTools = map[string]bool{
+	"Read": true, "Write": true, "Edit": true, "MultiEdit": true,
+	"NotebookEdit": true, "Glob": true, "Grep": true, "LS": true,
+}
+
+// closers maps each opening bracket to its closing bracket.
+var closers = map[byte]byte{'(': ')', '[': ']', '{': '}'}
+
+// Parse parses an allowed-tools value. It never fails: syntax errors are
+// collected in List.Errors and the entries are parsed as far as possible.
+func Parse(value string) *List {
+	p := &parser{list: &List{Value: value}}
+	spans := p.scan(value)
+	for _, s := range spans {
+		p.list.Tools = append(p.list.Tools, p.parseTool(s))
+	}
+	sort.SliceStable(p.list.Errors, func(i, j int) bool {
+		return p.list.Errors[i].Offset < p.list.Errors[j].Offset
+	})
+	return p.list
+}
+
+type parser struct {
+	list *List
+}
+
+func (p *parser) errorf(offset int, format string, args ...interface{}) {
+	p.list.Errors = append(p.list.Errors, &Error{Offset: offset, Message: fmt.Sprintf(format, args...), value: p.list.Value})
+}
+
+// span is the text of one entry and its offset in the value.
+type span struct {
+	text   string
+	offset int
+}
+
+// bracket is an opening bracket waiting to be closed.
+type bracket struct {
+	char   byte
+	offset int
+}
+
+// scan is the tokenizer: it splits the value into entries at separators
+// outside brackets and reports unbalanced brackets. A comma outside
+// brackets makes the list comma-separated; otherwise whitespace between
+// entries makes it space-separated.
+func (p *parser) scan(value string) []span {
+	outside := p.matchBrackets(value)
+
+	trimmed := strings.Trim(value, spaceChars)
+	lead := strings.Index(value, trimmed)
+	for i := lead; i < lead+len(trimmed); i++ {
+		if !outside[i] {
+			continue
+		}
+		if value[i] == ',' {
+			p.list.Separator = SeparatorComma
+			break
+		}
+		if isSpace(value[i]) {
+			p.list.Separator = SeparatorSpace
+		}
+	}
+
+	var spans []span
+	start := -1
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		separator := outside[i] && ((c == ',' && p.list.Separator == SeparatorComma) ||
+			(isSpace(c) && p.list.Separator == SeparatorSpace))
+		switch {
+		case separator && start >= 0:
+			spans = append(spans, span{text: value[start:i], offset: start})
+			start = -1
+		case separator && c == ',':
+			p.errorf(i, "empty entry before \",\"")
+		case !separator && start < 0 && !isSpace(c):
+			start = i
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, span{text: value[start:], offset: start})
+	}
+
+	// Entries of a comma-separated list may be surrounded by whitespace.
+	// Trimming uses the same whitespace as the loop above, so no span
+	// becomes empty; other spaces such as U+00A0 are reported by parseTool.
+	for i := range spans {
+		spans[i].text = strings.TrimRight(spans[i].text, spaceChars)
+	}
+	return slices.DeleteFunc(spans, func(s span) bool { return s.text == "" })
+}
+
+// matchBrackets reports unbalanced brackets and returns, for every byte of
+// the value, whether it is outside brackets. A closing bracket closes the
+// innermost matching opening bracket; brackets opened after it are unclosed.
+func (p *parser) matchBrackets(value string) []bool {
+	outside := make([]bool, len(value))
+	var open []bracket
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case closers[c] != 0:
+			open = append(open, bracket{c, i})
+			continue
+		case c == ')' || c == ']' || c == '}':
+			k := len(open) - 1
+			for k >= 0 && closers[open[k].char] != c {
+				k--
+			}
+			if k < 0 {
+				p.errorf(i, "unexpected %q (no matching opening bracket)", string(c))
+				break
+			}
+			for _, b := range open[k+1:] {
+				p.errorf(b.offset, "unclosed %q (closed by %q at character %d)", string(b.char), string(c), runeColumn(value, i))
+			}
+			open = open[:k]
+			continue
+		}
+		outside[i] = len(open) == 0
+	}
+	for _, b := range open {
+		p.errorf(b.offset, "unclosed %q", string(b.char))
+	}
+	return outside
+}
+
+// parseTool parses one entry: Name or Name(args).
+func (p *parser) parseTool(s span) *Tool {
+	t := &Tool{Raw: s.text, Offset: s.offset}
+	text := s.text
+
+	i := 0
+	for i < len(text) && isNameChar(text[i]) {
+		i++
+	}
+	t.Name = text[:i]
+
+	switch {
+	case i == 0 && len(text) > 0 && text[0] == '(':
+		p.errorf(s.offset, "missing tool name before \"(\"")
+	case i == 0:
+		if !p.hasError(s.offset, s.offset+1) {
+			p.errorf(s.offset, "invalid tool format: unexpected %q in %q", firstRune(text), text)
+		}
+		return t
+	case t.Name[0] == '-' || t.Name[0] == '_':
+		p.errorf(s.offset, "invalid tool format: %q must start with a letter or digit", t.Name)
+	}
+	if strings.HasPrefix(t.Name, MCPPrefix) {
+		p.parseMCP(t)
+	}
+
+	rest := text[i:]
+	switch {
+	case rest == "":
+		return t
+	case rest[0] == '(':
+		p.parseArgs(t, s.offset+i, rest)
+	case isSpace(rest[0]):
+		next := strings.TrimLeft(rest, " \t\n")
+		p.errorf(s.offset+len(text)-len(next), "missing \",\" between %q and %q", t.Name, next)
+	case !p.hasError(s.offset+i, s.offset+i+1):
+		// Stray closing brackets were reported by scan.
+		p.errorf(s.offset+i, "invalid tool format: unexpected %q in %q", firstRune(rest), text)
+	}
+	return t
+}
+
+// parseMCP splits an MCP tool name into server and tool.
+func (p *parser) parseMCP(t *Tool) {
+	server, tool, hasTool := strings.Cut(strings.TrimPrefix(t.Name, MCPPrefix), "__")
+	t.MCP = &MCPTool{Server: server, Tool: tool}
+	switch {
+	case server == "":
+		p.errorf(t.Offset, "missing MCP server name in %q (expected mcp__server or mcp__server__tool)", t.Name)
+	case hasTool && tool == "":
+		p.errorf(t.Offset, "missing MCP tool name in %q (expected mcp__server__tool)", t.Name)
+	}
+}
+
+// parseArgs parses "(args)" followed by nothing; offset is the offset of
+// the opening parenthesis in the value.
+func (p *parser) parseArgs(t *Tool, offset int, text string) {
+	closing := matchingParen(text)
+	if closing < 0 {
+		// Reported by scan as an unclosed bracket.
+		t.Args = &Args{Raw: text[1:], Offset: offset + 1, Pattern: &Opaque{Text: text[1:]}}
+		return
+	}
+	if after := text[closing+1:]; after != "" {
+		p.errorf(offset+closing+1, "unexpected %q after \")\"", after)
+	}
+
+	raw := text[1:closing]
+	t.Args = &Args{Raw: raw, Offset: offset + 1}
+	if strings.TrimSpace(raw) == "" {
+		p.errorf(offset, "empty arguments in %q (remove the parentheses or add a pattern)", t.Name+"()")
+		t.Args.Pattern = &Opaque{}
+		return
+	}
+	t.Args.Pattern = p.parsePattern(t.Name, raw, offset+1)
+}
+
+// matchingParen returns the index of the parenthesis closing text[0], or
+// -1. Brackets are matched as in scan.
+func matchingParen(text string) int {
+	var open []byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if closers[c] != 0 {
+			open = append(open, c)
+			continue
+		}
+		k := len(open) - 1
+		for k >= 0 && closers[open[k]] != c {
+			k--
+		}
+		if k < 0 {
+			continue
+		}
+		if open = open[:k]; k == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// hasError reports whether an error was recorded in [from, to).
+func (p *parser) hasError(from, to int) bool {
+	for _, e := range p.list.Errors {
+		if from <= e.Offset && e.Offset < to {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePattern interprets the arguments of a tool; offset is the offset of
+// raw in the value.
+func (p *parser) parsePattern(tool, raw string, offset int) Pattern {
+	switch {
+	case tool == "Bash":
+		return p.parseCommand(raw, offset)
+	case pathTools[tool]:
+		if _, err := path.Match(raw, ""); err != nil && !p.hasError(offset, offset+len(raw)) {
+			p.errorf(offset, "invalid glob pattern %q", raw)
+		}
+		return &PathGlob{Glob: raw}
+	case tool == "WebFetch" && strings.HasPrefix(raw, "domain:"):
+		host := strings.TrimPrefix(raw, "domain:")
+		switch {
+		case host == "":
+			p.errorf(offset, "missing host after \"domain:\"")
+		case strings.ContainsAny(host, "/ \t:"):
+			p.errorf(offset+len("domain:"), "invalid domain %q (expected a host name such as example.com)", host)
+		}
+		return &Domain{Host: host}
+	default:
+		return &Opaque{Text: raw}
+	}
+}
+
+// parseCommand parses a Bash pattern: a command, optionally ending in ":*"
+// to match every command with that prefix. "*" alone matches everything.
+func (p *parser) parseCommand(raw string, offset int) Pattern {
+	if strings.TrimSpace(raw) == "*" {
+		return &Command{Prefix: true}
+	}
+	command, prefix := strings.CutSuffix(raw, ":*")
+	if i := strings.Index(command, ":*"); i >= 0 {
+		p.errorf(offset+i, "\":*\" must end the pattern in %q", raw)
+	}
+	if prefix && strings.TrimSpace(command) == "" {
+		p.errorf(offset, "missing command before \":*\"")
+	}
+	return &Command{Command: command, Prefix: prefix}
+}
+
+// spaceChars are the characters that separate and surround entries.
+const spaceChars = " \t\n\r\v\f"
+
+func isSpace(c byte) bool {
+	return strings.IndexByte(spaceChars, c) >= 0
+}
+
+func isNameChar(c byte) bool {
+	return c == '_' || c == '-' || ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func firstRune(s string) string {
+	for _, r := range s {
+		return string(r)
+	}
+	return ""
+}
+
+// runeColumn returns the 1-based character position of a byte offset.
+func runeColumn(value string, offset int) int {
+	return utf8.RuneCountInString(value[:offset]) + 1
+}
@@ -0,0 +1,131 @@
+package toolspec
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse_Lists(t *testing.T) {
+	tests := []struct {
+		value     string
+		separator Separator
+		names     []string
+	}{
+		{"", SeparatorNone, nil},
+		{"Read", SeparatorNone, []string{"Read"}},
+		{"Bash(git:*) Bash(jq:*) Read", SeparatorSpace, []string{"Bash(git:*)", "Bash(jq:*)", "Read"}},
+		{"Bash(ls -la)  Read\tGrep", SeparatorSpace, []string{"Bash(ls -la)", "Read", "Grep"}},
+		{"Read, Grep, Glob", SeparatorComma, []string{"Read", "Grep", "Glob"}},
+		{"Read,Grep", SeparatorComma, []string{"Read", "Grep"}},
+		{" Read , Grep, ", SeparatorComma, []string{"Read", "Grep"}},
+		{"Bash(a, b) Read", SeparatorSpace, []string{"Bash(a, b)", "Read"}},
+		{"Bash(git diff), Read(src/[ab]/**)", SeparatorComma, []string{"Bash(git diff)", "Read(src/[ab]/**)"}},
+		{"\r", SeparatorNone, nil},
+		{"Read,\r", SeparatorComma, []string{"Read"}},
+		{"Read\r\nGrep", SeparatorSpace, []string{"Read", "Grep"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			list := Parse(tt.value)
+			if len(list.Errors) != 0 {
+				t.Errorf("unexpected errors: %v", list.Errors)
+			}
+			if list.Separator != tt.separator {
+				t.Errorf("Separator = %v, want %v", list.Separator, tt.separator)
+			}
+			if got := list.Names(); !reflect.DeepEqual(got, tt.names) {
+				t.Errorf("Names() = %q, want %q", got, tt.names)
+			}
+		})
+	}
+}
+
+func TestParse_AST(t *testing.T) {
+	list := Parse("Bash(git status:*) Read(./src/**) WebFetch(domain:example.com) mcp__github__get_issue mcp__figma Task(explore)")
+	if len(list.Errors) != 0 {
+		t.Fatalf("unexpected errors: %v", list.Errors)
+	}
+
+	want := []*Tool{
+		{Raw: "Bash(git status:*)", Offset: 0, Name: "Bash", Args: &Args{Raw: "git status:*", Offset: 5, Pattern: &Command{Command: "git status", Prefix: true}}},
+		{Raw: "Read(./src/**)", Offset: 19, Name: "Read", Args: &Args{Raw: "./src/**", Offset: 24, Pattern: &PathGlob{Glob: "./src/**"}}},
+		{Raw: "WebFetch(domain:example.com)", Offset: 34, Name: "WebFetch", Args: &Args{Raw: "domain:example.com", Offset: 43, Pattern: &Domain{Host: "example.com"}}},
+		{Raw: "mcp__github__get_issue", Offset: 63, Name: "mcp__github__get_issue", MCP: &MCPTool{Server: "github", Tool: "get_issue"}},
+		{Raw: "mcp__figma", Offset: 86, Name: "mcp__figma", MCP: &MCPTool{Server: "figma"}},
+		{Raw: "Task(explore)", Offset: 97, Name: "Task", Args: &Args{Raw: "explore", Offset: 102, Pattern: &Opaque{Text: "explore"}}},
+	}
+	if len(list.Tools) != len(want) {
+		t.Fatalf("got %d tools, want %d", len(list.Tools), len(want))
+	}
+	for i, got := range list.Tools {
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("tool %d = %+v (args %+v), want %+v (args %+v)", i, got, got.Args, want[i], want[i].Args)
+		}
+		if list.Value[got.Offset:got.Offset+len(got.Raw)] != got.Raw {
+			t.Errorf("tool %d: offset %d does not point at %q", i, got.Offset, got.Raw)
+		}
+	}
+}
+
+func TestParse_Commands(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Command
+	}{
+		{"Bash(git diff)", Command{Command: "git diff"}},
+		{"Bash(npm run test:*)", Command{Command: "npm run test", Prefix: true}},
+		{"Bash(*)", Command{Prefix: true}},
+	}
+	for _, tt := range tests {
+		list := Parse(tt.value)
+		if len(list.Errors) != 0 {
+			t.Errorf("%s: unexpected errors: %v", tt.value, list.Errors)
+			continue
+		}
+		if got := list.Tools[0].Args.Pattern; !reflect.DeepEqual(got, &tt.want) {
+			t.Errorf("%s: pattern = %+v, want %+v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		value  string
+		errors []string
+	}{
+		{"Bash(git status Read", []string{`unclosed "(" at character 5`}},
+		{"Read) Grep", []string{`unexpected ")" (no matching opening bracket) at character 5`}},
+		{"Read(src/[a.go) Grep", []string{`unclosed "[" (closed by ")" at character 15) at character 10`}},
+		{"Bash(a]) Read", []string{`unexpected "]" (no matching opening bracket) at character 7`}},
+		{"Bash() Read", []string{`empty arguments in "Bash()" (remove the parentheses or add a pattern) at character 5`}},
+		{"Bash( )", []string{`empty arguments in "Bash()" (remove the parentheses or add a pattern) at character 5`}},
+		{"Bash(git:*)x Read", []string{`unexpected "x" after ")" at character 12`}},
+		{"Bash(:*)", []string{`missing command before ":*" at character 6`}},
+		{"Bash(git:*:x)", []string{`":*" must end the pattern in "git:*:x" at character 9`}},
+		{"Read([]a)", []string{`invalid glob pattern "[]a" at character 6`}},
+		{"WebFetch(domain:)", []string{`missing host after "domain:" at character 10`}},
+		{"WebFetch(domain:https://x.org)", []string{`invalid domain "https://x.org" (expected a host name such as example.com) at character 17`}},
+		{"Tool.With.Dot", []string{`invalid tool format: unexpected "." in "Tool.With.Dot" at character 5`}},
+		{"Read, @x", []string{`invalid tool format: unexpected "@" in "@x" at character 7`}},
+		{"-Read", []string{`invalid tool format: "-Read" must start with a letter or digit at character 1`}},
+		{"(x)", []string{`missing tool name before "(" at character 1`}},
+		{"Read, , Grep", []string{`empty entry before "," at character 7`}},
+		{"Read Grep, Glob", []string{`missing "," between "Read" and "Grep" at character 6`}},
+		{"mcp__", []string{`missing MCP server name in "mcp__" (expected mcp__server or mcp__server__tool) at character 1`}},
+		{"mcp__github__", []string{`missing MCP tool name in "mcp__github__" (expected mcp__server__tool) at character 1`}},
+		{"Read, \u00a0", []string{`invalid tool format: unexpected "\u00a0" in "\u00a0" at character 7`}},
+		{"Read  Bash(ä]", []string{`unclosed "(" at character 11`, `unexpected "]" (no matching opening bracket) at character 13`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			var got []string
+			for _, e := range Parse(tt.value).Errors {
+				got = append(got, e.Error())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.errors, "\n") {
+				t.Errorf("errors =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.errors, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Package toolspec parses allowed-tools values such as
+//
+//	Bash(git status:*) Read mcp__github__get_issue
+//	Read, Edit(src/**), WebFetch(domain:example.com)
+//
+// into a typed syntax tree. Every node records its byte offset in the value,
+// so that syntax errors and later checks (the tool catalogue, permission
+// policies) can point at the exact entry.
+package toolspec
+
+import "fmt"
+
+// Separator is the character that separates the entries of a list.
+type Separator int
+
+const (
+	// SeparatorNone is used for lists with at most one entry.
+	SeparatorNone Separator = iota
+	// SeparatorSpace separates entries with whitespace (Agent Skills).
+	SeparatorSpace
+	// SeparatorComma separates entries with commas (Claude Code).
+	SeparatorComma
+)
+
+// List is a parsed allowed-tools value.
+type List struct {
+	// Value is the text that was parsed.
+	Value string
+
+	// Separator is the separator of the list, decided by the first comma or
+	// whitespace outside parentheses.
+	Separator Separator
+
+	Tools []*Tool
+
+	// Errors lists the syntax errors, in the order of their offsets.
+	Errors []*Error
+}
+
+// Tool is one entry of an allowed-tools list.
+type Tool struct {
+	// Raw is the entry as written, e.g. "Bash(git:*)".
+	Raw string
+
+	// Offset is the byte offset of Raw in the list value.
+	Offset int
+
+	// Name is the tool name, e.g. "Bash" or "mcp__github__get_issue".
+	Name string
+
+	// MCP is set for tools provided by an MCP server (names starting with "mcp__").
+	MCP *MCPTool
+
+	// Args is the permission pattern in parentheses (nil without parentheses).
+	Args *Args
+}
+
+// MCPTool is the server/tool split of an "mcp__server__tool" name.
+type MCPTool struct {
+	Server string
+
+	// Tool is the tool name, or "" if the entry allows every tool of the server.
+	Tool string
+}
+
+// Args is the permission pattern of a tool entry.
+type Args struct {
+	// Raw is the text between the parentheses.
+	Raw string
+
+	// Offset is the byte offset of Raw in the list value.
+	Offset int
+
+	// Pattern is Raw interpreted for the tool.
+	Pattern Pattern
+}
+
+// Pattern is the interpretation of a permission pattern: one of *Command,
+// *PathGlob, *Domain or *Opaque.
+type Pattern interface {
+	pattern()
+}
+
+// Command is a Bash command pattern. "git diff" matches that command
+// exactly; "git:*" (Prefix) matches every command starting with "git".
+type Command struct {
+	Command string
+	Prefix  bool
+}
+
+// PathGlob is a gitignore-style path pattern for file tools, e.g. "src/**".
+type PathGlob struct {
+	Glob string
+}
+
+// Domain restricts a web tool to a host, written "domain:example.com".
+type Domain struct {
+	Host string
+}
+
+// Opaque is a pattern of a tool whose pattern syntax is not known.
+type Opaque struct {
+	Text string
+}
+
+func (*Command) pattern()  {}
+func (*PathGlob) pattern() {}
+func (*Domain) pattern()   {}
+func (*Opaque) pattern()   {}
+
+// Error is a syntax error in an allowed-tools value.
+type Error struct {
+	// Offset is the byte offset in the list value the error refers to.
+	Offset int
+
+	// Message describes the error without its location.
+	Message string
+
+	value string
+}
+
+// Column returns the 1-based character (not byte) position of the error in
+// the value.
+func (e *Error) Column() int {
+	return runeColumn(e.value, e.Offset)
+}
+
+// Error formats the error with its character position.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at character %d", e.Message, e.Column())
+}
+
+// Column returns the 1-based character (not byte) position of a byte
+// offset in the value, such as Tool.Offset.
+func (l *List) Column(offset int) int {
+	return runeColumn(l.Value, offset)
+}
+
+// Names returns the entries as written, in order.
+func (l *List) Names() []string {
+	if len(l.Tools) == 0 {
+		return nil
+	}
+	names := make([]string, len(l.Tools))
+	for i, t := range l.Tools {
+		names[i] = t.Raw
+	}
+	return names
+}
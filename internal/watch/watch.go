@@ -0,0 +1,89 @@
+// Package watch detects changes to skill directories and other files by
+// polling the metadata of the files validation reads (the engine behind
+// `aglx validate --watch`).
+package watch
+
+import (
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+)
+
+// FileState is the metadata compared between polls.
+type FileState struct {
+	Size    int64
+	ModTime int64 // Unix nanoseconds
+	Mode    fs.FileMode
+}
+
+// Snapshot maps the slash-separated paths (relative to the skill) of the
+// watched files and directories to their state.
+type Snapshot map[string]FileState
+
+// Take records the state of the skill at path. For a directory it covers
+// every file and directory below it, since validation reads resources
+// anywhere in the skill (references, orphaned and hidden files); a file,
+// such as an archive or a config file, is recorded as a single entry ".".
+// A missing path yields an empty snapshot.
+func Take(path string) Snapshot {
+	snap := Snapshot{}
+	info, err := os.Stat(path)
+	if err != nil {
+		return snap
+	}
+	if !info.IsDir() {
+		snap["."] = state(info)
+		return snap
+	}
+
+	filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || p == path {
+			return nil // Unreadable: nothing to record
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if rel, err := filepath.Rel(path, p); err == nil {
+			snap[filepath.ToSlash(rel)] = state(info)
+		}
+		return nil
+	})
+	return snap
+}
+
+func state(info fs.FileInfo) FileState {
+	return FileState{Size: info.Size(), ModTime: info.ModTime().UnixNano(), Mode: info.Mode()}
+}
+
+// Watcher remembers the last snapshot of each skill path.
+type Watcher struct {
+	snapshots map[string]Snapshot
+}
+
+// New returns a Watcher with the current state of paths as its baseline.
+func New(paths []string) *Watcher {
+	w := &Watcher{snapshots: make(map[string]Snapshot, len(paths))}
+	for _, p := range paths {
+		w.snapshots[p] = Take(p)
+	}
+	return w
+}
+
+// Poll returns the paths whose snapshot changed since the previous poll, in
+// the order given. Paths seen for the first time count as changed; paths
+// no longer listed are forgotten.
+func (w *Watcher) Poll(paths []string) []string {
+	var changed []string
+	current := make(map[string]Snapshot, len(paths))
+	for _, p := range paths {
+		snap := Take(p)
+		current[p] = snap
+		if old, ok := w.snapshots[p]; !ok || !maps.Equal(old, snap) {
+			changed = append(changed, p)
+		}
+	}
+	w.snapshots = current
+	return changed
+}
@@ -0,0 +1,115 @@
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func write(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// touch moves the modification time forward so that changes are visible
+// even on file systems with coarse timestamps.
+func touch(t *testing.T, path string) {
+	t.Helper()
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTake(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "demo")
+	write(t, filepath.Join(dir, "SKILL.md"), "---\nname: demo\n---\n")
+	write(t, filepath.Join(dir, "scripts", "run.sh"), "echo\n")
+	write(t, filepath.Join(dir, "scripts", ".env"), "X=1\n")
+	write(t, filepath.Join(dir, "notes.txt"), "Notes\n")
+	write(t, filepath.Join(dir, ".claude", "CLAUDE.md"), "# Notes\n")
+
+	snap := Take(dir)
+	var got []string
+	for p := range snap {
+		got = append(got, p)
+	}
+	slices.Sort(got)
+	want := []string{".claude", ".claude/CLAUDE.md", "SKILL.md", "notes.txt", "scripts", "scripts/.env", "scripts/run.sh"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Take() = %v, want %v", got, want)
+	}
+
+	if len(Take(filepath.Join(dir, "missing"))) != 0 {
+		t.Error("expected an empty snapshot for a missing path")
+	}
+	archive := filepath.Join(t.TempDir(), "demo.zip")
+	write(t, archive, "zip")
+	if snap := Take(archive); len(snap) != 1 || snap["."].Size != 3 {
+		t.Errorf("expected a single entry for an archive, got %v", snap)
+	}
+}
+
+func TestWatcher_Poll(t *testing.T) {
+	root := t.TempDir()
+	a := filepath.Join(root, "a")
+	b := filepath.Join(root, "b")
+	write(t, filepath.Join(a, "SKILL.md"), "---\nname: a\n---\n")
+	write(t, filepath.Join(b, "SKILL.md"), "---\nname: b\n---\n")
+
+	w := New([]string{a, b})
+	if changed := w.Poll([]string{a, b}); len(changed) != 0 {
+		t.Errorf("expected no changes, got %v", changed)
+	}
+
+	// Editing SKILL.md, adding a resource and removing a file are changes.
+	write(t, filepath.Join(b, "SKILL.md"), "---\nname: b\ndescription: B.\n---\n")
+	touch(t, filepath.Join(b, "SKILL.md"))
+	if changed := w.Poll([]string{a, b}); !slices.Equal(changed, []string{b}) {
+		t.Errorf("expected [%s], got %v", b, changed)
+	}
+
+	write(t, filepath.Join(a, "references", "GUIDE.md"), "# Guide\n")
+	if changed := w.Poll([]string{a, b}); !slices.Equal(changed, []string{a}) {
+		t.Errorf("expected [%s], got %v", a, changed)
+	}
+
+	if err := os.Remove(filepath.Join(a, "references", "GUIDE.md")); err != nil {
+		t.Fatal(err)
+	}
+	if changed := w.Poll([]string{a, b}); !slices.Equal(changed, []string{a}) {
+		t.Errorf("expected [%s] after a removal, got %v", a, changed)
+	}
+
+	// Any file of the skill counts, not only SKILL.md and the optional
+	// directories: validation reads resources anywhere in the skill.
+	write(t, filepath.Join(a, "notes.txt"), "scratch\n")
+	if changed := w.Poll([]string{a, b}); !slices.Equal(changed, []string{a}) {
+		t.Errorf("expected [%s] for a new top-level file, got %v", a, changed)
+	}
+
+	// A watched file, such as a config file, is a single entry.
+	config := filepath.Join(root, ".aglx.yaml")
+	write(t, config, "spec: auto\n")
+	cw := New([]string{config})
+	write(t, config, "spec: claude-code\n")
+	if changed := cw.Poll([]string{config}); !slices.Equal(changed, []string{config}) {
+		t.Errorf("expected [%s] after editing a file, got %v", config, changed)
+	}
+
+	// New paths count as changed; dropped paths are forgotten.
+	c := filepath.Join(root, "c")
+	if changed := w.Poll([]string{b, c}); !slices.Equal(changed, []string{c}) {
+		t.Errorf("expected [%s], got %v", c, changed)
+	}
+	if changed := w.Poll([]string{a, b, c}); !slices.Equal(changed, []string{a}) {
+		t.Errorf("expected a forgotten path to count as new, got %v", changed)
+	}
+}
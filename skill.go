@@ -0,0 +1,313 @@
+package aglx
+
+import (
+	"fmt"
+	"io/fs"
+	"sort"
+
+	"github.com/biwakonbu/aglx/internal/checker"
+	"github.com/biwakonbu/aglx/internal/claude"
+	"github.com/biwakonbu/aglx/internal/skill"
+	"github.com/biwakonbu/aglx/internal/source"
+)
+
+// Spec is the specification a skill is validated against.
+type Spec string
+
+const (
+	// SpecAuto accepts both specifications (the allowed-tools format is not enforced).
+	SpecAuto Spec = ""
+	// SpecAgentSkills is the agentskills.io specification (space-separated allowed-tools).
+	SpecAgentSkills Spec = "agent-skills"
+	// SpecClaudeCode is the Claude Code specification (comma-separated allowed-tools).
+	SpecClaudeCode Spec = "claude-code"
+)
+
+// ParseSpec converts "auto" (or ""), "agent-skills" or "claude-code" into a Spec.
+func ParseSpec(s string) (Spec, error) {
+	spec, err := skill.ParseSpec(s)
+	return Spec(spec), err
+}
+
+// Severity is the level at which a rule reports its findings.
+type Severity int
+
+const (
+	// SeverityOff disables a rule.
+	SeverityOff Severity = iota
+	// SeverityWarning reports findings as warnings.
+	SeverityWarning
+	// SeverityError reports findings as errors.
+	SeverityError
+)
+
+// String returns "off", "warning" or "error".
+func (s Severity) String() string {
+	return skill.Severity(s).String()
+}
+
+// ParseSeverity converts "off", "warning" (or "warn") and "error" into a Severity.
+func ParseSeverity(s string) (Severity, error) {
+	sev, err := skill.ParseSeverity(s)
+	return Severity(sev), err
+}
+
+// Position identifies a location in a file. Line and Column are 1-based;
+// zero means unknown. A Position with only File set refers to a whole file
+// or directory.
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// String formats the position as "file:line:column", omitting unknown parts.
+func (p Position) String() string {
+	return source.Position(p).String()
+}
+
+// Finding is a single validation error or warning.
+type Finding struct {
+	// Rule is the stable ID of the rule that produced the finding, e.g. "name-format".
+	Rule string
+
+	// Field is the frontmatter field or directory the finding is about.
+	Field string
+
+	// Message describes the problem.
+	Message string
+
+	// Position is where the problem is (File is empty when unknown).
+	Position Position
+}
+
+// String formats the finding as "field: message (position) [rule]".
+func (f Finding) String() string {
+	s := fmt.Sprintf("%s: %s", f.Field, f.Message)
+	if pos := f.Position.String(); pos != "" {
+		s += " (" + pos + ")"
+	}
+	return s + " [" + f.Rule + "]"
+}
+
+// Skill is a parsed SKILL.md file.
+//
+// The frontmatter fields may be modified before calling Validate. Source
+// positions and the directory contents checked by the directory rules
+// always come from the parsed file.
+type Skill struct {
+	// Name, Description, License, Compatibility, AllowedTools and Metadata
+	// are the frontmatter fields.
+	Name          string
+	Description   string
+	License       string
+	Compatibility string
+	AllowedTools  string
+	Metadata      map[string]string
+
+	// Body is the Markdown content after the frontmatter.
+	Body string
+
+	// Path is the skill directory; its base name is what the name must match.
+	Path string
+
+	parsed *skill.Skill
+}
+
+// Parse reads and parses the SKILL.md file in the directory dirPath.
+func Parse(dirPath string) (*Skill, error) {
+	s, err := skill.Parse(dirPath)
+	if err != nil {
+		return nil, err
+	}
+	return newSkill(s), nil
+}
+
+// ParseFS reads and parses the SKILL.md file at the root of fsys, which holds
+// the skill directory. dirPath is the path the skill is reported under; its
+// base name is the directory name the skill name must match.
+func ParseFS(fsys fs.FS, dirPath string) (*Skill, error) {
+	s, err := skill.ParseFS(fsys, dirPath)
+	if err != nil {
+		return nil, err
+	}
+	return newSkill(s), nil
+}
+
+func newSkill(s *skill.Skill) *Skill {
+	if s == nil {
+		return nil
+	}
+	return &Skill{
+		Name:          s.Name,
+		Description:   s.Description,
+		License:       s.License,
+		Compatibility: s.Compatibility,
+		AllowedTools:  s.AllowedTools,
+		Metadata:      s.Metadata,
+		Body:          s.Body,
+		Path:          s.Path,
+		parsed:        s,
+	}
+}
+
+// internal returns the skill as the validator sees it, with the exported
+// fields applied on top of the parsed file.
+func (s *Skill) internal() *skill.Skill {
+	var in skill.Skill
+	if s.parsed != nil {
+		in = *s.parsed
+	}
+	in.Name = s.Name
+	in.Description = s.Description
+	in.License = s.License
+	in.Compatibility = s.Compatibility
+	in.AllowedTools = s.AllowedTools
+	in.Metadata = s.Metadata
+	in.Body = s.Body
+	in.Path = s.Path
+	return &in
+}
+
+// Tools returns the allowed-tools as a list. Both the space-separated
+// (Agent Skills) and comma-separated (Claude Code) formats are understood.
+func (s *Skill) Tools() []string {
+	return s.internal().ParsedAllowedTools()
+}
+
+// Options configures validation. The zero value (or nil) uses the defaults.
+type Options struct {
+	// Spec selects the specification. For Check, SpecAuto validates against
+	// both specifications and fills in both results.
+	Spec Spec
+
+	// Severities overrides the default severity of rules by rule ID,
+	// including the claude-md-* rules. SeverityOff disables a rule.
+	Severities map[string]Severity
+
+	// MaxBodyTokens and MaxBodyLines override the SKILL.md body size
+	// thresholds (0 uses the defaults).
+	MaxBodyTokens int
+	MaxBodyLines  int
+
+	// Tools lists custom and MCP tool names (e.g. "mcp__github") that
+	// allowed-tools may use in addition to the built-in tools.
+	Tools []string
+
+	// ClaudeMdWarningSize and ClaudeMdMaxSize override the CLAUDE.md body
+	// size thresholds in bytes (0 uses the defaults).
+	ClaudeMdWarningSize int
+	ClaudeMdMaxSize     int
+
+	// Concurrency is the maximum number of skills CheckMultiple checks at
+	// once (0 uses one per CPU, 1 checks them one by one).
+	Concurrency int
+}
+
+func (o *Options) checkOptions() *checker.CheckOptions {
+	if o == nil {
+		return &checker.CheckOptions{}
+	}
+	opts := &checker.CheckOptions{
+		Spec:          skill.Spec(o.Spec),
+		MaxBodyTokens: o.MaxBodyTokens,
+		MaxBodyLines:  o.MaxBodyLines,
+		Tools:         o.Tools,
+		Concurrency:   o.Concurrency,
+		ClaudeMd: claude.ValidationOptions{
+			WarningBodySize: o.ClaudeMdWarningSize,
+			MaxBodySize:     o.ClaudeMdMaxSize,
+		},
+	}
+	for id, sev := range o.Severities {
+		if _, ok := claude.RuleDescriptions[id]; ok {
+			if sev == SeverityOff {
+				if opts.ClaudeMd.Disabled == nil {
+					opts.ClaudeMd.Disabled = make(map[string]bool)
+				}
+				opts.ClaudeMd.Disabled[id] = true
+			}
+			continue
+		}
+		if opts.Severities == nil {
+			opts.Severities = make(map[string]skill.Severity)
+		}
+		opts.Severities[id] = skill.Severity(sev)
+	}
+	return opts
+}
+
+// ValidationResult holds the findings for one specification.
+type ValidationResult struct {
+	// Spec is the specification the skill was validated against.
+	Spec Spec
+
+	Errors   []Finding
+	Warnings []Finding
+}
+
+// Valid reports whether there are no errors.
+func (r *ValidationResult) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// Validate checks a skill against opts.Spec.
+func Validate(s *Skill, opts *Options) *ValidationResult {
+	o := opts.checkOptions()
+	return newValidationResult(o.Spec, skill.ValidateWithOptions(s.internal(), o.ValidationOptions(o.Spec)))
+}
+
+func newValidationResult(spec skill.Spec, r *skill.ValidationResult) *ValidationResult {
+	return &ValidationResult{
+		Spec:     Spec(spec),
+		Errors:   findings(r.Errors),
+		Warnings: findings(r.Warnings),
+	}
+}
+
+func findings(errs []skill.ValidationError) []Finding {
+	out := make([]Finding, len(errs))
+	for i, e := range errs {
+		out[i] = Finding{Rule: e.Rule, Field: e.Field, Message: e.Message, Position: Position(e.Pos)}
+	}
+	return out
+}
+
+// Rule describes a validation rule.
+type Rule struct {
+	// ID is the stable identifier used in findings, Options.Severities,
+	// config files and inline suppressions.
+	ID string
+
+	// Description is a one-line summary of what the rule checks.
+	Description string
+
+	// DefaultSeverity applies unless overridden by Options.Severities.
+	DefaultSeverity Severity
+
+	// Specs lists the specifications the rule applies to (empty means all).
+	// CLAUDE.md rules have no specs.
+	Specs []Spec
+}
+
+// Rules lists the SKILL.md rules in the order they run, followed by the
+// CLAUDE.md rules sorted by ID.
+func Rules() []Rule {
+	var rules []Rule
+	for _, r := range skill.Rules() {
+		rule := Rule{ID: r.ID, Description: r.Description, DefaultSeverity: Severity(r.DefaultSeverity)}
+		for _, spec := range r.Specs {
+			rule.Specs = append(rule.Specs, Spec(spec))
+		}
+		rules = append(rules, rule)
+	}
+	ids := make([]string, 0, len(claude.RuleDescriptions))
+	for id := range claude.RuleDescriptions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		rules = append(rules, Rule{ID: id, Description: claude.RuleDescriptions[id], DefaultSeverity: SeverityWarning})
+	}
+	return rules
+}